@@ -3,11 +3,20 @@
 package main
 
 import (
+	_ "portarius/internal/boleto/handler"
+	_ "portarius/internal/calendar/handler"
+	_ "portarius/internal/document/handler"
+	_ "portarius/internal/export/handler"
+	_ "portarius/internal/finance/handler"
+	_ "portarius/internal/guest/handler"
+	_ "portarius/internal/importer/handler"
 	_ "portarius/internal/inventory/handler"
 	_ "portarius/internal/package/handler"
+	_ "portarius/internal/reconciliation/handler"
 	_ "portarius/internal/reminder/handler"
 	_ "portarius/internal/reservation/handler"
 	_ "portarius/internal/resident/handler"
+	_ "portarius/internal/space/handler"
 	_ "portarius/internal/user/handler"
 	_ "portarius/internal/whatsapp/handler"
 )
@@ -0,0 +1,239 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	reservationDomain "portarius/internal/reservation/domain"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BoletoStatus string
+
+const (
+	StatusIssued    BoletoStatus = "EMITIDO"
+	StatusPaid      BoletoStatus = "PAGO"
+	StatusOverdue   BoletoStatus = "VENCIDO"
+	StatusCancelled BoletoStatus = "CANCELADO"
+)
+
+const (
+	BankBradesco      = "237"
+	defaultWallet     = "09"
+	defaultDueDays    = 3
+	ourNumberLength   = 11
+	maxBoletosPerItem = 999
+)
+
+// Boleto represents a FEBRABAN bank slip issued to collect the fee of a reservation
+// swagger:model
+type Boleto struct {
+	gorm.Model     `swaggerignore:"true"`
+	ReservationID  uint                           `json:"reservation_id" gorm:"not null;index"`
+	Reservation    *reservationDomain.Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID" swaggerignore:"true"`
+	BankCode       string                         `json:"bank_code" gorm:"type:varchar(3);not null"`
+	Agency         string                         `json:"agency" gorm:"type:varchar(4);not null"`
+	Account        string                         `json:"account" gorm:"type:varchar(7);not null"`
+	Wallet         string                         `json:"wallet" gorm:"type:varchar(2);not null"`
+	OurNumber      string                         `json:"our_number" gorm:"type:varchar(11);not null;uniqueIndex"`
+	OurNumberDigit string                         `json:"our_number_digit" gorm:"type:varchar(1);not null"`
+	DueDate        time.Time                      `json:"due_date" gorm:"not null"`
+	Amount         float64                        `json:"amount" gorm:"type:decimal(10,2);not null"`
+	Barcode        string                         `json:"barcode" gorm:"type:varchar(44);not null"`
+	DigitableLine  string                         `json:"digitable_line" gorm:"type:varchar(54);not null"`
+	Status         BoletoStatus                   `json:"status" gorm:"type:varchar(20);not null;default:'EMITIDO'"`
+	PaidAt         *time.Time                     `json:"paid_at" gorm:"type:timestamp"`
+	PaidAmount     float64                        `json:"paid_amount" gorm:"type:decimal(10,2);default:0"`
+	CancelledAt    *time.Time                     `json:"cancelled_at" gorm:"type:timestamp"`
+}
+
+// BoletoConfig represents the beneficiary account used to issue boletos
+type BoletoConfig struct {
+	BankCode            string
+	Agency              string
+	Account             string
+	Wallet              string
+	BeneficiaryName     string
+	BeneficiaryDocument string
+	DueDays             int
+}
+
+func ConfigFromEnv() (*BoletoConfig, error) {
+	config := &BoletoConfig{
+		BankCode:            envOrDefault("BOLETO_BANK_CODE", BankBradesco),
+		Agency:              os.Getenv("BOLETO_AGENCY"),
+		Account:             os.Getenv("BOLETO_ACCOUNT"),
+		Wallet:              envOrDefault("BOLETO_WALLET", defaultWallet),
+		BeneficiaryName:     os.Getenv("BOLETO_BENEFICIARY_NAME"),
+		BeneficiaryDocument: os.Getenv("BOLETO_BENEFICIARY_DOCUMENT"),
+		DueDays:             defaultDueDays,
+	}
+
+	if days, err := strconv.Atoi(os.Getenv("BOLETO_DUE_DAYS")); err == nil && days > 0 {
+		config.DueDays = days
+	}
+
+	if config.BankCode != BankBradesco {
+		return nil, fmt.Errorf("banco %s não suportado para emissão de boletos", config.BankCode)
+	}
+	if len(config.Agency) == 0 || len(config.Agency) > 4 || !isDigits(config.Agency) {
+		return nil, fmt.Errorf("agência do beneficiário não configurada")
+	}
+	if len(config.Account) == 0 || len(config.Account) > 7 || !isDigits(config.Account) {
+		return nil, fmt.Errorf("conta do beneficiário não configurada")
+	}
+	if len(config.Wallet) != 2 || !isDigits(config.Wallet) {
+		return nil, fmt.Errorf("carteira inválida: %s", config.Wallet)
+	}
+	if config.BeneficiaryName == "" {
+		return nil, fmt.Errorf("nome do beneficiário não configurado")
+	}
+
+	config.Agency = leftPadZeros(config.Agency, 4)
+	config.Account = leftPadZeros(config.Account, 7)
+
+	return config, nil
+}
+
+// DefaultDueDate returns the due date of a new boleto: DueDays from now, but never after the day before the event.
+func (c *BoletoConfig) DefaultDueDate(now time.Time, reservation *reservationDomain.Reservation) time.Time {
+	due := now.AddDate(0, 0, c.DueDays)
+	lastDay := reservation.StartTime.AddDate(0, 0, -1)
+	if lastDay.Before(due) && lastDay.After(now) {
+		due = lastDay
+	}
+	return time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
+}
+
+// NewBoleto builds the boleto of a reservation. The nosso número is the reservation ID followed by
+// the sequence of boletos issued for it, so reissuing never reuses a number.
+func NewBoleto(config *BoletoConfig, reservation *reservationDomain.Reservation, sequence int, dueDate time.Time) (*Boleto, error) {
+	if sequence < 1 || sequence > maxBoletosPerItem {
+		return nil, fmt.Errorf("limite de boletos da reserva atingido")
+	}
+	if reservation.ID > 99999999 {
+		return nil, fmt.Errorf("ID da reserva excede o tamanho do nosso número")
+	}
+
+	boleto := &Boleto{
+		ReservationID: reservation.ID,
+		BankCode:      config.BankCode,
+		Agency:        config.Agency,
+		Account:       config.Account,
+		Wallet:        config.Wallet,
+		OurNumber:     fmt.Sprintf("%08d%03d", reservation.ID, sequence),
+		DueDate:       dueDate,
+		Amount:        reservation.PaymentAmount,
+		Status:        StatusIssued,
+	}
+	boleto.OurNumberDigit = OurNumberCheckDigit(boleto.Wallet, boleto.OurNumber)
+
+	barcode, err := BuildBarcode(boleto.BankCode, boleto.DueDate, boleto.Amount, boleto.FreeField())
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := DigitableLine(barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	boleto.Barcode = barcode
+	boleto.DigitableLine = line
+	return boleto, nil
+}
+
+// FreeField returns the 25 digit free field of the Bradesco layout: agency, wallet, nosso número, account and a zero.
+func (b *Boleto) FreeField() string {
+	return b.Agency + b.Wallet + b.OurNumber + b.Account + "0"
+}
+
+// OurNumberCheckDigit computes the Bradesco nosso número check digit: modulo 11 with weights 2 to 7
+// over wallet and number, "P" when the remainder is 1.
+func OurNumberCheckDigit(wallet, ourNumber string) string {
+	remainder := modulo11(wallet+ourNumber, 7)
+	switch remainder {
+	case 0:
+		return "0"
+	case 1:
+		return "P"
+	default:
+		return strconv.Itoa(11 - remainder)
+	}
+}
+
+func (b *Boleto) FormattedOurNumber() string {
+	return fmt.Sprintf("%s/%s-%s", b.Wallet, b.OurNumber, b.OurNumberDigit)
+}
+
+// MatchesReference reports whether a nosso número read from a CNAB return file refers to this boleto.
+// Banks pad the field with zeros and some include the wallet or the check digit.
+func (b *Boleto) MatchesReference(reference string) bool {
+	reference = strings.TrimLeft(onlyDigitsOrP(reference), "0")
+	if reference == "" {
+		return false
+	}
+
+	candidates := []string{
+		b.OurNumber,
+		b.OurNumber + b.OurNumberDigit,
+		b.Wallet + b.OurNumber,
+		b.Wallet + b.OurNumber + b.OurNumberDigit,
+	}
+	for _, candidate := range candidates {
+		if strings.TrimLeft(candidate, "0") == reference {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *Boleto) IsOpen() bool {
+	return b.Status == StatusIssued || b.Status == StatusOverdue
+}
+
+// RefreshStatus marks an issued boleto as overdue once its due date has passed. It reports whether the status changed.
+func (b *Boleto) RefreshStatus(now time.Time) bool {
+	if b.Status != StatusIssued {
+		return false
+	}
+	if now.Before(b.DueDate.AddDate(0, 0, 1)) {
+		return false
+	}
+	b.Status = StatusOverdue
+	return true
+}
+
+func (b *Boleto) MarkAsPaid(paidAt time.Time, amount float64) {
+	b.Status = StatusPaid
+	b.PaidAt = &paidAt
+	b.PaidAmount = amount
+}
+
+func (b *Boleto) Cancel(now time.Time) {
+	b.Status = StatusCancelled
+	b.CancelledAt = &now
+}
+
+func onlyDigitsOrP(value string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == 'P' {
+			return r
+		}
+		return -1
+	}, strings.ToUpper(value))
+}
+
+func leftPadZeros(value string, size int) string {
+	return strings.Repeat("0", size-len(value)) + value
+}
+
+func envOrDefault(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
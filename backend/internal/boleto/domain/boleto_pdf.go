@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/boombuler/barcode/twooffive"
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfMargin        = 10.0
+	pdfContentWidth  = 190.0
+	pdfRowHeight     = 9.0
+	barcodeWidthMM   = 103.0
+	barcodeHeightMM  = 13.0
+	barcodeQuietZone = 5.0
+)
+
+// RenderPDF draws the payer receipt and the "ficha de compensação" of a boleto, with the
+// interleaved 2 of 5 barcode the banks read at the cashier.
+func RenderPDF(boleto *Boleto, config *BoletoConfig) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(false, pdfMargin)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	payer, payerDocument, description := payerDetails(boleto)
+
+	header := func(title string) {
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.CellFormat(30, pdfRowHeight, bankHeader(boleto.BankCode), "B", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(pdfContentWidth-30, pdfRowHeight, tr(title), "B", 1, "R", false, 0, "")
+	}
+
+	field := func(label, value string, width float64, newLine bool) {
+		x, y := pdf.GetXY()
+		pdf.SetFont("Helvetica", "", 6)
+		pdf.CellFormat(width, 3, tr(label), "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(width, pdfRowHeight-3, tr(value), "", 0, "L", false, 0, "")
+		pdf.Rect(x, y, width, pdfRowHeight, "D")
+		if newLine {
+			pdf.SetXY(pdfMargin, y+pdfRowHeight)
+		} else {
+			pdf.SetXY(x+width, y)
+		}
+	}
+
+	amount := fmt.Sprintf("R$ %.2f", boleto.Amount)
+	dueDate := boleto.DueDate.Format("02/01/2006")
+	agencyAccount := fmt.Sprintf("%s / %s", boleto.Agency, boleto.Account)
+
+	header("Recibo do Pagador")
+	field("Beneficiário", fmt.Sprintf("%s %s", config.BeneficiaryName, config.BeneficiaryDocument), 130, false)
+	field("Vencimento", dueDate, 60, true)
+	field("Pagador", fmt.Sprintf("%s %s", payer, payerDocument), 130, false)
+	field("Valor do documento", amount, 60, true)
+	field("Nosso número", boleto.FormattedOurNumber(), 50, false)
+	field("Agência / Código do beneficiário", agencyAccount, 50, false)
+	field("Linha digitável", boleto.DigitableLine, 90, true)
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.MultiCell(pdfContentWidth, 4, tr(description), "", "L", false)
+
+	pdf.SetY(pdf.GetY() + 6)
+	pdf.SetDashPattern([]float64{1, 1}, 0)
+	pdf.Line(pdfMargin, pdf.GetY(), pdfMargin+pdfContentWidth, pdf.GetY())
+	pdf.SetDashPattern([]float64{}, 0)
+	pdf.SetY(pdf.GetY() + 6)
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(30, pdfRowHeight, bankHeader(boleto.BankCode), "B", 0, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 11)
+	pdf.CellFormat(pdfContentWidth-30, pdfRowHeight, boleto.DigitableLine, "B", 1, "R", false, 0, "")
+	field("Local de pagamento", "Pagável em qualquer banco até o vencimento", 130, false)
+	field("Vencimento", dueDate, 60, true)
+	field("Beneficiário", fmt.Sprintf("%s %s", config.BeneficiaryName, config.BeneficiaryDocument), 130, false)
+	field("Agência / Código do beneficiário", agencyAccount, 60, true)
+	field("Data do documento", boleto.CreatedAt.Format("02/01/2006"), 35, false)
+	field("Número do documento", fmt.Sprintf("%d", boleto.ReservationID), 35, false)
+	field("Espécie doc.", "DS", 25, false)
+	field("Aceite", "N", 15, false)
+	field("Carteira", boleto.Wallet, 20, false)
+	field("Nosso número", boleto.FormattedOurNumber(), 60, true)
+	field("Instruções", "Não receber após o vencimento.", 130, false)
+	field("(=) Valor do documento", amount, 60, true)
+	field("Pagador", fmt.Sprintf("%s %s", payer, payerDocument), pdfContentWidth, true)
+
+	if err := drawBarcode(pdf, boleto.Barcode, pdfMargin+barcodeQuietZone, pdf.GetY()+4); err != nil {
+		return nil, err
+	}
+
+	pdf.SetY(pdf.GetY() + barcodeHeightMM + 6)
+	pdf.SetFont("Helvetica", "", 7)
+	pdf.CellFormat(pdfContentWidth, 4, tr("Autenticação mecânica - Ficha de Compensação"), "", 1, "R", false, 0, "")
+
+	if err := pdf.Error(); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func drawBarcode(pdf *fpdf.Fpdf, content string, x, y float64) error {
+	code, err := twooffive.Encode(content, true)
+	if err != nil {
+		return err
+	}
+
+	bounds := code.Bounds()
+	moduleWidth := barcodeWidthMM / float64(bounds.Dx())
+
+	pdf.SetFillColor(0, 0, 0)
+	for i := bounds.Min.X; i < bounds.Max.X; i++ {
+		if r, _, _, _ := code.At(i, bounds.Min.Y).RGBA(); r == 0 {
+			pdf.Rect(x+float64(i-bounds.Min.X)*moduleWidth, y, moduleWidth, barcodeHeightMM, "F")
+		}
+	}
+	pdf.SetY(y)
+	return nil
+}
+
+func bankHeader(bankCode string) string {
+	return fmt.Sprintf("%s-%d", bankCode, Modulo11BankDigit(bankCode))
+}
+
+// Modulo11BankDigit returns the check digit printed next to the bank code in the boleto header.
+func Modulo11BankDigit(bankCode string) int {
+	digit := 11 - modulo11(bankCode, 9)
+	if digit >= 10 {
+		return 0
+	}
+	return digit
+}
+
+func payerDetails(boleto *Boleto) (string, string, string) {
+	if boleto.Reservation == nil {
+		return "", "", ""
+	}
+
+	description := fmt.Sprintf("Taxa de reserva do Salão %s em %s", boleto.Reservation.GetLastCharFromSalon(), boleto.Reservation.StartTime.Format("02/01/2006"))
+
+	resident := boleto.Reservation.Resident
+	if resident == nil {
+		return "", "", description
+	}
+
+	return fmt.Sprintf("%s - Bloco %s Apto %s", resident.Name, resident.Block, resident.Apartment), resident.Document, description
+}
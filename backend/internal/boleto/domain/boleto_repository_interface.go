@@ -0,0 +1,10 @@
+package domain
+
+type IBoletoRepository interface {
+	GetByID(id uint) (*Boleto, error)
+	GetByReservation(reservationID uint) ([]Boleto, error)
+	GetByStatus(statuses ...BoletoStatus) ([]Boleto, error)
+	CountByReservation(reservationID uint) (int64, error)
+	Create(boleto *Boleto) error
+	Update(boleto *Boleto) error
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	currencyCodeReal = "9"
+	barcodeLength    = 44
+	freeFieldLength  = 25
+	maxAmountCents   = 9999999999
+)
+
+// dueDateFactorBase is day zero of the FEBRABAN due date factor. Once the factor reached 9999 on
+// 2025-02-21 it restarted from 1000, so the factor cycles every 9000 days.
+var dueDateFactorBase = time.Date(1997, time.October, 7, 0, 0, 0, 0, time.UTC)
+
+func DueDateFactor(dueDate time.Time) string {
+	day := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 0, 0, 0, 0, time.UTC)
+	days := int(day.Sub(dueDateFactorBase).Hours() / 24)
+	if days > 9999 {
+		days = (days-10000)%9000 + 1000
+	}
+	return fmt.Sprintf("%04d", days)
+}
+
+// BuildBarcode assembles the 44 digits of a boleto barcode: bank, currency, general check digit,
+// due date factor, amount and the bank specific free field.
+func BuildBarcode(bankCode string, dueDate time.Time, amount float64, freeField string) (string, error) {
+	if len(bankCode) != 3 || !isDigits(bankCode) {
+		return "", fmt.Errorf("código do banco inválido: %s", bankCode)
+	}
+	if len(freeField) != freeFieldLength || !isDigits(freeField) {
+		return "", fmt.Errorf("campo livre inválido: %s", freeField)
+	}
+
+	cents := int64(math.Round(amount * 100))
+	if cents <= 0 || cents > maxAmountCents {
+		return "", fmt.Errorf("valor do boleto inválido: %.2f", amount)
+	}
+
+	withoutDigit := bankCode + currencyCodeReal + DueDateFactor(dueDate) + fmt.Sprintf("%010d", cents) + freeField
+	digit := barcodeCheckDigit(withoutDigit)
+
+	return withoutDigit[:4] + strconv.Itoa(digit) + withoutDigit[4:], nil
+}
+
+// DigitableLine converts a barcode into the "linha digitável" printed on the boleto, with the
+// modulo 10 check digit of each of the first three fields.
+func DigitableLine(barcode string) (string, error) {
+	if len(barcode) != barcodeLength || !isDigits(barcode) {
+		return "", fmt.Errorf("código de barras inválido: %s", barcode)
+	}
+
+	field1 := barcode[0:4] + barcode[19:24]
+	field2 := barcode[24:34]
+	field3 := barcode[34:44]
+
+	field1 += strconv.Itoa(Modulo10(field1))
+	field2 += strconv.Itoa(Modulo10(field2))
+	field3 += strconv.Itoa(Modulo10(field3))
+
+	return fmt.Sprintf("%s.%s %s.%s %s.%s %s %s",
+		field1[:5], field1[5:],
+		field2[:5], field2[5:],
+		field3[:5], field3[5:],
+		barcode[4:5],
+		barcode[5:19],
+	), nil
+}
+
+// BarcodeFromDigitableLine rebuilds the barcode from a typed "linha digitável", validating every check digit.
+func BarcodeFromDigitableLine(line string) (string, error) {
+	digits := onlyDigits(line)
+	if len(digits) != 47 {
+		return "", fmt.Errorf("linha digitável deve ter 47 dígitos")
+	}
+
+	fields := []string{digits[0:10], digits[10:21], digits[21:32]}
+	for i, field := range fields {
+		if Modulo10(field[:len(field)-1]) != int(field[len(field)-1]-'0') {
+			return "", fmt.Errorf("dígito verificador do campo %d inválido", i+1)
+		}
+	}
+
+	barcode := digits[0:4] + digits[32:33] + digits[33:47] + digits[4:9] + digits[10:20] + digits[21:31]
+	if barcodeCheckDigit(barcode[:4]+barcode[5:]) != int(barcode[4]-'0') {
+		return "", fmt.Errorf("dígito verificador geral inválido")
+	}
+
+	return barcode, nil
+}
+
+func Modulo10(digits string) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := int(digits[i]-'0') * weight
+		sum += product/10 + product%10
+		if weight == 2 {
+			weight = 1
+		} else {
+			weight = 2
+		}
+	}
+	return (10 - sum%10) % 10
+}
+
+// modulo11 returns the remainder of the weighted sum using weights from 2 up to maxWeight, right to left.
+func modulo11(digits string, maxWeight int) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		weight++
+		if weight > maxWeight {
+			weight = 2
+		}
+	}
+	return sum % 11
+}
+
+func barcodeCheckDigit(digits string) int {
+	digit := 11 - modulo11(digits, 9)
+	if digit == 0 || digit == 10 || digit == 11 {
+		return 1
+	}
+	return digit
+}
+
+func isDigits(value string) bool {
+	if value == "" {
+		return false
+	}
+	for _, r := range value {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func onlyDigits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
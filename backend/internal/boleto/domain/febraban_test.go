@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"portarius/internal/boleto/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDueDateFactor(t *testing.T) {
+	tests := []struct {
+		date     time.Time
+		expected string
+	}{
+		{time.Date(2000, time.July, 3, 0, 0, 0, 0, time.UTC), "1000"},
+		{time.Date(2025, time.February, 21, 0, 0, 0, 0, time.UTC), "9999"},
+		{time.Date(2025, time.February, 22, 0, 0, 0, 0, time.UTC), "1000"},
+		{time.Date(2025, time.March, 24, 0, 0, 0, 0, time.UTC), "1030"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, domain.DueDateFactor(tt.date), tt.date.String())
+	}
+}
+
+func TestModulo10(t *testing.T) {
+	assert.Equal(t, 4, domain.Modulo10("261533"))
+	assert.Equal(t, 0, domain.Modulo10("0"))
+}
+
+func TestNewBoleto(t *testing.T) {
+	config := &domain.BoletoConfig{BankCode: domain.BankBradesco, Agency: "1234", Account: "0012345", Wallet: "09"}
+	reservation := &reservationDomain.Reservation{Model: gorm.Model{ID: 12}, PaymentAmount: 70}
+
+	boleto, err := domain.NewBoleto(config, reservation, 1, time.Date(2025, time.March, 24, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "00000012001", boleto.OurNumber)
+	assert.Len(t, boleto.Barcode, 44)
+	assert.Equal(t, "2379", boleto.Barcode[:4])
+	assert.Equal(t, "10300000007000", boleto.Barcode[5:19])
+	assert.Equal(t, boleto.FreeField(), boleto.Barcode[19:])
+
+	barcode, err := domain.BarcodeFromDigitableLine(boleto.DigitableLine)
+	assert.NoError(t, err)
+	assert.Equal(t, boleto.Barcode, barcode)
+
+	tampered := strings.Replace(boleto.DigitableLine, "7000", "7001", 1)
+	_, err = domain.BarcodeFromDigitableLine(tampered)
+	assert.Error(t, err)
+
+	assert.True(t, boleto.MatchesReference("000000012001"+boleto.OurNumberDigit))
+	assert.True(t, boleto.MatchesReference("0900000012001"))
+	assert.False(t, boleto.MatchesReference("00000012002"))
+}
+
+func TestOurNumberCheckDigit(t *testing.T) {
+	assert.Equal(t, "8", domain.OurNumberCheckDigit("19", "00000000002"))
+	assert.Equal(t, "P", domain.OurNumberCheckDigit("19", "00000000001"))
+}
@@ -0,0 +1,293 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"portarius/internal/boleto/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type BoletoHandler struct {
+	repo            domain.IBoletoRepository
+	reservationRepo reservationDomain.IReservationRepository
+}
+
+func NewBoletoHandler(repo domain.IBoletoRepository, reservationRepo reservationDomain.IReservationRepository) *BoletoHandler {
+	return &BoletoHandler{repo: repo, reservationRepo: reservationRepo}
+}
+
+// IssueBoletoRequest represents the reservation to charge and an optional due date
+// swagger:model
+type IssueBoletoRequest struct {
+	ReservationID uint   `json:"reservation_id" binding:"required"`
+	DueDate       string `json:"due_date" example:"2025-03-20"`
+}
+
+// Issue godoc
+// @Summary Issue a boleto for a reservation
+// @Description Issues a FEBRABAN boleto for the fee of a reservation paid by boleto. Any open boleto of the reservation is cancelled.
+// @Tags Boletos
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body IssueBoletoRequest true "Reservation and due date (YYYY-MM-DD)"
+// @Success 201 {object} domain.Boleto
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /boletos [post]
+func (c *BoletoHandler) Issue(ctx *gin.Context) {
+	var input IssueBoletoRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	config, err := domain.ConfigFromEnv()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := c.reservationRepo.GetByID(input.ReservationID)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Reserva não encontrada"})
+		return
+	}
+
+	if reservation.PaymentMethod != reservationDomain.PaymentMethodBoleto {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A forma de pagamento da reserva não é boleto"})
+		return
+	}
+
+	if reservation.Status == reservationDomain.StatusCancelled || reservation.PaymentStatus != reservationDomain.PaymentPending {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A reserva não possui pagamento pendente"})
+		return
+	}
+
+	now := time.Now()
+	dueDate := config.DefaultDueDate(now, reservation)
+	if input.DueDate != "" {
+		dueDate, err = time.ParseInLocation("2006-01-02", input.DueDate, now.Location())
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data de vencimento inválida"})
+			return
+		}
+		if dueDate.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "A data de vencimento não pode estar no passado"})
+			return
+		}
+	}
+
+	issued, err := c.repo.CountByReservation(reservation.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	boleto, err := domain.NewBoleto(config, reservation, int(issued)+1, dueDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	previous, err := c.repo.GetByReservation(reservation.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for i := range previous {
+		if !previous[i].IsOpen() {
+			continue
+		}
+		previous[i].Cancel(now)
+		if err := c.repo.Update(&previous[i]); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if err := c.repo.Create(boleto); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, boleto)
+}
+
+// GetByID godoc
+// @Summary Get a boleto by ID
+// @Description Retrieve a boleto with its barcode, linha digitável and status
+// @Tags Boletos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Boleto ID"
+// @Success 200 {object} domain.Boleto
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /boletos/{id} [get]
+func (c *BoletoHandler) GetByID(ctx *gin.Context) {
+	boleto, ok := c.findBoleto(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, boleto)
+}
+
+// GetByReservation godoc
+// @Summary List boletos of a reservation
+// @Description Retrieve every boleto issued for a reservation, newest first
+// @Tags Boletos
+// @Produce json
+// @Security BearerAuth
+// @Param reservationId path int true "Reservation ID"
+// @Success 200 {array} domain.Boleto
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /boletos/reservation/{reservationId} [get]
+func (c *BoletoHandler) GetByReservation(ctx *gin.Context) {
+	reservationID, err := strconv.ParseUint(ctx.Param("reservationId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID da reserva inválido"})
+		return
+	}
+
+	boletos, err := c.repo.GetByReservation(uint(reservationID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	for i := range boletos {
+		if boletos[i].RefreshStatus(now) {
+			if err := c.repo.Update(&boletos[i]); err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, boletos)
+}
+
+// GetPDF godoc
+// @Summary Download a boleto PDF
+// @Description Renders the boleto with the payer receipt, linha digitável and barcode
+// @Tags Boletos
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path int true "Boleto ID"
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /boletos/{id}/pdf [get]
+func (c *BoletoHandler) GetPDF(ctx *gin.Context) {
+	boleto, ok := c.findBoleto(ctx)
+	if !ok {
+		return
+	}
+
+	if boleto.Status == domain.StatusCancelled {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Boleto cancelado"})
+		return
+	}
+
+	config, err := domain.ConfigFromEnv()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	pdf, err := domain.RenderPDF(boleto, config)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=boleto-%s.pdf", boleto.OurNumber))
+	ctx.Data(http.StatusOK, "application/pdf", pdf)
+}
+
+// Cancel godoc
+// @Summary Cancel a boleto
+// @Description Cancels an open boleto so it is no longer reconciled against return files
+// @Tags Boletos
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Boleto ID"
+// @Success 200 {object} domain.Boleto
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /boletos/{id}/cancel [put]
+func (c *BoletoHandler) Cancel(ctx *gin.Context) {
+	boleto, ok := c.findBoleto(ctx)
+	if !ok {
+		return
+	}
+
+	if !boleto.IsOpen() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Somente boletos em aberto podem ser cancelados"})
+		return
+	}
+
+	boleto.Cancel(time.Now())
+	if err := c.repo.Update(boleto); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, boleto)
+}
+
+// ListBoletoStatuses godoc
+// @Summary List boleto statuses
+// @Description Returns the list of possible boleto statuses
+// @Tags Boletos
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.BoletoStatus
+// @Router /boletos/status [get]
+func (c *BoletoHandler) ListBoletoStatuses(ctx *gin.Context) {
+	statuses := []domain.BoletoStatus{
+		domain.StatusIssued,
+		domain.StatusPaid,
+		domain.StatusOverdue,
+		domain.StatusCancelled,
+	}
+	ctx.JSON(http.StatusOK, statuses)
+}
+
+func (c *BoletoHandler) findBoleto(ctx *gin.Context) (*domain.Boleto, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return nil, false
+	}
+
+	boleto, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Boleto não encontrado"})
+		return nil, false
+	}
+
+	if boleto.RefreshStatus(time.Now()) {
+		if err := c.repo.Update(boleto); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return nil, false
+		}
+	}
+
+	return boleto, true
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"portarius/internal/boleto/domain"
+
+	"gorm.io/gorm"
+)
+
+type boletoRepository struct {
+	db *gorm.DB
+}
+
+func NewBoletoRepository(db *gorm.DB) domain.IBoletoRepository {
+	return &boletoRepository{db: db}
+}
+
+func (r *boletoRepository) GetByID(id uint) (*domain.Boleto, error) {
+	var boleto domain.Boleto
+	err := r.db.Preload("Reservation.Resident").First(&boleto, id).Error
+	return &boleto, err
+}
+
+func (r *boletoRepository) GetByReservation(reservationID uint) ([]domain.Boleto, error) {
+	var boletos []domain.Boleto
+	err := r.db.Where("reservation_id = ?", reservationID).Order("created_at DESC").Find(&boletos).Error
+	return boletos, err
+}
+
+func (r *boletoRepository) GetByStatus(statuses ...domain.BoletoStatus) ([]domain.Boleto, error) {
+	var boletos []domain.Boleto
+	err := r.db.Where("status IN ?", statuses).Order("due_date ASC").Find(&boletos).Error
+	return boletos, err
+}
+
+func (r *boletoRepository) CountByReservation(reservationID uint) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&domain.Boleto{}).Where("reservation_id = ?", reservationID).Count(&count).Error
+	return count, err
+}
+
+func (r *boletoRepository) Create(boleto *domain.Boleto) error {
+	return r.db.Omit("Reservation").Create(boleto).Error
+}
+
+func (r *boletoRepository) Update(boleto *domain.Boleto) error {
+	return r.db.Omit("Reservation").Save(boleto).Error
+}
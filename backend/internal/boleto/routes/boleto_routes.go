@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"portarius/internal/boleto/domain"
+	boletoHandler "portarius/internal/boleto/handler"
+	"portarius/internal/boleto/repository"
+	reservationDomain "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterBoletoRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	var (
+		repo            domain.IBoletoRepository                 = repository.NewBoletoRepository(db)
+		reservationRepo reservationDomain.IReservationRepository = reservationRepository.NewReservationRepository(db)
+	)
+
+	handler := boletoHandler.NewBoletoHandler(repo, reservationRepo)
+
+	boletos := router.Group("/boletos")
+	{
+		boletos.POST("/", handler.Issue)
+		boletos.GET("/status", handler.ListBoletoStatuses)
+		boletos.GET("/reservation/:reservationId", handler.GetByReservation)
+		boletos.GET("/:id", handler.GetByID)
+		boletos.GET("/:id/pdf", handler.GetPDF)
+		boletos.PUT("/:id/cancel", handler.Cancel)
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	reservationDomain "portarius/internal/reservation/domain"
+	residentDomain "portarius/internal/resident/domain"
+
+	"gorm.io/gorm"
+)
+
+type FeedScope string
+
+const (
+	FeedScopeSpace    FeedScope = "ESPACO"
+	FeedScopeResident FeedScope = "MORADOR"
+)
+
+// CalendarFeed represents a tokenized iCal subscription for a space or a resident
+// swagger:model
+type CalendarFeed struct {
+	gorm.Model `swaggerignore:"true"`
+	Token      string                      `json:"token" gorm:"type:varchar(64);not null;uniqueIndex"`
+	Scope      FeedScope                   `json:"scope" gorm:"type:varchar(10);not null"`
+	Space      reservationDomain.SpaceType `json:"space" gorm:"type:varchar(10)"`
+	ResidentID *uint                       `json:"resident_id"`
+	Resident   *residentDomain.Resident    `json:"resident" gorm:"foreignKey:ResidentID" swaggerignore:"true"`
+	Name       string                      `json:"name"`
+}
+
+func (f *CalendarFeed) BeforeCreate(tx *gorm.DB) error {
+	if f.Token != "" {
+		return nil
+	}
+
+	token, err := GenerateFeedToken()
+	if err != nil {
+		return err
+	}
+	f.Token = token
+	return nil
+}
+
+func GenerateFeedToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
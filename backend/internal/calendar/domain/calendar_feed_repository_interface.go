@@ -0,0 +1,9 @@
+package domain
+
+type ICalendarFeedRepository interface {
+	GetAll() ([]CalendarFeed, error)
+	GetByID(id uint) (*CalendarFeed, error)
+	GetByToken(token string) (*CalendarFeed, error)
+	Create(feed *CalendarFeed) error
+	Delete(id uint) error
+}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"fmt"
+	reservationDomain "portarius/internal/reservation/domain"
+	"strings"
+	"time"
+)
+
+const (
+	icalDateTimeFormat = "20060102T150405Z"
+	icalLineLimit      = 75
+	icalDefaultEvent   = 12 * time.Hour
+)
+
+func BuildICS(calendarName string, reservations []reservationDomain.Reservation, includeResident bool) string {
+	now := time.Now().UTC()
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Portarius//Reservas//PT-BR",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:" + escapeText(calendarName),
+		"X-WR-TIMEZONE:UTC",
+	}
+
+	for i := range reservations {
+		lines = append(lines, buildEvent(&reservations[i], includeResident, now)...)
+	}
+
+	lines = append(lines, "END:VCALENDAR")
+
+	var builder strings.Builder
+	for _, line := range lines {
+		builder.WriteString(foldLine(line))
+		builder.WriteString("\r\n")
+	}
+	return builder.String()
+}
+
+func buildEvent(reservation *reservationDomain.Reservation, includeResident bool, now time.Time) []string {
+	start := reservation.StartTime.UTC()
+	end := reservation.EndTime.UTC()
+	if !end.After(start) {
+		end = start.Add(icalDefaultEvent)
+	}
+
+	lines := []string{
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:reservation-%d@portarius", reservation.ID),
+		"DTSTAMP:" + now.Format(icalDateTimeFormat),
+		"DTSTART:" + start.Format(icalDateTimeFormat),
+		"DTEND:" + end.Format(icalDateTimeFormat),
+		"SUMMARY:" + escapeText(EventTitle(reservation, includeResident)),
+		"STATUS:" + eventStatus(reservation.Status),
+		fmt.Sprintf("SEQUENCE:%d", eventSequence(reservation)),
+		"LOCATION:" + escapeText(spaceLabel(reservation)),
+	}
+
+	if !reservation.UpdatedAt.IsZero() {
+		lines = append(lines, "LAST-MODIFIED:"+reservation.UpdatedAt.UTC().Format(icalDateTimeFormat))
+	}
+
+	if description := eventDescription(reservation); description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(description))
+	}
+
+	if reservation.Status == reservationDomain.StatusCancelled {
+		lines = append(lines, "TRANSP:TRANSPARENT")
+	} else {
+		lines = append(lines, "TRANSP:OPAQUE")
+	}
+
+	return append(lines, "END:VEVENT")
+}
+
+func EventTitle(reservation *reservationDomain.Reservation, includeResident bool) string {
+	var status string
+	switch reservation.Status {
+	case reservationDomain.StatusPending:
+		status = "Pré-reserva"
+	case reservationDomain.StatusConfirmed:
+		status = "Reserva confirmada"
+	case reservationDomain.StatusKeysTaken:
+		status = "Chaves retiradas"
+	case reservationDomain.StatusKeysReturned:
+		status = "Reserva concluída"
+	case reservationDomain.StatusCancelled:
+		status = "Reserva cancelada"
+	default:
+		status = "Reserva"
+	}
+
+	title := fmt.Sprintf("[%s] %s", spaceLabel(reservation), status)
+
+	if includeResident && reservation.Resident != nil {
+		title += fmt.Sprintf(" - %s (Bloco %s %s)", reservation.Resident.Name, reservation.Resident.Block, reservation.Resident.Apartment)
+	}
+
+	return title
+}
+
+func eventStatus(status reservationDomain.ReservationStatus) string {
+	switch status {
+	case reservationDomain.StatusPending:
+		return "TENTATIVE"
+	case reservationDomain.StatusCancelled:
+		return "CANCELLED"
+	default:
+		return "CONFIRMED"
+	}
+}
+
+func eventSequence(reservation *reservationDomain.Reservation) int64 {
+	if reservation.UpdatedAt.IsZero() || !reservation.UpdatedAt.After(reservation.CreatedAt) {
+		return 0
+	}
+	return int64(reservation.UpdatedAt.Sub(reservation.CreatedAt) / time.Second)
+}
+
+func eventDescription(reservation *reservationDomain.Reservation) string {
+	parts := []string{}
+	if reservation.Description != "" {
+		parts = append(parts, reservation.Description)
+	}
+	if reservation.Status == reservationDomain.StatusCancelled && reservation.CancellationReason != "" {
+		parts = append(parts, "Motivo do cancelamento: "+reservation.CancellationReason)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func spaceLabel(reservation *reservationDomain.Reservation) string {
+	return "Salão " + reservation.GetLastCharFromSalon()
+}
+
+func escapeText(text string) string {
+	replacer := strings.NewReplacer(
+		"\\", "\\\\",
+		";", "\\;",
+		",", "\\,",
+		"\r\n", "\\n",
+		"\n", "\\n",
+	)
+	return replacer.Replace(text)
+}
+
+func foldLine(line string) string {
+	if len(line) <= icalLineLimit {
+		return line
+	}
+
+	var builder strings.Builder
+	limit := icalLineLimit
+	size := 0
+
+	for _, r := range line {
+		runeSize := len(string(r))
+		if size+runeSize > limit {
+			builder.WriteString("\r\n ")
+			size = 0
+			limit = icalLineLimit - 1
+		}
+		builder.WriteRune(r)
+		size += runeSize
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,106 @@
+package domain_test
+
+import (
+	"portarius/internal/calendar/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	residentDomain "portarius/internal/resident/domain"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newReservation(id uint, status reservationDomain.ReservationStatus) reservationDomain.Reservation {
+	created := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+	start := time.Date(2025, time.March, 15, 18, 0, 0, 0, time.UTC)
+	return reservationDomain.Reservation{
+		Model:     gorm.Model{ID: id, CreatedAt: created, UpdatedAt: created},
+		Space:     reservationDomain.Salon1,
+		StartTime: start,
+		EndTime:   start.Add(6 * time.Hour),
+		Status:    status,
+	}
+}
+
+// unfold joins the continuation lines of an iCalendar document back into its content lines
+func unfold(ics string) []string {
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(ics, "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func findLine(t *testing.T, lines []string, prefix string) string {
+	t.Helper()
+	for _, line := range lines {
+		if strings.HasPrefix(line, prefix) {
+			return line
+		}
+	}
+	require.Failf(t, "line not found", "no line starting with %q", prefix)
+	return ""
+}
+
+func TestBuildICSFoldsLongLines(t *testing.T) {
+	reservation := newReservation(1, reservationDomain.StatusConfirmed)
+	reservation.Description = strings.Repeat("Aniversário da Conceição com decoração ", 5)
+
+	ics := domain.BuildICS("Reservas", []reservationDomain.Reservation{reservation}, false)
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		assert.LessOrEqual(t, len(line), 75, "line longer than 75 octets: %q", line)
+		assert.True(t, utf8.ValidString(line), "multibyte character split across lines: %q", line)
+	}
+
+	description := findLine(t, unfold(ics), "DESCRIPTION:")
+	assert.Equal(t, "DESCRIPTION:"+reservation.Description, description)
+}
+
+func TestBuildICSEscapesText(t *testing.T) {
+	reservation := newReservation(2, reservationDomain.StatusConfirmed)
+	reservation.Description = "Festa; bolo, doces\nsaída às 22h \\ portaria"
+	reservation.Resident = &residentDomain.Resident{Name: "Silva, Ana", Block: "A", Apartment: "30"}
+
+	lines := unfold(domain.BuildICS("Salões; bloco A, B", []reservationDomain.Reservation{reservation}, true))
+
+	assert.Equal(t, `X-WR-CALNAME:Salões\; bloco A\, B`, findLine(t, lines, "X-WR-CALNAME:"))
+	assert.Equal(t, `DESCRIPTION:Festa\; bolo\, doces\nsaída às 22h \\ portaria`, findLine(t, lines, "DESCRIPTION:"))
+	assert.Equal(t, `SUMMARY:[Salão 1] Reserva confirmada - Silva\, Ana (Bloco A 30)`, findLine(t, lines, "SUMMARY:"))
+}
+
+func TestBuildICSCancelledReservation(t *testing.T) {
+	reservation := newReservation(3, reservationDomain.StatusCancelled)
+	reservation.CancellationReason = "chuva"
+	reservation.UpdatedAt = reservation.CreatedAt.Add(90 * time.Second)
+
+	lines := unfold(domain.BuildICS("Reservas", []reservationDomain.Reservation{reservation}, false))
+
+	assert.Equal(t, "UID:reservation-3@portarius", findLine(t, lines, "UID:"))
+	assert.Equal(t, "STATUS:CANCELLED", findLine(t, lines, "STATUS:"))
+	assert.Equal(t, "SEQUENCE:90", findLine(t, lines, "SEQUENCE:"))
+	assert.Equal(t, "TRANSP:TRANSPARENT", findLine(t, lines, "TRANSP:"))
+	assert.Equal(t, "DESCRIPTION:Motivo do cancelamento: chuva", findLine(t, lines, "DESCRIPTION:"))
+}
+
+func TestBuildICSEventStatusAndSequence(t *testing.T) {
+	tests := []struct {
+		status   reservationDomain.ReservationStatus
+		expected string
+	}{
+		{reservationDomain.StatusPending, "STATUS:TENTATIVE"},
+		{reservationDomain.StatusConfirmed, "STATUS:CONFIRMED"},
+		{reservationDomain.StatusKeysReturned, "STATUS:CONFIRMED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			reservation := newReservation(4, tt.status)
+			lines := unfold(domain.BuildICS("Reservas", []reservationDomain.Reservation{reservation}, false))
+
+			assert.Equal(t, tt.expected, findLine(t, lines, "STATUS:"))
+			assert.Equal(t, "SEQUENCE:0", findLine(t, lines, "SEQUENCE:"))
+			assert.Equal(t, "TRANSP:OPAQUE", findLine(t, lines, "TRANSP:"))
+		})
+	}
+}
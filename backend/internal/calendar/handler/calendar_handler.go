@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"portarius/internal/calendar/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	residentDomain "portarius/internal/resident/domain"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CalendarHandler struct {
+	repo            domain.ICalendarFeedRepository
+	reservationRepo reservationDomain.IReservationRepository
+	residentRepo    residentDomain.IResidentRepository
+}
+
+func NewCalendarHandler(repo domain.ICalendarFeedRepository, reservationRepo reservationDomain.IReservationRepository, residentRepo residentDomain.IResidentRepository) *CalendarHandler {
+	return &CalendarHandler{
+		repo:            repo,
+		reservationRepo: reservationRepo,
+		residentRepo:    residentRepo,
+	}
+}
+
+// GetFeed godoc
+// @Summary Get an iCal feed
+// @Description Returns the reservations of a space or resident as an iCalendar (.ics) feed. The token in the URL authenticates the subscription.
+// @Tags Calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token (optionally followed by .ics)"
+// @Success 200 {string} string "iCalendar feed"
+// @Failure 404
+// @Failure 500
+// @Router /calendar/{token} [get]
+func (c *CalendarHandler) GetFeed(ctx *gin.Context) {
+	token := strings.TrimSuffix(ctx.Param("token"), ".ics")
+
+	feed, err := c.repo.GetByToken(token)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Calendário não encontrado"})
+		return
+	}
+
+	var reservations []reservationDomain.Reservation
+
+	switch feed.Scope {
+	case domain.FeedScopeSpace:
+		reservations, err = c.reservationRepo.GetBySpace(string(feed.Space))
+	case domain.FeedScopeResident:
+		if feed.ResidentID == nil {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Calendário não encontrado"})
+			return
+		}
+		reservations, err = c.reservationRepo.GetByResident(*feed.ResidentID)
+	default:
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Calendário não encontrado"})
+		return
+	}
+
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ics := domain.BuildICS(feed.Name, reservations, feed.Scope == domain.FeedScopeSpace)
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=\"%s.ics\"", token))
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", []byte(ics))
+}
+
+// GetAll godoc
+// @Summary List calendar feeds
+// @Description Returns all iCal subscriptions with their tokens
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.CalendarFeed
+// @Failure 401
+// @Failure 500
+// @Router /calendar-feeds [get]
+func (c *CalendarHandler) GetAll(ctx *gin.Context) {
+	feeds, err := c.repo.GetAll()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, feeds)
+}
+
+// Create godoc
+// @Summary Create a calendar feed
+// @Description Creates a tokenized iCal subscription for a space (scope ESPACO) or a resident (scope MORADOR)
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param feed body domain.CalendarFeed true "Feed data (scope, space or resident_id, name)"
+// @Success 201 {object} map[string]interface{}
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /calendar-feeds [post]
+func (c *CalendarHandler) Create(ctx *gin.Context) {
+	var input domain.CalendarFeed
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	feed := domain.CalendarFeed{
+		Scope: input.Scope,
+		Name:  strings.TrimSpace(input.Name),
+	}
+
+	switch input.Scope {
+	case domain.FeedScopeSpace:
+		if !reservationDomain.IsValidSpaceType(string(input.Space)) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+			return
+		}
+		feed.Space = input.Space
+		if feed.Name == "" {
+			feed.Name = "Portarius - " + string(input.Space)
+		}
+	case domain.FeedScopeResident:
+		if input.ResidentID == nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Morador é obrigatório"})
+			return
+		}
+		resident, err := c.residentRepo.GetByID(*input.ResidentID)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Morador não encontrado"})
+			return
+		}
+		feed.ResidentID = &resident.ID
+		if feed.Name == "" {
+			feed.Name = "Portarius - " + resident.Name
+		}
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Escopo inválido"})
+		return
+	}
+
+	if err := c.repo.Create(&feed); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{
+		"feed": feed,
+		"url":  "/api/calendar/" + feed.Token + ".ics",
+	})
+}
+
+// Delete godoc
+// @Summary Delete a calendar feed
+// @Description Revokes an iCal subscription, invalidating its token
+// @Tags Calendar
+// @Security BearerAuth
+// @Param id path int true "Feed ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /calendar-feeds/{id} [delete]
+func (c *CalendarHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := c.repo.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Calendário excluído com sucesso"})
+}
+
+// ListFeedScopes godoc
+// @Summary List calendar feed scopes
+// @Description Returns the list of possible calendar feed scopes
+// @Tags Calendar
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.FeedScope
+// @Router /calendar-feeds/scopes [get]
+func (c *CalendarHandler) ListFeedScopes(ctx *gin.Context) {
+	scopes := []domain.FeedScope{
+		domain.FeedScopeSpace,
+		domain.FeedScopeResident,
+	}
+
+	ctx.JSON(http.StatusOK, scopes)
+}
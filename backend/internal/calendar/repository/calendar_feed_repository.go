@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"portarius/internal/calendar/domain"
+
+	"gorm.io/gorm"
+)
+
+type calendarFeedRepository struct {
+	db *gorm.DB
+}
+
+func NewCalendarFeedRepository(db *gorm.DB) domain.ICalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+func (r *calendarFeedRepository) GetAll() ([]domain.CalendarFeed, error) {
+	var feeds []domain.CalendarFeed
+	err := r.db.Preload("Resident").Find(&feeds).Error
+	return feeds, err
+}
+
+func (r *calendarFeedRepository) GetByID(id uint) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	err := r.db.First(&feed, id).Error
+	return &feed, err
+}
+
+func (r *calendarFeedRepository) GetByToken(token string) (*domain.CalendarFeed, error) {
+	var feed domain.CalendarFeed
+	err := r.db.Preload("Resident").Where("token = ?", token).First(&feed).Error
+	return &feed, err
+}
+
+func (r *calendarFeedRepository) Create(feed *domain.CalendarFeed) error {
+	return r.db.Create(feed).Error
+}
+
+func (r *calendarFeedRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.CalendarFeed{}, id).Error
+}
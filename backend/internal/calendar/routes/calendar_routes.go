@@ -0,0 +1,45 @@
+package routes
+
+import (
+	"portarius/internal/calendar/domain"
+	calendarHandler "portarius/internal/calendar/handler"
+	"portarius/internal/calendar/repository"
+	reservationDomain "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+	residentDomain "portarius/internal/resident/domain"
+	residentRepository "portarius/internal/resident/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func newCalendarHandler(db *gorm.DB) *calendarHandler.CalendarHandler {
+	var (
+		repo            domain.ICalendarFeedRepository           = repository.NewCalendarFeedRepository(db)
+		reservationRepo reservationDomain.IReservationRepository = reservationRepository.NewReservationRepository(db)
+		residentRepo    residentDomain.IResidentRepository       = residentRepository.NewResidentRepository(db)
+	)
+
+	return calendarHandler.NewCalendarHandler(repo, reservationRepo, residentRepo)
+}
+
+func RegisterCalendarRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	handler := newCalendarHandler(db)
+
+	calendar := router.Group("/calendar")
+	{
+		calendar.GET("/:token", handler.GetFeed)
+	}
+}
+
+func RegisterCalendarProtectedRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	handler := newCalendarHandler(db)
+
+	feeds := router.Group("/calendar-feeds")
+	{
+		feeds.GET("/", handler.GetAll)
+		feeds.POST("/", handler.Create)
+		feeds.DELETE("/:id", handler.Delete)
+		feeds.GET("/scopes", handler.ListFeedScopes)
+	}
+}
@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	financeDomain "portarius/internal/finance/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	spaceDomain "portarius/internal/space/domain"
+	"strings"
+)
+
+type DocumentType string
+
+const (
+	DocumentReceipt    DocumentType = "RECIBO"
+	DocumentRentalTerm DocumentType = "TERMO"
+)
+
+const defaultCondominiumName = "Condomínio"
+
+// Document represents a rendered PDF ready to be downloaded or sent to a resident
+type Document struct {
+	Type     DocumentType
+	FileName string
+	Content  []byte
+}
+
+// ReservationDocumentData represents everything printed on the documents of a reservation
+type ReservationDocumentData struct {
+	CondominiumName string
+	Reservation     *reservationDomain.Reservation
+	Entries         []financeDomain.FinancialEntry
+	Rules           *spaceDomain.SpaceRules
+	Policy          *spaceDomain.CancellationPolicy
+}
+
+func ParseDocumentType(value string) (DocumentType, error) {
+	switch DocumentType(strings.ToUpper(value)) {
+	case DocumentReceipt:
+		return DocumentReceipt, nil
+	case DocumentRentalTerm:
+		return DocumentRentalTerm, nil
+	default:
+		return "", fmt.Errorf("tipo de documento inválido: %s", value)
+	}
+}
+
+func (t DocumentType) Label() string {
+	switch t {
+	case DocumentReceipt:
+		return "Recibo de pagamento"
+	case DocumentRentalTerm:
+		return "Termo de responsabilidade"
+	default:
+		return string(t)
+	}
+}
+
+func CondominiumName() string {
+	if name := os.Getenv("CONDOMINIUM_NAME"); name != "" {
+		return name
+	}
+	return defaultCondominiumName
+}
+
+// Render builds the PDF of the document type, refusing receipts of reservations that were never paid
+// and terms of cancelled reservations.
+func Render(documentType DocumentType, data *ReservationDocumentData) (*Document, error) {
+	reservation := data.Reservation
+
+	var content []byte
+	var err error
+
+	switch documentType {
+	case DocumentReceipt:
+		if reservation.PaymentStatus == reservationDomain.PaymentPending || reservation.PaymentDate == nil {
+			return nil, fmt.Errorf("a reserva ainda não foi paga")
+		}
+		content, err = RenderReceipt(data)
+	case DocumentRentalTerm:
+		if reservation.Status == reservationDomain.StatusCancelled {
+			return nil, fmt.Errorf("a reserva foi cancelada")
+		}
+		content, err = RenderRentalTerm(data)
+	default:
+		return nil, fmt.Errorf("tipo de documento inválido: %s", documentType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Document{
+		Type:     documentType,
+		FileName: fmt.Sprintf("%s-reserva-%d.pdf", strings.ToLower(string(documentType)), reservation.ID),
+		Content:  content,
+	}, nil
+}
+
+func ReceiptNumber(reservation *reservationDomain.Reservation) string {
+	return fmt.Sprintf("REC-%06d", reservation.ID)
+}
+
+func paymentMethodLabel(method reservationDomain.PaymentMethod) string {
+	switch method {
+	case reservationDomain.PaymentMethodPix:
+		return "PIX"
+	case reservationDomain.PaymentMethodBoleto:
+		return "Boleto bancário"
+	default:
+		return string(method)
+	}
+}
+
+func entryTypeLabel(entryType financeDomain.EntryType) string {
+	switch entryType {
+	case financeDomain.EntryRefund:
+		return "Reembolso"
+	case financeDomain.EntryDamageCharge:
+		return "Taxa de danos"
+	default:
+		return string(entryType)
+	}
+}
+
+func residentLine(reservation *reservationDomain.Reservation) (string, string) {
+	if reservation.Resident == nil {
+		return "", ""
+	}
+	resident := reservation.Resident
+	return resident.Name, fmt.Sprintf("Bloco %s, apartamento %s", resident.Block, resident.Apartment)
+}
+
+func spaceName(reservation *reservationDomain.Reservation) string {
+	return "Salão " + reservation.GetLastCharFromSalon()
+}
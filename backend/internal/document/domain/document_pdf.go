@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pageMargin   = 20.0
+	contentWidth = 170.0
+	lineHeight   = 6.0
+)
+
+// pdfWriter wraps fpdf with the translator needed to print accented text with the core fonts.
+type pdfWriter struct {
+	pdf *fpdf.Fpdf
+	tr  func(string) string
+}
+
+func newPDFWriter(title, condominium string) *pdfWriter {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(true, pageMargin)
+	pdf.SetTitle(title, true)
+	pdf.AddPage()
+
+	w := &pdfWriter{pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(contentWidth, 8, w.tr(condominium), "", 1, "C", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.CellFormat(contentWidth, 8, w.tr(title), "B", 1, "C", false, 0, "")
+	pdf.Ln(4)
+
+	return w
+}
+
+func (w *pdfWriter) field(label, value string) {
+	w.pdf.SetFont("Helvetica", "B", 10)
+	w.pdf.CellFormat(50, lineHeight, w.tr(label), "", 0, "L", false, 0, "")
+	w.pdf.SetFont("Helvetica", "", 10)
+	w.pdf.MultiCell(contentWidth-50, lineHeight, w.tr(value), "", "L", false)
+}
+
+func (w *pdfWriter) section(title string) {
+	w.pdf.Ln(3)
+	w.pdf.SetFont("Helvetica", "B", 11)
+	w.pdf.CellFormat(contentWidth, lineHeight+1, w.tr(title), "B", 1, "L", false, 0, "")
+	w.pdf.Ln(1)
+}
+
+func (w *pdfWriter) paragraph(text string) {
+	w.pdf.SetFont("Helvetica", "", 10)
+	w.pdf.MultiCell(contentWidth, lineHeight-1, w.tr(text), "", "J", false)
+}
+
+func (w *pdfWriter) signature(label string) {
+	w.pdf.Ln(16)
+	x := w.pdf.GetX() + 35
+	y := w.pdf.GetY()
+	w.pdf.Line(x, y, x+100, y)
+	w.pdf.SetFont("Helvetica", "", 9)
+	w.pdf.CellFormat(contentWidth, lineHeight, w.tr(label), "", 1, "C", false, 0, "")
+}
+
+func (w *pdfWriter) footer(issuedAt time.Time) {
+	w.pdf.Ln(6)
+	w.pdf.SetFont("Helvetica", "I", 8)
+	w.pdf.CellFormat(contentWidth, lineHeight, w.tr("Documento emitido em "+issuedAt.Format("02/01/2006 15:04")), "", 1, "R", false, 0, "")
+}
+
+func (w *pdfWriter) bytes() ([]byte, error) {
+	if err := w.pdf.Error(); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := w.pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func formatCurrency(amount float64) string {
+	return fmt.Sprintf("R$ %.2f", amount)
+}
@@ -0,0 +1,86 @@
+package domain_test
+
+import (
+	"bytes"
+	"portarius/internal/document/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	residentDomain "portarius/internal/resident/domain"
+	spaceDomain "portarius/internal/space/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestRender(t *testing.T) {
+	paidAt := time.Date(2025, time.March, 10, 14, 0, 0, 0, time.UTC)
+	start := time.Date(2025, time.March, 15, 10, 0, 0, 0, time.UTC)
+
+	newData := func(status reservationDomain.ReservationStatus, paymentStatus reservationDomain.PaymentStatus, paymentDate *time.Time) *domain.ReservationDocumentData {
+		return &domain.ReservationDocumentData{
+			CondominiumName: "Condomínio Residencial",
+			Reservation: &reservationDomain.Reservation{
+				Model:         gorm.Model{ID: 7},
+				Resident:      &residentDomain.Resident{Name: "João Silva", Block: "A", Apartment: "12"},
+				Space:         reservationDomain.Salon1,
+				StartTime:     start,
+				EndTime:       start.Add(12 * time.Hour),
+				Status:        status,
+				PaymentStatus: paymentStatus,
+				PaymentMethod: reservationDomain.PaymentMethodPix,
+				PaymentAmount: 70,
+				PaymentDate:   paymentDate,
+			},
+			Rules:  spaceDomain.DefaultRules(reservationDomain.Salon1),
+			Policy: spaceDomain.DefaultCancellationPolicy(reservationDomain.Salon1),
+		}
+	}
+
+	tests := []struct {
+		name         string
+		documentType domain.DocumentType
+		data         *domain.ReservationDocumentData
+		wantErr      bool
+		fileName     string
+	}{
+		{
+			name:         "receipt of a paid reservation",
+			documentType: domain.DocumentReceipt,
+			data:         newData(reservationDomain.StatusConfirmed, reservationDomain.PaymentPaid, &paidAt),
+			fileName:     "recibo-reserva-7.pdf",
+		},
+		{
+			name:         "receipt of an unpaid reservation",
+			documentType: domain.DocumentReceipt,
+			data:         newData(reservationDomain.StatusPending, reservationDomain.PaymentPending, nil),
+			wantErr:      true,
+		},
+		{
+			name:         "term of a pending reservation",
+			documentType: domain.DocumentRentalTerm,
+			data:         newData(reservationDomain.StatusPending, reservationDomain.PaymentPending, nil),
+			fileName:     "termo-reserva-7.pdf",
+		},
+		{
+			name:         "term of a cancelled reservation",
+			documentType: domain.DocumentRentalTerm,
+			data:         newData(reservationDomain.StatusCancelled, reservationDomain.PaymentPending, nil),
+			wantErr:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := domain.Render(tt.documentType, tt.data)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.fileName, document.FileName)
+			assert.True(t, bytes.HasPrefix(document.Content, []byte("%PDF")))
+		})
+	}
+}
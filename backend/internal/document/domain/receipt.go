@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RenderReceipt prints the payment receipt of a reservation, listing refunds and damage charges recorded after the payment.
+func RenderReceipt(data *ReservationDocumentData) ([]byte, error) {
+	reservation := data.Reservation
+	name, unit := residentLine(reservation)
+
+	w := newPDFWriter("Recibo de Pagamento", data.CondominiumName)
+
+	w.field("Recibo nº", ReceiptNumber(reservation))
+	w.field("Morador", name)
+	w.field("Unidade", unit)
+	w.field("Espaço", spaceName(reservation))
+	w.field("Data da reserva", reservation.StartTime.Format("02/01/2006"))
+	w.field("Forma de pagamento", paymentMethodLabel(reservation.PaymentMethod))
+	w.field("Data do pagamento", reservation.PaymentDate.Format("02/01/2006"))
+	w.field("Valor pago", formatCurrency(reservation.PaymentAmount))
+
+	if len(data.Entries) > 0 {
+		w.section("Movimentações posteriores")
+		for _, entry := range data.Entries {
+			w.field(entry.OccurredAt.Format("02/01/2006"), fmt.Sprintf("%s: %s %s", entryTypeLabel(entry.Type), formatCurrency(entry.Amount), entry.Description))
+		}
+	}
+
+	w.pdf.Ln(6)
+	w.paragraph(fmt.Sprintf(
+		"Recebemos de %s (%s) a quantia de %s referente à taxa de uso do %s no dia %s.",
+		name, unit, formatCurrency(reservation.PaymentAmount), spaceName(reservation), reservation.StartTime.Format("02/01/2006"),
+	))
+
+	w.signature(data.CondominiumName)
+	w.footer(time.Now())
+
+	return w.bytes()
+}
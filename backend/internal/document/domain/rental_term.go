@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// RenderRentalTerm prints the term of responsibility the resident signs before using the space,
+// with the space rules and the cancellation policy in force.
+func RenderRentalTerm(data *ReservationDocumentData) ([]byte, error) {
+	reservation := data.Reservation
+	name, unit := residentLine(reservation)
+
+	w := newPDFWriter("Termo de Responsabilidade de Uso", data.CondominiumName)
+
+	w.field("Reserva nº", fmt.Sprintf("%d", reservation.ID))
+	w.field("Morador", name)
+	w.field("Unidade", unit)
+	w.field("Espaço", spaceName(reservation))
+	w.field("Período", fmt.Sprintf("%s a %s", reservation.StartTime.Format("02/01/2006 15:04"), reservation.EndTime.Format("02/01/2006 15:04")))
+	w.field("Taxa de uso", formatCurrency(reservation.PaymentAmount))
+	w.field("Forma de pagamento", paymentMethodLabel(reservation.PaymentMethod))
+
+	w.pdf.Ln(4)
+	w.paragraph(fmt.Sprintf(
+		"Eu, %s, morador da unidade %s, declaro estar ciente das regras de uso do %s e assumo a responsabilidade pela conservação do espaço, de seus móveis e equipamentos, e pela conduta dos meus convidados durante o período reservado.",
+		name, unit, spaceName(reservation),
+	))
+
+	if data.Rules != nil && data.Rules.Text != "" {
+		w.section("Regras de uso")
+		w.paragraph(data.Rules.Text)
+		if data.Rules.Capacity > 0 {
+			w.paragraph(fmt.Sprintf("Capacidade máxima do espaço: %d convidados.", data.Rules.Capacity))
+		}
+	}
+
+	if data.Policy != nil {
+		w.section("Política de cancelamento")
+		w.paragraph(fmt.Sprintf(
+			"Cancelamentos com pelo menos %d dias de antecedência são reembolsados integralmente. Após esse prazo, o reembolso é de %.0f%% do valor pago. Cancelamentos no dia do evento não são reembolsados.",
+			data.Policy.FullRefundDaysBefore, data.Policy.PartialRefundPercentage,
+		))
+	}
+
+	w.signature(fmt.Sprintf("%s - %s", name, unit))
+	w.signature("Síndico / Administração")
+	w.footer(time.Now())
+
+	return w.bytes()
+}
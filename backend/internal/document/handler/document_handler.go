@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"portarius/internal/document/domain"
+	documentService "portarius/internal/document/service"
+	"portarius/internal/eventbus"
+	reminderDomain "portarius/internal/reminder/domain"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DocumentHandler struct {
+	service *documentService.ReservationDocumentService
+}
+
+func NewDocumentHandler(service *documentService.ReservationDocumentService) *DocumentHandler {
+	return &DocumentHandler{service: service}
+}
+
+// Download godoc
+// @Summary Download a reservation document
+// @Description Renders the payment receipt (RECIBO) or the term of responsibility (TERMO) of a reservation as PDF
+// @Tags Documents
+// @Produce application/pdf
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Param type path string true "Document type" Enums(RECIBO,TERMO)
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Router /reservations/{id}/documents/{type} [get]
+func (c *DocumentHandler) Download(ctx *gin.Context) {
+	document, ok := c.buildDocument(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", document.FileName))
+	ctx.Data(http.StatusOK, "application/pdf", document.Content)
+}
+
+// Send godoc
+// @Summary Send a reservation document to the resident
+// @Description Renders the receipt or the term of responsibility and sends it to the resident through the notification channel
+// @Tags Documents
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Param type path string true "Document type" Enums(RECIBO,TERMO)
+// @Success 202
+// @Failure 400
+// @Failure 401
+// @Router /reservations/{id}/documents/{type}/send [post]
+func (c *DocumentHandler) Send(ctx *gin.Context) {
+	document, ok := c.buildDocument(ctx)
+	if !ok {
+		return
+	}
+
+	reservationID, _ := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	id := uint(reservationID)
+
+	eventbus.Publish("ReservationDocumentReady", &eventbus.ReservationDocumentReadyEvent{
+		ReservationID: &id,
+		Channel:       string(reminderDomain.ReminderChannelWhatsApp),
+		DocumentLabel: document.Type.Label(),
+		FileName:      document.FileName,
+		Content:       document.Content,
+	})
+
+	ctx.JSON(http.StatusAccepted, gin.H{"message": "Documento enviado para o morador"})
+}
+
+// ListDocumentTypes godoc
+// @Summary List reservation document types
+// @Description Returns the list of documents that can be generated for a reservation
+// @Tags Documents
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.DocumentType
+// @Router /documents/types [get]
+func (c *DocumentHandler) ListDocumentTypes(ctx *gin.Context) {
+	types := []domain.DocumentType{
+		domain.DocumentReceipt,
+		domain.DocumentRentalTerm,
+	}
+	ctx.JSON(http.StatusOK, types)
+}
+
+func (c *DocumentHandler) buildDocument(ctx *gin.Context) (*domain.Document, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return nil, false
+	}
+
+	documentType, err := domain.ParseDocumentType(ctx.Param("type"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	document, err := c.service.Build(documentType, uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return document, true
+}
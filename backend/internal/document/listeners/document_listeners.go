@@ -0,0 +1,43 @@
+package listeners
+
+import (
+	"log"
+	"portarius/internal/document/domain"
+	documentService "portarius/internal/document/service"
+	"portarius/internal/eventbus"
+)
+
+var service *documentService.ReservationDocumentService
+
+func RegisterDocumentListeners(documentService *documentService.ReservationDocumentService) {
+	service = documentService
+
+	eventbus.Subscribe("ReservationPaid", onReservationPaid)
+	eventbus.Subscribe("ReservationConfirmed", onReservationConfirmed)
+}
+
+func onReservationPaid(e eventbus.Event) {
+	event := e.(*eventbus.ReservationPaidEvent)
+	publishDocument(domain.DocumentReceipt, event.ReservationID, event.Channel)
+}
+
+func onReservationConfirmed(e eventbus.Event) {
+	event := e.(*eventbus.ReservationConfirmedEvent)
+	publishDocument(domain.DocumentRentalTerm, event.ReservationID, event.Channel)
+}
+
+func publishDocument(documentType domain.DocumentType, reservationID *uint, channel string) {
+	document, err := service.Build(documentType, *reservationID)
+	if err != nil {
+		log.Printf("Erro ao gerar %s da reserva %d: %v", documentType.Label(), *reservationID, err)
+		return
+	}
+
+	eventbus.Publish("ReservationDocumentReady", &eventbus.ReservationDocumentReadyEvent{
+		ReservationID: reservationID,
+		Channel:       channel,
+		DocumentLabel: documentType.Label(),
+		FileName:      document.FileName,
+		Content:       document.Content,
+	})
+}
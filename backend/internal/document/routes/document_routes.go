@@ -0,0 +1,20 @@
+package routes
+
+import (
+	documentHandler "portarius/internal/document/handler"
+	documentService "portarius/internal/document/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+func RegisterDocumentRoutes(router *gin.RouterGroup, service *documentService.ReservationDocumentService) {
+	handler := documentHandler.NewDocumentHandler(service)
+
+	reservations := router.Group("/reservations")
+	{
+		reservations.GET("/:id/documents/:type", handler.Download)
+		reservations.POST("/:id/documents/:type/send", handler.Send)
+	}
+
+	router.GET("/documents/types", handler.ListDocumentTypes)
+}
@@ -0,0 +1,56 @@
+package document
+
+import (
+	"fmt"
+	"portarius/internal/document/domain"
+	financeDomain "portarius/internal/finance/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	spaceDomain "portarius/internal/space/domain"
+)
+
+type ReservationDocumentService struct {
+	reservationRepo reservationDomain.IReservationRepository
+	financeRepo     financeDomain.IFinancialEntryRepository
+	rulesRepo       spaceDomain.ISpaceRulesRepository
+	policyRepo      spaceDomain.ICancellationPolicyRepository
+}
+
+func NewReservationDocumentService(reservationRepo reservationDomain.IReservationRepository, financeRepo financeDomain.IFinancialEntryRepository, rulesRepo spaceDomain.ISpaceRulesRepository, policyRepo spaceDomain.ICancellationPolicyRepository) *ReservationDocumentService {
+	return &ReservationDocumentService{
+		reservationRepo: reservationRepo,
+		financeRepo:     financeRepo,
+		rulesRepo:       rulesRepo,
+		policyRepo:      policyRepo,
+	}
+}
+
+func (s *ReservationDocumentService) Build(documentType domain.DocumentType, reservationID uint) (*domain.Document, error) {
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reserva não encontrada")
+	}
+
+	data := &domain.ReservationDocumentData{
+		CondominiumName: domain.CondominiumName(),
+		Reservation:     reservation,
+	}
+
+	switch documentType {
+	case domain.DocumentReceipt:
+		data.Entries, err = s.financeRepo.GetByReservation(reservation.ID)
+		if err != nil {
+			return nil, err
+		}
+	case domain.DocumentRentalTerm:
+		data.Rules, err = s.rulesRepo.GetBySpace(string(reservation.Space))
+		if err != nil {
+			return nil, err
+		}
+		data.Policy, err = s.policyRepo.GetBySpace(string(reservation.Space))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return domain.Render(documentType, data)
+}
@@ -8,6 +8,20 @@ type PackageCreatedEvent struct {
 	Recipient string
 }
 
+// PackagesBatchCreatedEvent groups the packages of one resident registered in the same delivery,
+// so a single notification is sent for all of them
+type PackagesBatchCreatedEvent struct {
+	ResidentID *uint
+	PackageIDs []uint
+	Channel    string
+}
+
+// PackageReturnRegisteredEvent is published when a pending package is refused or set aside to be
+// returned to the sender
+type PackageReturnRegisteredEvent struct {
+	PackageID *uint
+}
+
 type ReservationCreatedEvent struct {
 	ReservationID *uint
 	StartTime     time.Time
@@ -15,6 +29,46 @@ type ReservationCreatedEvent struct {
 	Recipient     string
 }
 
+type ReservationConfirmedEvent struct {
+	ReservationID *uint
+	Channel       string
+}
+
+type ReservationInspectedEvent struct {
+	ReservationID *uint
+	Channel       string
+	Findings      string
+	DamageAmount  float64
+}
+
+type ReservationKeysOverdueEvent struct {
+	ReservationID *uint
+	Channel       string
+	AlertLevel    int
+	HoursOverdue  int
+}
+
+type ReservationPaidEvent struct {
+	ReservationID *uint
+	Channel       string
+}
+
+type ReservationDocumentReadyEvent struct {
+	ReservationID *uint
+	Channel       string
+	DocumentLabel string
+	FileName      string
+	Content       []byte
+}
+
+type UnlistedGuestArrivedEvent struct {
+	ReservationID *uint
+	Channel       string
+	GuestName     string
+	Document      string
+	VehiclePlate  string
+}
+
 type ReminderEvent struct {
 	ReminderID     *uint
 	ReservationID  *uint
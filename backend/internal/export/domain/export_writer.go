@@ -0,0 +1,274 @@
+package domain
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "CSV"
+	ExportFormatXLSX ExportFormat = "XLSX"
+	ExportFormatJSON ExportFormat = "JSON"
+)
+
+// ExportTimeLayout is used for dates in CSV and XLSX files; JSON keeps RFC 3339
+const ExportTimeLayout = "2006-01-02 15:04:05"
+
+// Column describes an exported field: Key names it in JSON and Header labels it in CSV and XLSX
+type Column struct {
+	Key    string
+	Header string
+}
+
+// RowWriter writes exported rows as they are read, so the whole table never has to be in memory
+type RowWriter interface {
+	WriteRow(values []any) error
+	Close() error
+}
+
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch ExportFormat(strings.ToUpper(value)) {
+	case "", ExportFormatCSV:
+		return ExportFormatCSV, nil
+	case ExportFormatXLSX:
+		return ExportFormatXLSX, nil
+	case ExportFormatJSON:
+		return ExportFormatJSON, nil
+	default:
+		return "", fmt.Errorf("formato de exportação inválido: %s", value)
+	}
+}
+
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case ExportFormatJSON:
+		return "application/json; charset=utf-8"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+func (f ExportFormat) Extension() string {
+	return strings.ToLower(string(f))
+}
+
+// NewRowWriter starts an export in the given format, writing the header right away
+func NewRowWriter(format ExportFormat, w io.Writer, sheet string, columns []Column) (RowWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVRowWriter(w, columns)
+	case ExportFormatXLSX:
+		return newXLSXRowWriter(w, sheet, columns)
+	case ExportFormatJSON:
+		return newJSONRowWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("formato de exportação inválido: %s", format)
+	}
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func newCSVRowWriter(w io.Writer, columns []Column) (*csvRowWriter, error) {
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Header
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &csvRowWriter{writer: writer}, nil
+}
+
+func (c *csvRowWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		record[i] = formatValue(value)
+	}
+	return c.writer.Write(record)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+type xlsxRowWriter struct {
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	output io.Writer
+	row    int
+}
+
+func newXLSXRowWriter(w io.Writer, sheet string, columns []Column) (*xlsxRowWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName(file.GetSheetName(0), sheet); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	header := make([]any, len(columns))
+	for i, column := range columns {
+		header[i] = column.Header
+	}
+	if err := stream.SetRow("A1", header); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &xlsxRowWriter{file: file, stream: stream, output: w, row: 1}, nil
+}
+
+func (x *xlsxRowWriter) WriteRow(values []any) error {
+	x.row++
+	cells := make([]any, len(values))
+	for i, value := range values {
+		cells[i] = spreadsheetValue(value)
+	}
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cell, cells)
+}
+
+// Close writes the workbook; the rows were kept in the temporary files of the stream writer until here
+func (x *xlsxRowWriter) Close() error {
+	defer x.file.Close()
+
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.output)
+}
+
+type jsonRowWriter struct {
+	output  io.Writer
+	columns []Column
+	written bool
+}
+
+func newJSONRowWriter(w io.Writer, columns []Column) (*jsonRowWriter, error) {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return nil, err
+	}
+	return &jsonRowWriter{output: w, columns: columns}, nil
+}
+
+// WriteRow writes an object with the fields in the order of the columns
+func (j *jsonRowWriter) WriteRow(values []any) error {
+	var builder strings.Builder
+	if j.written {
+		builder.WriteString(",")
+	}
+	builder.WriteString("{")
+
+	for i, column := range j.columns {
+		if i > 0 {
+			builder.WriteString(",")
+		}
+
+		key, _ := json.Marshal(column.Key)
+		builder.Write(key)
+		builder.WriteString(":")
+
+		var value any
+		if i < len(values) {
+			value = normalizeValue(values[i])
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		builder.Write(encoded)
+	}
+
+	builder.WriteString("}")
+	j.written = true
+
+	_, err := io.WriteString(j.output, builder.String())
+	return err
+}
+
+func (j *jsonRowWriter) Close() error {
+	_, err := io.WriteString(j.output, "]")
+	return err
+}
+
+// normalizeValue dereferences pointers and turns zero dates into empty values
+func normalizeValue(value any) any {
+	switch v := value.(type) {
+	case *uint:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case *time.Time:
+		if v == nil || v.IsZero() {
+			return nil
+		}
+		return *v
+	case *string:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case time.Time:
+		if v.IsZero() {
+			return nil
+		}
+		return v
+	default:
+		return value
+	}
+}
+
+func spreadsheetValue(value any) any {
+	switch v := normalizeValue(value).(type) {
+	case nil:
+		return nil
+	case time.Time:
+		return v.Format(ExportTimeLayout)
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
+
+func formatValue(value any) string {
+	switch v := normalizeValue(value).(type) {
+	case nil:
+		return ""
+	case time.Time:
+		return v.Format(ExportTimeLayout)
+	case float64:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,120 @@
+package domain_test
+
+import (
+	"bytes"
+	"net/url"
+	"portarius/internal/export/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+var columns = []domain.Column{
+	{Key: "id", Header: "ID"},
+	{Key: "name", Header: "Nome"},
+	{Key: "received_at", Header: "Recebida em"},
+	{Key: "delivered_to_id", Header: "Entregue a"},
+}
+
+func writeRows(t *testing.T, format domain.ExportFormat) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	writer, err := domain.NewRowWriter(format, &buffer, "Encomendas", columns)
+	require.NoError(t, err)
+
+	receivedAt := time.Date(2025, time.November, 3, 10, 30, 0, 0, time.UTC)
+	deliveredTo := uint(7)
+	require.NoError(t, writer.WriteRow([]any{uint(1), "Fulano, de Tal", receivedAt, &deliveredTo}))
+	require.NoError(t, writer.WriteRow([]any{uint(2), "Cicrano", time.Time{}, (*uint)(nil)}))
+	require.NoError(t, writer.Close())
+
+	return buffer.Bytes()
+}
+
+func TestRowWriter_CSV(t *testing.T) {
+	data := writeRows(t, domain.ExportFormatCSV)
+
+	assert.Equal(t, "ID,Nome,Recebida em,Entregue a\n1,\"Fulano, de Tal\",2025-11-03 10:30:00,7\n2,Cicrano,,\n", string(data))
+}
+
+func TestRowWriter_JSON(t *testing.T) {
+	data := writeRows(t, domain.ExportFormatJSON)
+
+	assert.JSONEq(t, `[
+		{"id":1,"name":"Fulano, de Tal","received_at":"2025-11-03T10:30:00Z","delivered_to_id":7},
+		{"id":2,"name":"Cicrano","received_at":null,"delivered_to_id":null}
+	]`, string(data))
+}
+
+func TestRowWriter_JSONWithoutRows(t *testing.T) {
+	var buffer bytes.Buffer
+	writer, err := domain.NewRowWriter(domain.ExportFormatJSON, &buffer, "Encomendas", columns)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	assert.Equal(t, "[]", buffer.String())
+}
+
+func TestRowWriter_XLSX(t *testing.T) {
+	data := writeRows(t, domain.ExportFormatXLSX)
+
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows("Encomendas")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"ID", "Nome", "Recebida em", "Entregue a"},
+		{"1", "Fulano, de Tal", "2025-11-03 10:30:00", "7"},
+		{"2", "Cicrano"},
+	}, rows)
+}
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := domain.ParseExportFormat("xlsx")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportFormatXLSX, format)
+
+	format, err = domain.ParseExportFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ExportFormatCSV, format)
+
+	_, err = domain.ParseExportFormat("pdf")
+	assert.Error(t, err)
+}
+
+func TestExportEntity_FileName(t *testing.T) {
+	entity, err := domain.ParseExportEntity("encomendas")
+	require.NoError(t, err)
+
+	now := time.Date(2025, time.November, 3, 10, 0, 0, 0, time.UTC)
+	assert.Equal(t, "encomendas_20251103.xlsx", entity.FileName(domain.ExportFormatXLSX, now))
+	assert.Equal(t, "Encomendas", entity.SheetName())
+
+	_, err = domain.ParseExportEntity("boletos")
+	assert.Error(t, err)
+}
+
+func TestFilters(t *testing.T) {
+	filters := url.Values{"residentId": {"12"}, "start_date": {"2025-11-03"}, "packageId": {"abc"}}
+
+	id, err := domain.FilterID(filters, "residentId")
+	require.NoError(t, err)
+	assert.Equal(t, uint(12), *id)
+
+	id, err = domain.FilterID(filters, "ownerId")
+	require.NoError(t, err)
+	assert.Nil(t, id)
+
+	_, err = domain.FilterID(filters, "packageId")
+	assert.EqualError(t, err, "filtro packageId inválido: abc")
+
+	date, err := domain.FilterDate(filters, "start_date")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, time.November, 3, 0, 0, 0, 0, time.UTC), *date)
+}
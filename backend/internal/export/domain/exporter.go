@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ExportEntity string
+
+const (
+	ExportEntityResidents    ExportEntity = "MORADORES"
+	ExportEntityPackages     ExportEntity = "ENCOMENDAS"
+	ExportEntityReservations ExportEntity = "RESERVAS"
+	ExportEntityInventory    ExportEntity = "INVENTARIO"
+	ExportEntityReminders    ExportEntity = "LEMBRETES"
+)
+
+// ExportBatchSize is how many records are loaded from the database at a time
+const ExportBatchSize = 500
+
+// Exporter streams the records of an entity. Query applies the filters sent with the export and
+// is called before anything is written, so invalid filters can still be answered with an error.
+type Exporter interface {
+	Entity() ExportEntity
+	Columns() []Column
+	Query(db *gorm.DB, filters url.Values) (*gorm.DB, error)
+	Export(query *gorm.DB, write func(values []any) error) error
+}
+
+func ParseExportEntity(value string) (ExportEntity, error) {
+	entity := ExportEntity(strings.ToUpper(value))
+	switch entity {
+	case ExportEntityResidents, ExportEntityPackages, ExportEntityReservations, ExportEntityInventory, ExportEntityReminders:
+		return entity, nil
+	default:
+		return "", fmt.Errorf("entidade de exportação inválida: %s", value)
+	}
+}
+
+// FileName is the name of the downloaded file, e.g. moradores_20251103.csv
+func (e ExportEntity) FileName(format ExportFormat, now time.Time) string {
+	return fmt.Sprintf("%s_%s.%s", strings.ToLower(string(e)), now.Format("20060102"), format.Extension())
+}
+
+// SheetName names the worksheet of XLSX exports
+func (e ExportEntity) SheetName() string {
+	name := strings.ToLower(string(e))
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// FilterID reads an optional ID filter, returning nil when it was not sent
+func FilterID(filters url.Values, name string) (*uint, error) {
+	value := filters.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("filtro %s inválido: %s", name, value)
+	}
+
+	result := uint(id)
+	return &result, nil
+}
+
+// FilterDate reads an optional date filter in the 2006-01-02 layout
+func FilterDate(filters url.Values, name string) (*time.Time, error) {
+	value := filters.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return nil, fmt.Errorf("filtro %s inválido, use o formato YYYY-MM-DD: %s", name, value)
+	}
+
+	return &date, nil
+}
@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"portarius/internal/export/domain"
+	exportService "portarius/internal/export/service"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportHandler struct {
+	service *exportService.ExportService
+}
+
+func NewExportHandler(service *exportService.ExportService) *ExportHandler {
+	return &ExportHandler{service: service}
+}
+
+// Export godoc
+// @Summary Export an entity
+// @Description Streams every record of an entity as CSV, XLSX or JSON. Each entity accepts its own optional filters, sent as query parameters: MORADORES (block, apartment, residentType), ENCOMENDAS (status, carrier, residentId, start_date, end_date), RESERVAS (residentId, space, status, start_date, end_date), INVENTARIO (inventoryType, ownerId) and LEMBRETES (status, channel, recipient, reservationId, packageId, pending). Dates use the YYYY-MM-DD format.
+// @Tags Exports
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce json
+// @Security BearerAuth
+// @Param entity path string true "Entity" Enums(MORADORES,ENCOMENDAS,RESERVAS,INVENTARIO,LEMBRETES)
+// @Param format query string false "File format" Enums(CSV,XLSX,JSON) default(CSV)
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Router /exports/{entity} [get]
+func (c *ExportHandler) Export(ctx *gin.Context) {
+	entity, err := domain.ParseExportEntity(ctx.Param("entity"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, err := domain.ParseExportFormat(ctx.Query("format"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	export, err := c.service.Prepare(entity, format, ctx.Request.URL.Query())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Type", format.ContentType())
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", entity.FileName(format, time.Now())))
+	ctx.Status(http.StatusOK)
+
+	// The status was already sent, so a failure halfway can only be logged and the download is cut short
+	if err := export.Write(ctx.Writer); err != nil {
+		log.Printf("Erro ao exportar %s: %v", entity, err)
+		ctx.Abort()
+	}
+}
+
+// ListExportEntities godoc
+// @Summary List all exportable entities
+// @Description Returns the list of entities that can be exported
+// @Tags Exports
+// @Produce json
+// @Success 200 {array} domain.ExportEntity "List of exportable entities"
+// @Router /exports/exportEntities [get]
+func (c *ExportHandler) ListExportEntities(ctx *gin.Context) {
+	entities := []domain.ExportEntity{
+		domain.ExportEntityResidents,
+		domain.ExportEntityPackages,
+		domain.ExportEntityReservations,
+		domain.ExportEntityInventory,
+		domain.ExportEntityReminders,
+	}
+
+	ctx.JSON(http.StatusOK, entities)
+}
+
+// ListExportFormats godoc
+// @Summary List all export formats
+// @Description Returns the list of file formats of the exports
+// @Tags Exports
+// @Produce json
+// @Success 200 {array} domain.ExportFormat "List of export formats"
+// @Router /exports/exportFormats [get]
+func (c *ExportHandler) ListExportFormats(ctx *gin.Context) {
+	formats := []domain.ExportFormat{
+		domain.ExportFormatCSV,
+		domain.ExportFormatXLSX,
+		domain.ExportFormatJSON,
+	}
+
+	ctx.JSON(http.StatusOK, formats)
+}
@@ -0,0 +1,32 @@
+package routes
+
+import (
+	exportHandler "portarius/internal/export/handler"
+	exportService "portarius/internal/export/service"
+	inventoryService "portarius/internal/inventory/service"
+	packageService "portarius/internal/package/service"
+	reminderService "portarius/internal/reminder/service"
+	reservationService "portarius/internal/reservation/service"
+	residentService "portarius/internal/resident/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterExportRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	service := exportService.NewExportService(db,
+		residentService.NewResidentExporter(),
+		packageService.NewPackageExporter(),
+		reservationService.NewReservationExporter(),
+		inventoryService.NewInventoryExporter(),
+		reminderService.NewReminderExporter(),
+	)
+	handler := exportHandler.NewExportHandler(service)
+
+	exports := router.Group("/exports")
+	{
+		exports.GET("/exportEntities", handler.ListExportEntities)
+		exports.GET("/exportFormats", handler.ListExportFormats)
+		exports.GET("/:entity", handler.Export)
+	}
+}
@@ -0,0 +1,63 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"portarius/internal/export/domain"
+
+	"gorm.io/gorm"
+)
+
+type ExportService struct {
+	db        *gorm.DB
+	exporters map[domain.ExportEntity]domain.Exporter
+}
+
+// PreparedExport is an export whose filters were already validated, ready to be written
+type PreparedExport struct {
+	Entity   domain.ExportEntity
+	Format   domain.ExportFormat
+	exporter domain.Exporter
+	query    *gorm.DB
+}
+
+func NewExportService(db *gorm.DB, exporters ...domain.Exporter) *ExportService {
+	service := &ExportService{
+		db:        db,
+		exporters: map[domain.ExportEntity]domain.Exporter{},
+	}
+	for _, exporter := range exporters {
+		service.exporters[exporter.Entity()] = exporter
+	}
+	return service
+}
+
+// Prepare validates the filters of an export without reading any record
+func (s *ExportService) Prepare(entity domain.ExportEntity, format domain.ExportFormat, filters url.Values) (*PreparedExport, error) {
+	exporter, ok := s.exporters[entity]
+	if !ok {
+		return nil, fmt.Errorf("exportação não suportada: %s", entity)
+	}
+
+	query, err := exporter.Query(s.db, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PreparedExport{Entity: entity, Format: format, exporter: exporter, query: query}, nil
+}
+
+// Write streams the records to w in batches of domain.ExportBatchSize
+func (p *PreparedExport) Write(w io.Writer) error {
+	writer, err := domain.NewRowWriter(p.Format, w, p.Entity.SheetName(), p.exporter.Columns())
+	if err != nil {
+		return err
+	}
+
+	if err := p.exporter.Export(p.query, writer.WriteRow); err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
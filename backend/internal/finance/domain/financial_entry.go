@@ -0,0 +1,28 @@
+package domain
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type EntryType string
+
+const (
+	EntryRefund       EntryType = "REEMBOLSO"
+	EntryDamageCharge EntryType = "TAXA_DANOS"
+)
+
+// FinancialEntry represents a money movement linked to a reservation, other than its rental fee
+// swagger:model
+type FinancialEntry struct {
+	gorm.Model    `swaggerignore:"true"`
+	ReservationID *uint                           `json:"reservation_id" gorm:"not null;index"`
+	Reservation   *reservationDomain.Reservation  `json:"reservation" gorm:"foreignKey:ReservationID" swaggerignore:"true"`
+	Type          EntryType                       `json:"type" gorm:"type:varchar(20);not null"`
+	Amount        float64                         `json:"amount" gorm:"type:decimal(10,2);not null"`
+	PaymentMethod reservationDomain.PaymentMethod `json:"payment_method" gorm:"type:varchar(20)"`
+	Description   string                          `json:"description" gorm:"type:text"`
+	OccurredAt    time.Time                       `json:"occurred_at" gorm:"not null"`
+}
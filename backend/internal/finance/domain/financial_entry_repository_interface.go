@@ -0,0 +1,15 @@
+package domain
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	"time"
+)
+
+type IFinancialEntryRepository interface {
+	GetAll(page, pageSize int) ([]FinancialEntry, error)
+	GetByID(id uint) (*FinancialEntry, error)
+	GetByReservation(reservationID uint) ([]FinancialEntry, error)
+	GetByPeriod(start, end time.Time) ([]FinancialEntry, error)
+	Create(entry *FinancialEntry) error
+	SaveWithReservation(reservation *reservationDomain.Reservation, inspection *reservationDomain.ReservationInspection, entries []FinancialEntry) error
+}
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"math"
+	reservationDomain "portarius/internal/reservation/domain"
+	"sort"
+	"time"
+)
+
+const (
+	ReportMonthFormat = "2006-01"
+	MaxReportMonths   = 24
+)
+
+// FinancialReportRow represents the reservation fees, refunds and damage charges of a space in a month,
+// for one payment method and payment status
+// swagger:model
+type FinancialReportRow struct {
+	Month         string                          `json:"month"`
+	Space         reservationDomain.SpaceType     `json:"space"`
+	PaymentMethod reservationDomain.PaymentMethod `json:"payment_method"`
+	PaymentStatus reservationDomain.PaymentStatus `json:"payment_status"`
+	Reservations  int                             `json:"reservations"`
+	Amount        float64                         `json:"amount"`
+	Refunds       float64                         `json:"refunds"`
+	DamageCharges float64                         `json:"damage_charges"`
+	Net           float64                         `json:"net"`
+}
+
+// FinancialReportTotals represents the totals of a report period
+// swagger:model
+type FinancialReportTotals struct {
+	Reservations  int     `json:"reservations"`
+	Received      float64 `json:"received"`
+	Pending       float64 `json:"pending"`
+	Refunds       float64 `json:"refunds"`
+	DamageCharges float64 `json:"damage_charges"`
+	Net           float64 `json:"net"`
+}
+
+// FinancialReportComparison represents the variation of the net revenue against the previous period
+// swagger:model
+type FinancialReportComparison struct {
+	NetChange           float64  `json:"net_change"`
+	NetChangePercentage *float64 `json:"net_change_percentage"`
+	ReceivedChange      float64  `json:"received_change"`
+	ReservationsChange  int      `json:"reservations_change"`
+}
+
+// FinancialReport represents the common space revenue of a period compared with the previous period of the same length
+// swagger:model
+type FinancialReport struct {
+	From           string                    `json:"from"`
+	To             string                    `json:"to"`
+	Rows           []FinancialReportRow      `json:"rows"`
+	Totals         FinancialReportTotals     `json:"totals"`
+	PreviousFrom   string                    `json:"previous_from"`
+	PreviousTo     string                    `json:"previous_to"`
+	PreviousTotals FinancialReportTotals     `json:"previous_totals"`
+	Comparison     FinancialReportComparison `json:"comparison"`
+}
+
+// ReportPeriod represents the months covered by a report, from the first day of From to the first day after To
+type ReportPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+func NewReportPeriod(fromMonth, toMonth time.Time) ReportPeriod {
+	start := time.Date(fromMonth.Year(), fromMonth.Month(), 1, 0, 0, 0, 0, fromMonth.Location())
+	end := time.Date(toMonth.Year(), toMonth.Month(), 1, 0, 0, 0, 0, toMonth.Location()).AddDate(0, 1, 0)
+	return ReportPeriod{Start: start, End: end}
+}
+
+func (p ReportPeriod) Months() int {
+	return (p.End.Year()-p.Start.Year())*12 + int(p.End.Month()-p.Start.Month())
+}
+
+// Previous returns the period of the same number of months right before this one.
+func (p ReportPeriod) Previous() ReportPeriod {
+	return ReportPeriod{Start: p.Start.AddDate(0, -p.Months(), 0), End: p.Start}
+}
+
+func (p ReportPeriod) Contains(date time.Time) bool {
+	return !date.Before(p.Start) && date.Before(p.End)
+}
+
+func (p ReportPeriod) FromLabel() string {
+	return p.Start.Format(ReportMonthFormat)
+}
+
+func (p ReportPeriod) ToLabel() string {
+	return p.End.AddDate(0, 0, -1).Format(ReportMonthFormat)
+}
+
+type reportKey struct {
+	month         string
+	space         reservationDomain.SpaceType
+	paymentMethod reservationDomain.PaymentMethod
+	paymentStatus reservationDomain.PaymentStatus
+}
+
+// BuildReportRows aggregates the reservations by the month of the event and the financial entries by the
+// month they occurred. Cancelled reservations that were never paid carry no money and are left out.
+func BuildReportRows(period ReportPeriod, reservations []reservationDomain.Reservation, entries []FinancialEntry) ([]FinancialReportRow, FinancialReportTotals) {
+	rows := map[reportKey]*FinancialReportRow{}
+	totals := FinancialReportTotals{}
+
+	row := func(month time.Time, reservation *reservationDomain.Reservation) *FinancialReportRow {
+		key := reportKey{
+			month:         month.Format(ReportMonthFormat),
+			space:         reservation.Space,
+			paymentMethod: reservation.PaymentMethod,
+			paymentStatus: reservation.PaymentStatus,
+		}
+		if rows[key] == nil {
+			rows[key] = &FinancialReportRow{
+				Month:         key.month,
+				Space:         key.space,
+				PaymentMethod: key.paymentMethod,
+				PaymentStatus: key.paymentStatus,
+			}
+		}
+		return rows[key]
+	}
+
+	for i := range reservations {
+		reservation := &reservations[i]
+		if !period.Contains(reservation.StartTime) {
+			continue
+		}
+		if reservation.Status == reservationDomain.StatusCancelled && reservation.PaymentStatus == reservationDomain.PaymentPending {
+			continue
+		}
+
+		r := row(reservation.StartTime, reservation)
+		r.Reservations++
+		r.Amount += reservation.PaymentAmount
+
+		totals.Reservations++
+		if reservation.PaymentStatus == reservationDomain.PaymentPending {
+			totals.Pending += reservation.PaymentAmount
+		} else {
+			totals.Received += reservation.PaymentAmount
+		}
+	}
+
+	for i := range entries {
+		entry := &entries[i]
+		if entry.Reservation == nil || !period.Contains(entry.OccurredAt) {
+			continue
+		}
+
+		r := row(entry.OccurredAt, entry.Reservation)
+		switch entry.Type {
+		case EntryRefund:
+			r.Refunds += entry.Amount
+			totals.Refunds += entry.Amount
+		case EntryDamageCharge:
+			r.DamageCharges += entry.Amount
+			totals.DamageCharges += entry.Amount
+		}
+	}
+
+	result := make([]FinancialReportRow, 0, len(rows))
+	for _, r := range rows {
+		if r.PaymentStatus != reservationDomain.PaymentPending {
+			r.Net = roundCents(r.Amount - r.Refunds + r.DamageCharges)
+		} else {
+			r.Net = roundCents(r.DamageCharges - r.Refunds)
+		}
+		r.Amount = roundCents(r.Amount)
+		r.Refunds = roundCents(r.Refunds)
+		r.DamageCharges = roundCents(r.DamageCharges)
+		result = append(result, *r)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Month != b.Month {
+			return a.Month < b.Month
+		}
+		if a.Space != b.Space {
+			return a.Space < b.Space
+		}
+		if a.PaymentMethod != b.PaymentMethod {
+			return a.PaymentMethod < b.PaymentMethod
+		}
+		return a.PaymentStatus < b.PaymentStatus
+	})
+
+	totals.Received = roundCents(totals.Received)
+	totals.Pending = roundCents(totals.Pending)
+	totals.Refunds = roundCents(totals.Refunds)
+	totals.DamageCharges = roundCents(totals.DamageCharges)
+	totals.Net = roundCents(totals.Received - totals.Refunds + totals.DamageCharges)
+
+	return result, totals
+}
+
+func CompareTotals(current, previous FinancialReportTotals) FinancialReportComparison {
+	comparison := FinancialReportComparison{
+		NetChange:          roundCents(current.Net - previous.Net),
+		ReceivedChange:     roundCents(current.Received - previous.Received),
+		ReservationsChange: current.Reservations - previous.Reservations,
+	}
+
+	if previous.Net != 0 {
+		percentage := roundCents((current.Net - previous.Net) / math.Abs(previous.Net) * 100)
+		comparison.NetChangePercentage = &percentage
+	}
+
+	return comparison
+}
+
+func roundCents(value float64) float64 {
+	return math.Round(value*100) / 100
+}
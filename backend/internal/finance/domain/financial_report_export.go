@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+var reportHeader = []string{"Mês", "Espaço", "Forma de pagamento", "Status do pagamento", "Reservas", "Valor", "Reembolsos", "Taxas de danos", "Líquido"}
+
+func WriteReportCSV(w io.Writer, report *FinancialReport) error {
+	writer := csv.NewWriter(w)
+	writer.Comma = ','
+
+	records := [][]string{reportHeader}
+	for _, row := range report.Rows {
+		records = append(records, []string{
+			row.Month,
+			string(row.Space),
+			string(row.PaymentMethod),
+			string(row.PaymentStatus),
+			strconv.Itoa(row.Reservations),
+			formatAmount(row.Amount),
+			formatAmount(row.Refunds),
+			formatAmount(row.DamageCharges),
+			formatAmount(row.Net),
+		})
+	}
+
+	records = append(records,
+		[]string{},
+		[]string{"Período", "Reservas", "Recebido", "Pendente", "Reembolsos", "Taxas de danos", "Líquido"},
+		totalsRecord(report.From+" a "+report.To, report.Totals),
+		totalsRecord(report.PreviousFrom+" a "+report.PreviousTo, report.PreviousTotals),
+		[]string{"Variação", strconv.Itoa(report.Comparison.ReservationsChange), formatAmount(report.Comparison.ReceivedChange), "", "", "", formatAmount(report.Comparison.NetChange)},
+	)
+
+	if err := writer.WriteAll(records); err != nil {
+		return fmt.Errorf("erro ao gerar CSV: %v", err)
+	}
+	return nil
+}
+
+func ReportXLSX(report *FinancialReport) ([]byte, error) {
+	file := excelize.NewFile()
+	defer file.Close()
+
+	const rowsSheet = "Receitas"
+	const summarySheet = "Resumo"
+
+	if err := file.SetSheetName("Sheet1", rowsSheet); err != nil {
+		return nil, err
+	}
+	if _, err := file.NewSheet(summarySheet); err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, len(reportHeader))
+	for i, title := range reportHeader {
+		header[i] = title
+	}
+	if err := file.SetSheetRow(rowsSheet, "A1", &header); err != nil {
+		return nil, err
+	}
+
+	for i, row := range report.Rows {
+		values := []interface{}{
+			row.Month,
+			string(row.Space),
+			string(row.PaymentMethod),
+			string(row.PaymentStatus),
+			row.Reservations,
+			row.Amount,
+			row.Refunds,
+			row.DamageCharges,
+			row.Net,
+		}
+		if err := file.SetSheetRow(rowsSheet, fmt.Sprintf("A%d", i+2), &values); err != nil {
+			return nil, err
+		}
+	}
+
+	summary := [][]interface{}{
+		{"Período", "Reservas", "Recebido", "Pendente", "Reembolsos", "Taxas de danos", "Líquido"},
+		totalsValues(report.From+" a "+report.To, report.Totals),
+		totalsValues(report.PreviousFrom+" a "+report.PreviousTo, report.PreviousTotals),
+		{"Variação", report.Comparison.ReservationsChange, report.Comparison.ReceivedChange, nil, nil, nil, report.Comparison.NetChange},
+	}
+	if report.Comparison.NetChangePercentage != nil {
+		summary = append(summary, []interface{}{"Variação do líquido (%)", nil, nil, nil, nil, nil, *report.Comparison.NetChangePercentage})
+	}
+
+	for i, values := range summary {
+		if err := file.SetSheetRow(summarySheet, fmt.Sprintf("A%d", i+1), &values); err != nil {
+			return nil, err
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := file.Write(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func totalsRecord(label string, totals FinancialReportTotals) []string {
+	return []string{
+		label,
+		strconv.Itoa(totals.Reservations),
+		formatAmount(totals.Received),
+		formatAmount(totals.Pending),
+		formatAmount(totals.Refunds),
+		formatAmount(totals.DamageCharges),
+		formatAmount(totals.Net),
+	}
+}
+
+func totalsValues(label string, totals FinancialReportTotals) []interface{} {
+	return []interface{}{label, totals.Reservations, totals.Received, totals.Pending, totals.Refunds, totals.DamageCharges, totals.Net}
+}
+
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
@@ -0,0 +1,73 @@
+package domain_test
+
+import (
+	"portarius/internal/finance/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportPeriodPrevious(t *testing.T) {
+	period := domain.NewReportPeriod(time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, 3, period.Months())
+	assert.Equal(t, "2025-01", period.FromLabel())
+	assert.Equal(t, "2025-03", period.ToLabel())
+
+	previous := period.Previous()
+	assert.Equal(t, "2024-10", previous.FromLabel())
+	assert.Equal(t, "2024-12", previous.ToLabel())
+}
+
+func TestBuildReportRows(t *testing.T) {
+	march := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	period := domain.NewReportPeriod(march, march)
+
+	reservation := func(space reservationDomain.SpaceType, day int, status reservationDomain.ReservationStatus, paymentStatus reservationDomain.PaymentStatus, amount float64) reservationDomain.Reservation {
+		return reservationDomain.Reservation{
+			Space:         space,
+			StartTime:     march.AddDate(0, 0, day-1),
+			Status:        status,
+			PaymentMethod: reservationDomain.PaymentMethodPix,
+			PaymentStatus: paymentStatus,
+			PaymentAmount: amount,
+		}
+	}
+
+	reservations := []reservationDomain.Reservation{
+		reservation(reservationDomain.Salon1, 7, reservationDomain.StatusConfirmed, reservationDomain.PaymentPaid, 70),
+		reservation(reservationDomain.Salon1, 10, reservationDomain.StatusKeysReturned, reservationDomain.PaymentPaid, 45),
+		reservation(reservationDomain.Salon2, 12, reservationDomain.StatusPending, reservationDomain.PaymentPending, 45),
+		reservation(reservationDomain.Salon2, 14, reservationDomain.StatusCancelled, reservationDomain.PaymentPending, 70),
+		reservation(reservationDomain.Salon1, 40, reservationDomain.StatusConfirmed, reservationDomain.PaymentPaid, 70),
+	}
+
+	refunded := reservation(reservationDomain.Salon2, 1, reservationDomain.StatusCancelled, reservationDomain.PaymentRefunded, 70)
+	entries := []domain.FinancialEntry{
+		{Type: domain.EntryDamageCharge, Amount: 30, OccurredAt: march.AddDate(0, 0, 10), Reservation: &reservations[1]},
+		{Type: domain.EntryRefund, Amount: 70, OccurredAt: march.AddDate(0, 0, 2), Reservation: &refunded},
+	}
+
+	rows, totals := domain.BuildReportRows(period, reservations, entries)
+
+	assert.Len(t, rows, 3)
+	assert.Equal(t, domain.FinancialReportRow{
+		Month: "2025-03", Space: reservationDomain.Salon1, PaymentMethod: reservationDomain.PaymentMethodPix, PaymentStatus: reservationDomain.PaymentPaid,
+		Reservations: 2, Amount: 115, DamageCharges: 30, Net: 145,
+	}, rows[0])
+	assert.Equal(t, reservationDomain.PaymentPending, rows[1].PaymentStatus)
+	assert.Equal(t, 1, rows[1].Reservations)
+	assert.Equal(t, 0.0, rows[1].Net)
+	assert.Equal(t, reservationDomain.PaymentRefunded, rows[2].PaymentStatus)
+	assert.Equal(t, 0, rows[2].Reservations)
+	assert.Equal(t, 70.0, rows[2].Refunds)
+
+	assert.Equal(t, domain.FinancialReportTotals{Reservations: 3, Received: 115, Pending: 45, Refunds: 70, DamageCharges: 30, Net: 75}, totals)
+
+	comparison := domain.CompareTotals(totals, domain.FinancialReportTotals{Reservations: 2, Received: 100, Net: 50})
+	assert.Equal(t, 25.0, comparison.NetChange)
+	assert.Equal(t, 50.0, *comparison.NetChangePercentage)
+	assert.Equal(t, 1, comparison.ReservationsChange)
+}
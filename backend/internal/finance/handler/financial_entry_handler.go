@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"portarius/internal/finance/domain"
+	financeService "portarius/internal/finance/service"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FinancialEntryHandler struct {
+	repo          domain.IFinancialEntryRepository
+	reportService *financeService.FinancialReportService
+}
+
+func NewFinancialEntryHandler(repo domain.IFinancialEntryRepository, reportService *financeService.FinancialReportService) *FinancialEntryHandler {
+	return &FinancialEntryHandler{repo: repo, reportService: reportService}
+}
+
+// GetAll godoc
+// @Summary List financial entries
+// @Description Get paginated list of refunds and other money movements linked to reservations
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param pageSize query int false "Items per page" minimum(1) maximum(100) default(10)
+// @Success 200 {array} domain.FinancialEntry
+// @Failure 401
+// @Failure 500
+// @Router /financial-entries [get]
+func (c *FinancialEntryHandler) GetAll(ctx *gin.Context) {
+	page, err := strconv.Atoi(ctx.Query("page"))
+	pageSize, err := strconv.Atoi(ctx.Query("pageSize"))
+	entries, err := c.repo.GetAll(page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// GetByReservation godoc
+// @Summary List financial entries of a reservation
+// @Description Retrieve all refunds and other money movements linked to a reservation
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Param reservationId path int true "Reservation ID"
+// @Success 200 {array} domain.FinancialEntry
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /financial-entries/reservation/{reservationId} [get]
+func (c *FinancialEntryHandler) GetByReservation(ctx *gin.Context) {
+	reservationID, err := strconv.ParseUint(ctx.Param("reservationId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID da reserva inválido"})
+		return
+	}
+
+	entries, err := c.repo.GetByReservation(uint(reservationID))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, entries)
+}
+
+// ListEntryTypes godoc
+// @Summary List financial entry types
+// @Description Returns the list of possible financial entry types
+// @Tags Finance
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.EntryType
+// @Router /financial-entries/types [get]
+func (c *FinancialEntryHandler) ListEntryTypes(ctx *gin.Context) {
+	types := []domain.EntryType{
+		domain.EntryRefund,
+		domain.EntryDamageCharge,
+	}
+	ctx.JSON(http.StatusOK, types)
+}
+
+// GetReport godoc
+// @Summary Monthly financial report
+// @Description Aggregates reservation fees by month, space, payment method and payment status, with refunds and damage charges, and compares the totals with the previous period of the same length. Months use the format yyyy-MM and default to the current month.
+// @Tags Finance
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Security BearerAuth
+// @Param from query string false "First month in format yyyy-MM"
+// @Param to query string false "Last month in format yyyy-MM"
+// @Param format query string false "Output format" Enums(json,csv,xlsx) default(json)
+// @Success 200 {object} domain.FinancialReport
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /financial-entries/report [get]
+func (c *FinancialEntryHandler) GetReport(ctx *gin.Context) {
+	now := time.Now()
+	currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	from, err := parseReportMonth(ctx.Query("from"), currentMonth)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Mês inicial inválido"})
+		return
+	}
+
+	to, err := parseReportMonth(ctx.Query("to"), from)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Mês final inválido"})
+		return
+	}
+
+	if to.Before(from) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "O mês final deve ser igual ou posterior ao mês inicial"})
+		return
+	}
+
+	period := domain.NewReportPeriod(from, to)
+	if period.Months() > domain.MaxReportMonths {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("O período do relatório não pode ultrapassar %d meses", domain.MaxReportMonths)})
+		return
+	}
+
+	report, err := c.reportService.Build(period)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileName := fmt.Sprintf("relatorio-financeiro-%s-%s", report.From, report.To)
+
+	switch strings.ToLower(ctx.DefaultQuery("format", "json")) {
+	case "json":
+		ctx.JSON(http.StatusOK, report)
+	case "csv":
+		var buffer bytes.Buffer
+		if err := domain.WriteReportCSV(&buffer, report); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", fileName))
+		ctx.Data(http.StatusOK, "text/csv; charset=utf-8", buffer.Bytes())
+	case "xlsx":
+		content, err := domain.ReportXLSX(report)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s.xlsx", fileName))
+		ctx.Data(http.StatusOK, "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", content)
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Formato inválido"})
+	}
+}
+
+func parseReportMonth(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.ParseInLocation(domain.ReportMonthFormat, value, time.Local)
+}
@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"portarius/internal/finance/domain"
+	"portarius/internal/infra"
+	reservationDomain "portarius/internal/reservation/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type financialEntryRepository struct {
+	db *gorm.DB
+}
+
+func NewFinancialEntryRepository(db *gorm.DB) domain.IFinancialEntryRepository {
+	return &financialEntryRepository{db: db}
+}
+
+func (r *financialEntryRepository) GetAll(page, pageSize int) ([]domain.FinancialEntry, error) {
+	var entries []domain.FinancialEntry
+	err := r.db.Scopes(infra.Paginate(page, pageSize)).Order("occurred_at DESC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *financialEntryRepository) GetByID(id uint) (*domain.FinancialEntry, error) {
+	var entry domain.FinancialEntry
+	err := r.db.First(&entry, id).Error
+	return &entry, err
+}
+
+func (r *financialEntryRepository) GetByReservation(reservationID uint) ([]domain.FinancialEntry, error) {
+	var entries []domain.FinancialEntry
+	err := r.db.Where("reservation_id = ?", reservationID).Order("occurred_at ASC").Find(&entries).Error
+	return entries, err
+}
+
+func (r *financialEntryRepository) GetByPeriod(start, end time.Time) ([]domain.FinancialEntry, error) {
+	var entries []domain.FinancialEntry
+	err := r.db.Preload("Reservation").
+		Where("occurred_at >= ? AND occurred_at < ?", start, end).
+		Order("occurred_at ASC").
+		Find(&entries).Error
+	return entries, err
+}
+
+func (r *financialEntryRepository) Create(entry *domain.FinancialEntry) error {
+	return r.db.Create(entry).Error
+}
+
+// SaveWithReservation saves the reservation together with the entries it generated, and the
+// inspection when there is one, so a cancellation or key return is never half recorded
+func (r *financialEntryRepository) SaveWithReservation(reservation *reservationDomain.Reservation, inspection *reservationDomain.ReservationInspection, entries []domain.FinancialEntry) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(reservation).Error; err != nil {
+			return err
+		}
+		if inspection != nil {
+			inspection.ReservationID = &reservation.ID
+			if err := tx.Create(inspection).Error; err != nil {
+				return err
+			}
+		}
+		for i := range entries {
+			entries[i].ReservationID = &reservation.ID
+			if err := tx.Create(&entries[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,31 @@
+package routes
+
+import (
+	"portarius/internal/finance/domain"
+	financeHandler "portarius/internal/finance/handler"
+	"portarius/internal/finance/repository"
+	financeService "portarius/internal/finance/service"
+	reservationDomain "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterFinanceRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	var (
+		repo            domain.IFinancialEntryRepository         = repository.NewFinancialEntryRepository(db)
+		reservationRepo reservationDomain.IReservationRepository = reservationRepository.NewReservationRepository(db)
+	)
+
+	reportService := financeService.NewFinancialReportService(repo, reservationRepo)
+	handler := financeHandler.NewFinancialEntryHandler(repo, reportService)
+
+	entries := router.Group("/financial-entries")
+	{
+		entries.GET("/", handler.GetAll)
+		entries.GET("/reservation/:reservationId", handler.GetByReservation)
+		entries.GET("/types", handler.ListEntryTypes)
+		entries.GET("/report", handler.GetReport)
+	}
+}
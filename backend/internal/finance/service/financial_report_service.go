@@ -0,0 +1,44 @@
+package finance
+
+import (
+	"portarius/internal/finance/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+)
+
+type FinancialReportService struct {
+	repo            domain.IFinancialEntryRepository
+	reservationRepo reservationDomain.IReservationRepository
+}
+
+func NewFinancialReportService(repo domain.IFinancialEntryRepository, reservationRepo reservationDomain.IReservationRepository) *FinancialReportService {
+	return &FinancialReportService{repo: repo, reservationRepo: reservationRepo}
+}
+
+// Build computes the report of the period and of the previous period of the same length.
+func (s *FinancialReportService) Build(period domain.ReportPeriod) (*domain.FinancialReport, error) {
+	previous := period.Previous()
+
+	reservations, err := s.reservationRepo.FindByDateRange(previous.Start, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.GetByPeriod(previous.Start, period.End)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, totals := domain.BuildReportRows(period, reservations, entries)
+	_, previousTotals := domain.BuildReportRows(previous, reservations, entries)
+
+	return &domain.FinancialReport{
+		From:           period.FromLabel(),
+		To:             period.ToLabel(),
+		Rows:           rows,
+		Totals:         totals,
+		PreviousFrom:   previous.FromLabel(),
+		PreviousTo:     previous.ToLabel(),
+		PreviousTotals: previousTotals,
+		Comparison:     domain.CompareTotals(totals, previousTotals),
+	}, nil
+}
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"errors"
+	reservationDomain "portarius/internal/reservation/domain"
+	"portarius/internal/utils"
+	vehicleDomain "portarius/internal/vehicle/domain"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+// CheckInWindow is how long before the start of a reservation its guests may already check in at the gate.
+const CheckInWindow = 2 * time.Hour
+
+var (
+	ErrCheckInReservationNotFound = errors.New("reserva não encontrada")
+	ErrCheckInClosed              = errors.New("o check-in desta reserva não está aberto")
+)
+
+type CheckInStatus string
+
+const (
+	CheckInConfirmed      CheckInStatus = "CONFIRMADO"
+	CheckInAlreadyArrived CheckInStatus = "JA_REGISTRADO"
+	CheckInNotListed      CheckInStatus = "NAO_LISTADO"
+)
+
+// Guest represents a visitor on the guest list of a reservation
+// swagger:model
+type Guest struct {
+	gorm.Model    `swaggerignore:"true"`
+	ReservationID uint       `json:"reservation_id" gorm:"not null;index"`
+	Name          string     `json:"name" gorm:"type:varchar(100);not null" binding:"required"`
+	Document      string     `json:"document" gorm:"type:varchar(20);index"`
+	VehiclePlate  string     `json:"vehicle_plate" gorm:"type:varchar(10);index"`
+	ArrivedAt     *time.Time `json:"arrived_at" gorm:"type:timestamp"`
+	CheckedInByID *uint      `json:"checked_in_by_id"`
+}
+
+// UnlistedArrival represents someone who showed up at the gate for a reservation without being on its guest list
+// swagger:model
+type UnlistedArrival struct {
+	gorm.Model     `swaggerignore:"true"`
+	ReservationID  *uint     `json:"reservation_id" gorm:"index"`
+	Name           string    `json:"name" gorm:"type:varchar(100)"`
+	Document       string    `json:"document" gorm:"type:varchar(20)"`
+	VehiclePlate   string    `json:"vehicle_plate" gorm:"type:varchar(10)"`
+	ArrivedAt      time.Time `json:"arrived_at" gorm:"not null"`
+	RegisteredByID *uint     `json:"registered_by_id"`
+}
+
+// CheckInRequest represents the identification given by a visitor at the gate
+// swagger:model
+type CheckInRequest struct {
+	ReservationID *uint  `json:"reservation_id"`
+	Name          string `json:"name"`
+	Document      string `json:"document"`
+	VehiclePlate  string `json:"vehicle_plate"`
+}
+
+// CheckInResult represents the answer given to the gate for a visitor
+// swagger:model
+type CheckInResult struct {
+	Status        CheckInStatus                `json:"status"`
+	ReservationID *uint                        `json:"reservation_id"`
+	Guest         *Guest                       `json:"guest,omitempty"`
+	Vehicle       *vehicleDomain.VehicleLookup `json:"vehicle,omitempty"`
+}
+
+// Normalize tidies the guest and validates the vehicle plate, when one is given, as a Brazilian plate
+func (g *Guest) Normalize() error {
+	g.Name = strings.Join(strings.Fields(g.Name), " ")
+	g.Document = NormalizeDocument(g.Document)
+	if strings.TrimSpace(g.VehiclePlate) == "" {
+		g.VehiclePlate = ""
+		return nil
+	}
+
+	plate, _, err := vehicleDomain.ParsePlate(g.VehiclePlate)
+	if err != nil {
+		return err
+	}
+	g.VehiclePlate = plate
+	return nil
+}
+
+// Normalize tidies the identification given at the gate. The plate is not rejected when it is not a
+// valid plate, since it was read at the gate; it just matches no guest.
+func (r *CheckInRequest) Normalize() {
+	r.Name = strings.Join(strings.Fields(r.Name), " ")
+	r.Document = NormalizeDocument(r.Document)
+	r.VehiclePlate = vehicleDomain.NormalizePlate(r.VehiclePlate)
+}
+
+func (r *CheckInRequest) IsEmpty() bool {
+	return r.Name == "" && r.Document == "" && r.VehiclePlate == ""
+}
+
+// NormalizeDocument keeps only letters and digits so "123.456.789-00" and "12345678900" match.
+func NormalizeDocument(document string) string {
+	return strings.ToUpper(keepAlphanumeric(document))
+}
+
+// IsCheckInOpen reports whether guests of the reservation may check in at the given time.
+func IsCheckInOpen(reservation *reservationDomain.Reservation, now time.Time) bool {
+	switch reservation.Status {
+	case reservationDomain.StatusCancelled, reservationDomain.StatusKeysReturned:
+		return false
+	}
+	return !now.Before(reservation.StartTime.Add(-CheckInWindow)) && !now.After(reservation.EndTime)
+}
+
+// FindGuest looks for the visitor on the guest list by document, then by vehicle plate, in the old or
+// Mercosul format, and finally by name.
+func FindGuest(guests []Guest, request *CheckInRequest) *Guest {
+	if request.Document != "" {
+		for i := range guests {
+			if guests[i].Document != "" && guests[i].Document == request.Document {
+				return &guests[i]
+			}
+		}
+	}
+
+	if plates := vehicleDomain.PlateVariants(request.VehiclePlate); len(plates) > 0 {
+		for i := range guests {
+			if slices.Contains(plates, guests[i].VehiclePlate) {
+				return &guests[i]
+			}
+		}
+	}
+
+	if request.Name != "" {
+		name := foldName(request.Name)
+		for i := range guests {
+			if foldName(guests[i].Name) == name {
+				return &guests[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+func keepAlphanumeric(value string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, value)
+}
+
+func foldName(name string) string {
+	return utils.RemoveAccents(strings.ToLower(strings.Join(strings.Fields(name), " ")))
+}
@@ -0,0 +1,13 @@
+package domain
+
+type IGuestRepository interface {
+	GetByReservation(reservationID uint) ([]Guest, error)
+	GetByReservations(reservationIDs []uint) ([]Guest, error)
+	GetByID(id uint) (*Guest, error)
+	CountByReservation(reservationID uint) (int64, error)
+	CreateMany(guests []Guest) error
+	Update(guest *Guest) error
+	Delete(id uint) error
+	CreateUnlistedArrival(arrival *UnlistedArrival) error
+	GetUnlistedArrivals(page, pageSize int) ([]UnlistedArrival, error)
+}
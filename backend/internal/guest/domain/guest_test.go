@@ -0,0 +1,79 @@
+package domain_test
+
+import (
+	"portarius/internal/guest/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	vehicleDomain "portarius/internal/vehicle/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestFindGuest(t *testing.T) {
+	guests := []domain.Guest{
+		{Model: gorm.Model{ID: 1}, Name: "Maria José", Document: "12345678900"},
+		{Model: gorm.Model{ID: 2}, Name: "Carlos Souza", VehiclePlate: "ABC1D23"},
+		{Model: gorm.Model{ID: 3}, Name: "Ana Lima"},
+	}
+
+	tests := []struct {
+		name     string
+		request  domain.CheckInRequest
+		expected uint
+	}{
+		{"by formatted document", domain.CheckInRequest{Document: "123.456.789-00"}, 1},
+		{"by plate with dash", domain.CheckInRequest{VehiclePlate: "abc-1d23"}, 2},
+		{"by plate in the old format", domain.CheckInRequest{VehiclePlate: "ABC-1323"}, 2},
+		{"by unreadable plate", domain.CheckInRequest{VehiclePlate: "AB1"}, 0},
+		{"by name ignoring accents and case", domain.CheckInRequest{Name: "  maria  jose "}, 1},
+		{"document wins over name", domain.CheckInRequest{Name: "Ana Lima", Document: "12345678900"}, 1},
+		{"not on the list", domain.CheckInRequest{Name: "Pedro", Document: "999"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.Normalize()
+			guest := domain.FindGuest(guests, &tt.request)
+			if tt.expected == 0 {
+				assert.Nil(t, guest)
+				return
+			}
+			assert.Equal(t, tt.expected, guest.ID)
+		})
+	}
+}
+
+func TestGuestNormalize(t *testing.T) {
+	guest := domain.Guest{Name: " Carlos   Souza ", Document: "123.456.789-00", VehiclePlate: "abc-1234"}
+	require.NoError(t, guest.Normalize())
+	assert.Equal(t, "Carlos Souza", guest.Name)
+	assert.Equal(t, "12345678900", guest.Document)
+	assert.Equal(t, "ABC1234", guest.VehiclePlate)
+
+	guest = domain.Guest{Name: "Ana", VehiclePlate: " "}
+	require.NoError(t, guest.Normalize())
+	assert.Empty(t, guest.VehiclePlate)
+
+	guest = domain.Guest{Name: "Ana", VehiclePlate: "AB-12"}
+	assert.ErrorIs(t, guest.Normalize(), vehicleDomain.ErrInvalidPlate)
+}
+
+func TestIsCheckInOpen(t *testing.T) {
+	start := time.Date(2025, time.March, 15, 18, 0, 0, 0, time.UTC)
+	reservation := &reservationDomain.Reservation{
+		StartTime: start,
+		EndTime:   start.Add(6 * time.Hour),
+		Status:    reservationDomain.StatusKeysTaken,
+	}
+
+	assert.False(t, domain.IsCheckInOpen(reservation, start.Add(-3*time.Hour)))
+	assert.True(t, domain.IsCheckInOpen(reservation, start.Add(-time.Hour)))
+	assert.True(t, domain.IsCheckInOpen(reservation, start.Add(5*time.Hour)))
+	assert.False(t, domain.IsCheckInOpen(reservation, start.Add(7*time.Hour)))
+
+	reservation.Status = reservationDomain.StatusCancelled
+	assert.False(t, domain.IsCheckInOpen(reservation, start))
+}
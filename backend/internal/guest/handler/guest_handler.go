@@ -0,0 +1,409 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"portarius/internal/eventbus"
+	"portarius/internal/guest/domain"
+	middleware "portarius/internal/middleware/auth"
+	reminderDomain "portarius/internal/reminder/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	spaceDomain "portarius/internal/space/domain"
+	vehicleDomain "portarius/internal/vehicle/domain"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type GuestHandler struct {
+	repo            domain.IGuestRepository
+	reservationRepo reservationDomain.IReservationRepository
+	rulesRepo       spaceDomain.ISpaceRulesRepository
+	vehicleRepo     vehicleDomain.IVehicleRepository
+}
+
+func NewGuestHandler(repo domain.IGuestRepository, reservationRepo reservationDomain.IReservationRepository, rulesRepo spaceDomain.ISpaceRulesRepository, vehicleRepo vehicleDomain.IVehicleRepository) *GuestHandler {
+	return &GuestHandler{
+		repo:            repo,
+		reservationRepo: reservationRepo,
+		rulesRepo:       rulesRepo,
+		vehicleRepo:     vehicleRepo,
+	}
+}
+
+// AddGuestsRequest represents the guests added to the list of a reservation
+// swagger:model
+type AddGuestsRequest struct {
+	Guests []domain.Guest `json:"guests" binding:"required,min=1,dive"`
+}
+
+// GetByReservation godoc
+// @Summary List the guests of a reservation
+// @Description Retrieve the guest list of a reservation with the arrival time of each guest
+// @Tags Guests
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Success 200 {array} domain.Guest
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /reservations/{id}/guests [get]
+func (c *GuestHandler) GetByReservation(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	guests, err := c.repo.GetByReservation(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, guests)
+}
+
+// AddGuests godoc
+// @Summary Add guests to a reservation
+// @Description Adds guests with name, document and vehicle plate to the guest list of a reservation, respecting the capacity of the space
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Param body body AddGuestsRequest true "Guests"
+// @Success 201 {array} domain.Guest
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /reservations/{id}/guests [post]
+func (c *GuestHandler) AddGuests(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input AddGuestsRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reservation, err := c.reservationRepo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Reserva não encontrada"})
+		return
+	}
+
+	if reservation.Status == reservationDomain.StatusCancelled || reservation.Status == reservationDomain.StatusKeysReturned {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Não é possível alterar a lista de convidados desta reserva"})
+		return
+	}
+
+	rules, err := c.rulesRepo.GetBySpace(string(reservation.Space))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := c.repo.GetByReservation(reservation.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(existing)+len(input.Guests) > rules.Capacity {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("A lista de convidados excede a capacidade do espaço (%d convidados)", rules.Capacity)})
+		return
+	}
+
+	documents := map[string]bool{}
+	for _, guest := range existing {
+		if guest.Document != "" {
+			documents[guest.Document] = true
+		}
+	}
+
+	guests := make([]domain.Guest, 0, len(input.Guests))
+	for _, guest := range input.Guests {
+		if err := guest.Normalize(); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if guest.Name == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "O nome do convidado é obrigatório"})
+			return
+		}
+		if guest.Document != "" && documents[guest.Document] {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("O documento %s já está na lista de convidados", guest.Document)})
+			return
+		}
+		documents[guest.Document] = guest.Document != ""
+
+		guests = append(guests, domain.Guest{
+			ReservationID: reservation.ID,
+			Name:          guest.Name,
+			Document:      guest.Document,
+			VehiclePlate:  guest.VehiclePlate,
+		})
+	}
+
+	if err := c.repo.CreateMany(guests); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, guests)
+}
+
+// DeleteGuest godoc
+// @Summary Remove a guest from a reservation
+// @Description Removes a guest who has not arrived yet from the guest list of a reservation
+// @Tags Guests
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Param guestId path int true "Guest ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /reservations/{id}/guests/{guestId} [delete]
+func (c *GuestHandler) DeleteGuest(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	guestID, err := strconv.ParseUint(ctx.Param("guestId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID do convidado inválido"})
+		return
+	}
+
+	guest, err := c.repo.GetByID(uint(guestID))
+	if err != nil || guest.ReservationID != uint(id) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Convidado não encontrado"})
+		return
+	}
+
+	if guest.ArrivedAt != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "O convidado já entrou no condomínio"})
+		return
+	}
+
+	if err := c.repo.Delete(guest.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// CheckIn godoc
+// @Summary Check in a visitor at the gate
+// @Description Looks for the visitor on the guest lists of the reservations happening now, by document, vehicle plate or name. Listed guests are marked as arrived; anyone not on the list is recorded and the host is alerted. Without a reservation_id and with several reservations open, the hosts of all of them are alerted. When the plate belongs to a vehicle registered to a resident, the vehicle and its unit are returned too.
+// @Tags Guests
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param body body domain.CheckInRequest true "Visitor identification"
+// @Success 200 {object} domain.CheckInResult
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /gate/check-in [post]
+func (c *GuestHandler) CheckIn(ctx *gin.Context) {
+	var input domain.CheckInRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	input.Normalize()
+	if input.IsEmpty() {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Informe o nome, o documento ou a placa do visitante"})
+		return
+	}
+
+	now := time.Now()
+
+	reservations, err := c.openReservations(input.ReservationID, now)
+	if errors.Is(err, domain.ErrCheckInReservationNotFound) || errors.Is(err, domain.ErrCheckInClosed) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ids := make([]uint, 0, len(reservations))
+	for _, reservation := range reservations {
+		ids = append(ids, reservation.ID)
+	}
+
+	guests, err := c.repo.GetByReservations(ids)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	vehicle, err := c.lookupVehicle(input.VehiclePlate)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if guest := domain.FindGuest(guests, &input); guest != nil {
+		result := domain.CheckInResult{Status: domain.CheckInAlreadyArrived, ReservationID: &guest.ReservationID, Guest: guest, Vehicle: vehicle}
+
+		if guest.ArrivedAt == nil {
+			guest.ArrivedAt = &now
+			guest.CheckedInByID = middleware.GetUserID(ctx)
+			if err := c.repo.Update(guest); err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			result.Status = domain.CheckInConfirmed
+		}
+
+		ctx.JSON(http.StatusOK, result)
+		return
+	}
+
+	arrival := domain.UnlistedArrival{
+		ReservationID:  input.ReservationID,
+		Name:           input.Name,
+		Document:       input.Document,
+		VehiclePlate:   input.VehiclePlate,
+		ArrivedAt:      now,
+		RegisteredByID: middleware.GetUserID(ctx),
+	}
+	if arrival.ReservationID == nil && len(reservations) == 1 {
+		arrival.ReservationID = &reservations[0].ID
+	}
+
+	if err := c.repo.CreateUnlistedArrival(&arrival); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	// when the visitor could be going to any of several reservations, every host is alerted
+	hosts := []*uint{}
+	if arrival.ReservationID != nil {
+		hosts = append(hosts, arrival.ReservationID)
+	} else {
+		for i := range reservations {
+			hosts = append(hosts, &reservations[i].ID)
+		}
+	}
+
+	for _, reservationID := range hosts {
+		eventbus.Publish("UnlistedGuestArrived", &eventbus.UnlistedGuestArrivedEvent{
+			ReservationID: reservationID,
+			Channel:       string(reminderDomain.ReminderChannelWhatsApp),
+			GuestName:     arrival.Name,
+			Document:      arrival.Document,
+			VehiclePlate:  arrival.VehiclePlate,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, domain.CheckInResult{Status: domain.CheckInNotListed, ReservationID: arrival.ReservationID, Vehicle: vehicle})
+}
+
+// lookupVehicle checks the plate of the visitor against the vehicles of the residents, returning nil
+// when no plate was given or the plate is not registered
+func (c *GuestHandler) lookupVehicle(plate string) (*vehicleDomain.VehicleLookup, error) {
+	plates := vehicleDomain.PlateVariants(plate)
+	if len(plates) == 0 {
+		return nil, nil
+	}
+
+	vehicle, err := c.vehicleRepo.FindByPlates(plates)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	lookup := vehicleDomain.NewVehicleLookup(plates[0], vehicle)
+	return &lookup, nil
+}
+
+// GetUnlistedArrivals godoc
+// @Summary List visitors not on a guest list
+// @Description Get paginated list of visitors who showed up at the gate without being on the guest list
+// @Tags Guests
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param pageSize query int false "Items per page" minimum(1) maximum(100) default(10)
+// @Success 200 {array} domain.UnlistedArrival
+// @Failure 401
+// @Failure 500
+// @Router /gate/unlisted-arrivals [get]
+func (c *GuestHandler) GetUnlistedArrivals(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.Query("page"))
+	pageSize, _ := strconv.Atoi(ctx.Query("pageSize"))
+	arrivals, err := c.repo.GetUnlistedArrivals(page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, arrivals)
+}
+
+// ListCheckInStatuses godoc
+// @Summary List check-in statuses
+// @Description Returns the list of possible results of a gate check-in
+// @Tags Guests
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.CheckInStatus
+// @Router /gate/checkInStatus [get]
+func (c *GuestHandler) ListCheckInStatuses(ctx *gin.Context) {
+	statuses := []domain.CheckInStatus{
+		domain.CheckInConfirmed,
+		domain.CheckInAlreadyArrived,
+		domain.CheckInNotListed,
+	}
+	ctx.JSON(http.StatusOK, statuses)
+}
+
+// openReservations returns the reservation chosen by the gate, or every reservation whose guests may check in now.
+func (c *GuestHandler) openReservations(reservationID *uint, now time.Time) ([]reservationDomain.Reservation, error) {
+	if reservationID != nil {
+		reservation, err := c.reservationRepo.GetByID(*reservationID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domain.ErrCheckInReservationNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !domain.IsCheckInOpen(reservation, now) {
+			return nil, domain.ErrCheckInClosed
+		}
+		return []reservationDomain.Reservation{*reservation}, nil
+	}
+
+	candidates, err := c.reservationRepo.FindByDateRange(now.Add(-24*time.Hour), now.Add(domain.CheckInWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	open := []reservationDomain.Reservation{}
+	for i := range candidates {
+		if domain.IsCheckInOpen(&candidates[i], now) {
+			open = append(open, candidates[i])
+		}
+	}
+	return open, nil
+}
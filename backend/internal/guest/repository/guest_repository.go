@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"portarius/internal/guest/domain"
+	"portarius/internal/infra"
+
+	"gorm.io/gorm"
+)
+
+type guestRepository struct {
+	db *gorm.DB
+}
+
+func NewGuestRepository(db *gorm.DB) domain.IGuestRepository {
+	return &guestRepository{db: db}
+}
+
+func (r *guestRepository) GetByReservation(reservationID uint) ([]domain.Guest, error) {
+	var guests []domain.Guest
+	err := r.db.Where("reservation_id = ?", reservationID).Order("name ASC").Find(&guests).Error
+	return guests, err
+}
+
+func (r *guestRepository) GetByReservations(reservationIDs []uint) ([]domain.Guest, error) {
+	var guests []domain.Guest
+	if len(reservationIDs) == 0 {
+		return guests, nil
+	}
+	err := r.db.Where("reservation_id IN ?", reservationIDs).Find(&guests).Error
+	return guests, err
+}
+
+func (r *guestRepository) GetByID(id uint) (*domain.Guest, error) {
+	var guest domain.Guest
+	err := r.db.First(&guest, id).Error
+	return &guest, err
+}
+
+func (r *guestRepository) CountByReservation(reservationID uint) (int64, error) {
+	var count int64
+	err := r.db.Model(&domain.Guest{}).Where("reservation_id = ?", reservationID).Count(&count).Error
+	return count, err
+}
+
+func (r *guestRepository) CreateMany(guests []domain.Guest) error {
+	return r.db.Create(&guests).Error
+}
+
+func (r *guestRepository) Update(guest *domain.Guest) error {
+	return r.db.Save(guest).Error
+}
+
+func (r *guestRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Guest{}, id).Error
+}
+
+func (r *guestRepository) CreateUnlistedArrival(arrival *domain.UnlistedArrival) error {
+	return r.db.Create(arrival).Error
+}
+
+func (r *guestRepository) GetUnlistedArrivals(page, pageSize int) ([]domain.UnlistedArrival, error) {
+	var arrivals []domain.UnlistedArrival
+	err := r.db.Scopes(infra.Paginate(page, pageSize)).Order("arrived_at DESC").Find(&arrivals).Error
+	return arrivals, err
+}
@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"portarius/internal/guest/domain"
+	guestHandler "portarius/internal/guest/handler"
+	"portarius/internal/guest/repository"
+	reservationDomain "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+	spaceDomain "portarius/internal/space/domain"
+	spaceRepository "portarius/internal/space/repository"
+	vehicleDomain "portarius/internal/vehicle/domain"
+	vehicleRepository "portarius/internal/vehicle/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterGuestRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	var (
+		repo            domain.IGuestRepository                  = repository.NewGuestRepository(db)
+		reservationRepo reservationDomain.IReservationRepository = reservationRepository.NewReservationRepository(db)
+		rulesRepo       spaceDomain.ISpaceRulesRepository        = spaceRepository.NewSpaceRulesRepository(db)
+		vehicleRepo     vehicleDomain.IVehicleRepository         = vehicleRepository.NewVehicleRepository(db)
+	)
+
+	handler := guestHandler.NewGuestHandler(repo, reservationRepo, rulesRepo, vehicleRepo)
+
+	reservations := router.Group("/reservations")
+	{
+		reservations.GET("/:id/guests", handler.GetByReservation)
+		reservations.POST("/:id/guests", handler.AddGuests)
+		reservations.DELETE("/:id/guests/:guestId", handler.DeleteGuest)
+	}
+
+	gate := router.Group("/gate")
+	{
+		gate.POST("/check-in", handler.CheckIn)
+		gate.GET("/unlisted-arrivals", handler.GetUnlistedArrivals)
+		gate.GET("/checkInStatus", handler.ListCheckInStatuses)
+	}
+}
@@ -8,6 +8,11 @@ import (
 )
 
 func IsHolyday(date time.Time) bool {
+	_, ok := FindHolyday(date)
+	return ok
+}
+
+func FindHolyday(date time.Time) (domain.Holyday, bool) {
 	holidays := GetHolidaysMock(date.Year())
 
 	dateStr := date.Format("2006-01-02")
@@ -15,11 +20,11 @@ func IsHolyday(date time.Time) bool {
 	for _, holiday := range holidays {
 		holidayStr := holiday.Date.Format("2006-01-02")
 		if holidayStr == dateStr {
-			return true
+			return holiday, true
 		}
 	}
 
-	return false
+	return domain.Holyday{}, false
 }
 
 func GetHolidays(year int) []domain.Holyday {
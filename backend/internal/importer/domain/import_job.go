@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ImportKind string
+
+const (
+	ImportKindResidents         ImportKind = "MORADORES"
+	ImportKindPets              ImportKind = "PETS"
+	ImportKindSalonReservations ImportKind = "RESERVAS_SALAO"
+)
+
+type ImportJobStatus string
+
+const (
+	ImportJobPending    ImportJobStatus = "PENDENTE"
+	ImportJobProcessing ImportJobStatus = "PROCESSANDO"
+	ImportJobCompleted  ImportJobStatus = "CONCLUIDO"
+	ImportJobFailed     ImportJobStatus = "FALHOU"
+)
+
+type ImportFormat string
+
+const (
+	ImportFormatCSV  ImportFormat = "CSV"
+	ImportFormatXLSX ImportFormat = "XLSX"
+)
+
+// ErrRowSkipped marks a row that has nothing to import, such as a resident line without a pet.
+// Skipped rows are counted but do not make the import fail.
+var ErrRowSkipped = errors.New("linha ignorada")
+
+// ImportJob represents a file imported in the background. Rows are committed in a single transaction:
+// when any row fails nothing is written and the failed rows are available in the error file.
+// swagger:model
+type ImportJob struct {
+	gorm.Model    `swaggerignore:"true"`
+	Kind          ImportKind      `json:"kind" gorm:"type:varchar(20);not null;index"`
+	FileName      string          `json:"file_name" gorm:"not null"`
+	Format        ImportFormat    `json:"format" gorm:"type:varchar(10);not null"`
+	Status        ImportJobStatus `json:"status" gorm:"type:varchar(20);not null;default:'PENDENTE';index"`
+	TotalRows     int             `json:"total_rows" gorm:"default:0"`
+	ProcessedRows int             `json:"processed_rows" gorm:"default:0"`
+	ImportedRows  int             `json:"imported_rows" gorm:"default:0"`
+	SkippedRows   int             `json:"skipped_rows" gorm:"default:0"`
+	FailedRows    int             `json:"failed_rows" gorm:"default:0"`
+	Message       string          `json:"message" gorm:"type:text"`
+	ErrorFile     []byte          `json:"-" gorm:"type:bytea"`
+	HasErrorFile  bool            `json:"has_error_file" gorm:"default:false"`
+	CreatedByID   *uint           `json:"created_by_id"`
+	StartedAt     *time.Time      `json:"started_at" gorm:"type:timestamp"`
+	FinishedAt    *time.Time      `json:"finished_at" gorm:"type:timestamp"`
+}
+
+func ParseImportKind(value string) (ImportKind, error) {
+	switch ImportKind(strings.ToUpper(value)) {
+	case ImportKindResidents, ImportKindPets, ImportKindSalonReservations:
+		return ImportKind(strings.ToUpper(value)), nil
+	default:
+		return "", fmt.Errorf("tipo de importação inválido: %s", value)
+	}
+}
+
+// DetectImportFormat returns the format of a file from its extension
+func DetectImportFormat(fileName string) (ImportFormat, error) {
+	switch strings.ToLower(filepath.Ext(fileName)) {
+	case ".csv", ".txt":
+		return ImportFormatCSV, nil
+	case ".xlsx":
+		return ImportFormatXLSX, nil
+	default:
+		return "", fmt.Errorf("formato de arquivo não suportado, envie um CSV ou XLSX")
+	}
+}
+
+// Progress returns the percentage of rows already processed
+func (j *ImportJob) Progress() float64 {
+	if j.TotalRows == 0 {
+		if j.Status == ImportJobCompleted || j.Status == ImportJobFailed {
+			return 100
+		}
+		return 0
+	}
+	return float64(j.ProcessedRows) * 100 / float64(j.TotalRows)
+}
+
+func (j *ImportJob) Start(totalRows int, now time.Time) {
+	j.Status = ImportJobProcessing
+	j.TotalRows = totalRows
+	j.StartedAt = &now
+}
+
+// Finish closes the job. A job with failed rows is marked as failed and keeps the error file; as the
+// transaction is rolled back, no row counts as imported or skipped.
+func (j *ImportJob) Finish(errorFile []byte, now time.Time) {
+	j.FinishedAt = &now
+
+	if j.FailedRows > 0 {
+		j.Status = ImportJobFailed
+		j.ImportedRows = 0
+		j.SkippedRows = 0
+		j.ErrorFile = errorFile
+		j.HasErrorFile = len(errorFile) > 0
+		j.Message = fmt.Sprintf("%d linha(s) com erro, nenhuma alteração foi gravada", j.FailedRows)
+		return
+	}
+
+	j.Status = ImportJobCompleted
+	j.Message = fmt.Sprintf("%d linha(s) importada(s), %d ignorada(s)", j.ImportedRows, j.SkippedRows)
+}
+
+// Fail closes the job with an error that prevented the file from being processed or saved. Nothing is
+// written, so no row counts as imported or skipped.
+func (j *ImportJob) Fail(err error, now time.Time) {
+	j.FinishedAt = &now
+	j.Status = ImportJobFailed
+	j.ImportedRows = 0
+	j.SkippedRows = 0
+	j.Message = err.Error()
+}
+
+func (j *ImportJob) ErrorFileName() string {
+	name := strings.TrimSuffix(j.FileName, filepath.Ext(j.FileName))
+	return fmt.Sprintf("%s_erros.csv", name)
+}
@@ -0,0 +1,9 @@
+package domain
+
+type IImportJobRepository interface {
+	Create(job *ImportJob) error
+	Update(job *ImportJob) error
+	UpdateProgress(job *ImportJob) error
+	GetByID(id uint) (*ImportJob, error)
+	GetAll(page, pageSize int) ([]ImportJob, error)
+}
@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"portarius/internal/utils"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Column describes a field read by an importer. Aliases are the accepted header names,
+// compared in lowercase and without accents.
+type Column struct {
+	Field    string
+	Aliases  []string
+	Required bool
+}
+
+// RowImporter imports the rows of one kind of file. ImportRow runs inside the transaction of the job
+// and returns ErrRowSkipped for rows that have nothing to import.
+type RowImporter interface {
+	Kind() ImportKind
+	Columns() []Column
+	ImportRow(tx *gorm.DB, row Row) error
+}
+
+// ColumnMap maps the fields of an importer to the position of their column in the file
+type ColumnMap map[string]int
+
+// Row represents a data row of an imported file, with its values accessed by field
+type Row struct {
+	Line    int
+	Values  []string
+	columns ColumnMap
+}
+
+// RowError represents a row rejected by an importer
+type RowError struct {
+	Line    int
+	Values  []string
+	Message string
+}
+
+// MapColumns finds the column of each field in the header, failing when a required one is missing
+func MapColumns(header []string, columns []Column) (ColumnMap, error) {
+	positions := map[string]int{}
+	for i, name := range header {
+		positions[NormalizeHeader(name)] = i
+	}
+
+	mapping := ColumnMap{}
+	missing := []string{}
+
+	for _, column := range columns {
+		found := false
+		for _, alias := range append([]string{column.Field}, column.Aliases...) {
+			if index, ok := positions[NormalizeHeader(alias)]; ok {
+				mapping[column.Field] = index
+				found = true
+				break
+			}
+		}
+		if !found && column.Required {
+			name := column.Field
+			if len(column.Aliases) > 0 {
+				name = column.Aliases[0]
+			}
+			missing = append(missing, name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("colunas obrigatórias ausentes no cabeçalho: %s", strings.Join(missing, ", "))
+	}
+
+	return mapping, nil
+}
+
+func NewRow(line int, values []string, columns ColumnMap) Row {
+	return Row{Line: line, Values: values, columns: columns}
+}
+
+// Get returns the trimmed value of a field, or an empty string when the column is absent
+func (r Row) Get(field string) string {
+	index, ok := r.columns[field]
+	if !ok || index >= len(r.Values) {
+		return ""
+	}
+	return strings.TrimSpace(r.Values[index])
+}
+
+// Require returns the value of a field, failing when it is empty
+func (r Row) Require(field, label string) (string, error) {
+	value := r.Get(field)
+	if value == "" {
+		return "", fmt.Errorf("%s é obrigatório", label)
+	}
+	return value, nil
+}
+
+// BuildErrorFile writes the rejected rows as CSV, with the original columns followed by the line number and the error
+func BuildErrorFile(header []string, rowErrors []RowError) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := csv.NewWriter(&buffer)
+
+	if err := writer.Write(append(append([]string{}, header...), "Linha", "Erro")); err != nil {
+		return nil, err
+	}
+
+	for _, rowError := range rowErrors {
+		values := make([]string, len(header))
+		copy(values, rowError.Values)
+		values = append(values, fmt.Sprintf("%d", rowError.Line), rowError.Message)
+		if err := writer.Write(values); err != nil {
+			return nil, err
+		}
+	}
+
+	writer.Flush()
+	return buffer.Bytes(), writer.Error()
+}
+
+// NormalizeHeader folds a header name to lowercase without accents, extra spaces or a byte order mark
+func NormalizeHeader(name string) string {
+	name = strings.TrimPrefix(name, "\uFEFF")
+	name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+	return utils.RemoveAccents(name)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// MaxImportRows bounds the size of an imported file
+const MaxImportRows = 20000
+
+// Table represents the header and rows of an imported spreadsheet
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+// ReadTable reads a CSV (comma or semicolon separated) or the first sheet of an XLSX file.
+// Empty rows are dropped.
+func ReadTable(format ImportFormat, data []byte) (*Table, error) {
+	var records [][]string
+	var err error
+
+	switch format {
+	case ImportFormatCSV:
+		records, err = readCSV(data)
+	case ImportFormatXLSX:
+		records, err = readXLSX(data)
+	default:
+		return nil, fmt.Errorf("formato de arquivo não suportado: %s", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	table := &Table{}
+	for _, record := range records {
+		if isEmptyRecord(record) {
+			continue
+		}
+		if table.Header == nil {
+			table.Header = record
+			continue
+		}
+		table.Rows = append(table.Rows, record)
+	}
+
+	if table.Header == nil {
+		return nil, fmt.Errorf("o arquivo está vazio")
+	}
+	if len(table.Rows) > MaxImportRows {
+		return nil, fmt.Errorf("o arquivo tem mais de %d linhas", MaxImportRows)
+	}
+
+	return table, nil
+}
+
+func readCSV(data []byte) ([][]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.Comma = detectSeparator(data)
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler CSV: %v", err)
+	}
+	return records, nil
+}
+
+func readXLSX(data []byte) ([][]string, error) {
+	file, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("erro ao abrir planilha: %v", err)
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("a planilha não tem abas")
+	}
+
+	records, err := file.GetRows(sheets[0])
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler planilha: %v", err)
+	}
+	return records, nil
+}
+
+// detectSeparator picks semicolon when the header has more of them than commas, as in spreadsheets
+// exported with the Brazilian locale
+func detectSeparator(data []byte) rune {
+	header, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(header, []byte(";")) > bytes.Count(header, []byte(",")) {
+		return ';'
+	}
+	return ','
+}
+
+func isEmptyRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package domain_test
+
+import (
+	"bytes"
+	"errors"
+	"portarius/internal/importer/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+var residentColumns = []domain.Column{
+	{Field: "block", Aliases: []string{"Bloco"}, Required: true},
+	{Field: "apartment", Aliases: []string{"Apto", "Apartamento"}, Required: true},
+	{Field: "name", Aliases: []string{"Morador", "Nome"}, Required: true},
+	{Field: "email", Aliases: []string{"E-mail"}},
+}
+
+func TestReadTable_CSV(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "comma", data: "Bloco,Apto,Morador\nA,30,Fulano\n,,\nB,40,Cicrano\n"},
+		{name: "semicolon with BOM", data: "\xef\xbb\xbfBloco;Apto;Morador\r\nA;30;Fulano\r\nB;40;Cicrano\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := domain.ReadTable(domain.ImportFormatCSV, []byte(tt.data))
+			require.NoError(t, err)
+			assert.Equal(t, []string{"Bloco", "Apto", "Morador"}, table.Header)
+			assert.Equal(t, [][]string{{"A", "30", "Fulano"}, {"B", "40", "Cicrano"}}, table.Rows)
+		})
+	}
+
+	_, err := domain.ReadTable(domain.ImportFormatCSV, []byte("\n\n"))
+	assert.Error(t, err)
+}
+
+func TestReadTable_XLSX(t *testing.T) {
+	file := excelize.NewFile()
+	sheet := file.GetSheetName(0)
+	require.NoError(t, file.SetSheetRow(sheet, "A1", &[]string{"Bloco", "Apto", "Morador"}))
+	require.NoError(t, file.SetSheetRow(sheet, "A2", &[]interface{}{"A", 30, "Fulano"}))
+
+	var buffer bytes.Buffer
+	require.NoError(t, file.Write(&buffer))
+
+	table, err := domain.ReadTable(domain.ImportFormatXLSX, buffer.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Bloco", "Apto", "Morador"}, table.Header)
+	assert.Equal(t, [][]string{{"A", "30", "Fulano"}}, table.Rows)
+}
+
+func TestMapColumns(t *testing.T) {
+	columns, err := domain.MapColumns([]string{" apartamento ", "NOME", "Bloco", "Observação"}, residentColumns)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ColumnMap{"apartment": 0, "name": 1, "block": 2}, columns)
+
+	row := domain.NewRow(2, []string{" 30 ", "Fulano"}, columns)
+	assert.Equal(t, "30", row.Get("apartment"))
+	assert.Equal(t, "", row.Get("block"))
+	assert.Equal(t, "", row.Get("email"))
+
+	_, err = row.Require("block", "o bloco")
+	assert.EqualError(t, err, "o bloco é obrigatório")
+
+	_, err = domain.MapColumns([]string{"Bloco", "Nome"}, residentColumns)
+	assert.EqualError(t, err, "colunas obrigatórias ausentes no cabeçalho: Apto")
+}
+
+func TestBuildErrorFile(t *testing.T) {
+	data, err := domain.BuildErrorFile([]string{"Bloco", "Apto"}, []domain.RowError{
+		{Line: 3, Values: []string{"Z"}, Message: "apartamento inválido"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Bloco,Apto,Linha,Erro\nZ,,3,apartamento inválido\n", string(data))
+}
+
+func TestImportJob_Finish(t *testing.T) {
+	now := time.Date(2025, time.November, 3, 10, 0, 0, 0, time.UTC)
+
+	job := &domain.ImportJob{FileName: "moradores.xlsx"}
+	job.Start(4, now)
+	assert.Equal(t, domain.ImportJobProcessing, job.Status)
+
+	job.ProcessedRows, job.ImportedRows, job.SkippedRows = 4, 3, 1
+	job.Finish(nil, now)
+	assert.Equal(t, domain.ImportJobCompleted, job.Status)
+	assert.Equal(t, 100.0, job.Progress())
+	assert.False(t, job.HasErrorFile)
+
+	failed := &domain.ImportJob{FileName: "moradores.xlsx", TotalRows: 4, ProcessedRows: 2, ImportedRows: 1, FailedRows: 1}
+	assert.Equal(t, 50.0, failed.Progress())
+	failed.Finish([]byte("Bloco,Linha,Erro\n"), now)
+	assert.Equal(t, domain.ImportJobFailed, failed.Status)
+	assert.Equal(t, 0, failed.ImportedRows)
+	assert.True(t, failed.HasErrorFile)
+	assert.Equal(t, "moradores_erros.csv", failed.ErrorFileName())
+}
+
+func TestImportJob_Fail(t *testing.T) {
+	job := &domain.ImportJob{TotalRows: 3, ProcessedRows: 3, ImportedRows: 2, SkippedRows: 1}
+	job.Fail(errors.New("erro ao gravar a importação"), time.Now())
+
+	assert.Equal(t, domain.ImportJobFailed, job.Status)
+	assert.Equal(t, 0, job.ImportedRows)
+	assert.Equal(t, 0, job.SkippedRows)
+	assert.Equal(t, "erro ao gravar a importação", job.Message)
+}
@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"portarius/internal/importer/domain"
+	importerService "portarius/internal/importer/service"
+	middleware "portarius/internal/middleware/auth"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxImportFileSize = 10 << 20
+
+type ImportHandler struct {
+	repo    domain.IImportJobRepository
+	service *importerService.ImportService
+}
+
+// ImportJobResponse represents an import job with its progress
+// swagger:model
+type ImportJobResponse struct {
+	*domain.ImportJob
+	Progress float64 `json:"progress"`
+}
+
+func NewImportHandler(repo domain.IImportJobRepository, service *importerService.ImportService) *ImportHandler {
+	return &ImportHandler{repo: repo, service: service}
+}
+
+// Create godoc
+// @Summary Start an import
+// @Description Uploads a CSV or XLSX file and imports it in the background. Columns are matched by header name. Rows are committed together: if any row is rejected nothing is written and the rejected rows can be downloaded from the error file. Poll the job to follow its progress.
+// @Tags Imports
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or XLSX file"
+// @Param kind formData string true "Import kind" Enums(MORADORES,PETS,RESERVAS_SALAO)
+// @Success 202 {object} ImportJobResponse
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /imports [post]
+func (c *ImportHandler) Create(ctx *gin.Context) {
+	kind, err := domain.ParseImportKind(ctx.PostForm("kind"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.start(ctx, kind)
+}
+
+// ImportResidents godoc
+// @Summary Import residents
+// @Description Kept for clients of the former residents import. Starts an import job of kind MORADORES with the uploaded CSV or XLSX file; poll the job at /imports/{id}.
+// @Tags Residents
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or XLSX file"
+// @Success 202 {object} ImportJobResponse
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /residents/import [post]
+func (c *ImportHandler) ImportResidents(ctx *gin.Context) {
+	c.start(ctx, domain.ImportKindResidents)
+}
+
+// ImportPets godoc
+// @Summary Import pets
+// @Description Kept for clients of the former pets import. Starts an import job of kind PETS with the uploaded CSV or XLSX file; poll the job at /imports/{id}.
+// @Tags Inventory
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "CSV or XLSX file"
+// @Success 202 {object} ImportJobResponse
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /inventory/import-pets [post]
+func (c *ImportHandler) ImportPets(ctx *gin.Context) {
+	c.start(ctx, domain.ImportKindPets)
+}
+
+func (c *ImportHandler) start(ctx *gin.Context, kind domain.ImportKind) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Nenhum arquivo enviado"})
+		return
+	}
+
+	if fileHeader.Size > maxImportFileSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo muito grande"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	job, err := c.service.Start(kind, fileHeader.Filename, data, middleware.GetUserID(ctx))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, ImportJobResponse{ImportJob: job, Progress: job.Progress()})
+}
+
+// GetAll godoc
+// @Summary List imports
+// @Description Get paginated list of import jobs, most recent first
+// @Tags Imports
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param pageSize query int false "Items per page" minimum(1) maximum(100) default(10)
+// @Success 200 {array} ImportJobResponse
+// @Failure 401
+// @Failure 500
+// @Router /imports [get]
+func (c *ImportHandler) GetAll(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.Query("page"))
+	pageSize, _ := strconv.Atoi(ctx.Query("pageSize"))
+
+	jobs, err := c.repo.GetAll(page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := make([]ImportJobResponse, 0, len(jobs))
+	for i := range jobs {
+		response = append(response, ImportJobResponse{ImportJob: &jobs[i], Progress: jobs[i].Progress()})
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetByID godoc
+// @Summary Get an import
+// @Description Returns the status and progress of an import job
+// @Tags Imports
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Import job ID"
+// @Success 200 {object} ImportJobResponse
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /imports/{id} [get]
+func (c *ImportHandler) GetByID(ctx *gin.Context) {
+	job, ok := c.findJob(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ImportJobResponse{ImportJob: job, Progress: job.Progress()})
+}
+
+// GetErrorFile godoc
+// @Summary Download the error file of an import
+// @Description Returns a CSV with the rejected rows, their line in the original file and the reason
+// @Tags Imports
+// @Produce text/csv
+// @Security BearerAuth
+// @Param id path int true "Import job ID"
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /imports/{id}/errors [get]
+func (c *ImportHandler) GetErrorFile(ctx *gin.Context) {
+	job, ok := c.findJob(ctx)
+	if !ok {
+		return
+	}
+
+	if !job.HasErrorFile {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Importação sem arquivo de erros"})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", job.ErrorFileName()))
+	ctx.Data(http.StatusOK, "text/csv; charset=utf-8", job.ErrorFile)
+}
+
+// ListImportKinds godoc
+// @Summary List all import kinds
+// @Description Returns the list of files that can be imported
+// @Tags Imports
+// @Produce json
+// @Success 200 {array} domain.ImportKind "List of import kinds"
+// @Router /imports/importKinds [get]
+func (c *ImportHandler) ListImportKinds(ctx *gin.Context) {
+	kinds := []domain.ImportKind{
+		domain.ImportKindResidents,
+		domain.ImportKindPets,
+		domain.ImportKindSalonReservations,
+	}
+
+	ctx.JSON(http.StatusOK, kinds)
+}
+
+// ListImportStatus godoc
+// @Summary List all import job statuses
+// @Description Returns the list of possible statuses of an import job
+// @Tags Imports
+// @Produce json
+// @Success 200 {array} domain.ImportJobStatus "List of import job statuses"
+// @Router /imports/importStatus [get]
+func (c *ImportHandler) ListImportStatus(ctx *gin.Context) {
+	statuses := []domain.ImportJobStatus{
+		domain.ImportJobPending,
+		domain.ImportJobProcessing,
+		domain.ImportJobCompleted,
+		domain.ImportJobFailed,
+	}
+
+	ctx.JSON(http.StatusOK, statuses)
+}
+
+func (c *ImportHandler) findJob(ctx *gin.Context) (*domain.ImportJob, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return nil, false
+	}
+
+	job, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Importação não encontrada"})
+		return nil, false
+	}
+
+	return job, true
+}
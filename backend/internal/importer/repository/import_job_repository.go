@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"portarius/internal/importer/domain"
+	"portarius/internal/infra"
+
+	"gorm.io/gorm"
+)
+
+type importJobRepository struct {
+	db *gorm.DB
+}
+
+func NewImportJobRepository(db *gorm.DB) domain.IImportJobRepository {
+	return &importJobRepository{db: db}
+}
+
+func (r *importJobRepository) Create(job *domain.ImportJob) error {
+	return r.db.Create(job).Error
+}
+
+func (r *importJobRepository) Update(job *domain.ImportJob) error {
+	return r.db.Save(job).Error
+}
+
+// UpdateProgress saves only the counters, so polling clients see the job advance while it runs
+func (r *importJobRepository) UpdateProgress(job *domain.ImportJob) error {
+	return r.db.Model(job).Select("ProcessedRows", "ImportedRows", "SkippedRows", "FailedRows").Updates(job).Error
+}
+
+func (r *importJobRepository) GetByID(id uint) (*domain.ImportJob, error) {
+	var job domain.ImportJob
+	err := r.db.First(&job, id).Error
+	return &job, err
+}
+
+// GetAll lists the jobs without their error files
+func (r *importJobRepository) GetAll(page, pageSize int) ([]domain.ImportJob, error) {
+	var jobs []domain.ImportJob
+	err := r.db.Omit("ErrorFile").Scopes(infra.Paginate(page, pageSize)).Order("created_at DESC").Find(&jobs).Error
+	return jobs, err
+}
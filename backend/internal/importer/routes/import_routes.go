@@ -0,0 +1,41 @@
+package routes
+
+import (
+	"portarius/internal/importer/domain"
+	importerHandler "portarius/internal/importer/handler"
+	"portarius/internal/importer/repository"
+	importerService "portarius/internal/importer/service"
+	inventoryService "portarius/internal/inventory/service"
+	reservationService "portarius/internal/reservation/service"
+	residentService "portarius/internal/resident/service"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterImportRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	var (
+		repo domain.IImportJobRepository = repository.NewImportJobRepository(db)
+	)
+
+	service := importerService.NewImportService(db, repo,
+		residentService.NewResidentRowImporter(),
+		inventoryService.NewPetRowImporter(),
+		reservationService.NewSalonRowImporter(),
+	)
+	handler := importerHandler.NewImportHandler(repo, service)
+
+	imports := router.Group("/imports")
+	{
+		imports.POST("/", handler.Create)
+		imports.GET("/", handler.GetAll)
+		imports.GET("/:id", handler.GetByID)
+		imports.GET("/:id/errors", handler.GetErrorFile)
+		imports.GET("/importKinds", handler.ListImportKinds)
+		imports.GET("/importStatus", handler.ListImportStatus)
+	}
+
+	// former import endpoints, now started as import jobs
+	router.POST("/residents/import", handler.ImportResidents)
+	router.POST("/inventory/import-pets", handler.ImportPets)
+}
@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"portarius/internal/importer/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// progressInterval is how many rows are processed between two progress updates of a job
+const progressInterval = 50
+
+// errRowsRejected rolls back the transaction of a job when any row was rejected
+var errRowsRejected = errors.New("linhas rejeitadas")
+
+type ImportService struct {
+	db        *gorm.DB
+	repo      domain.IImportJobRepository
+	importers map[domain.ImportKind]domain.RowImporter
+}
+
+func NewImportService(db *gorm.DB, repo domain.IImportJobRepository, importers ...domain.RowImporter) *ImportService {
+	service := &ImportService{
+		db:        db,
+		repo:      repo,
+		importers: map[domain.ImportKind]domain.RowImporter{},
+	}
+	for _, importer := range importers {
+		service.importers[importer.Kind()] = importer
+	}
+	return service
+}
+
+// Start validates the file header and creates the job, processing the rows in the background.
+// Errors in the file structure are returned right away; errors in rows are reported by the job.
+func (s *ImportService) Start(kind domain.ImportKind, fileName string, data []byte, userID *uint) (*domain.ImportJob, error) {
+	importer, ok := s.importers[kind]
+	if !ok {
+		return nil, fmt.Errorf("tipo de importação não suportado: %s", kind)
+	}
+
+	format, err := domain.DetectImportFormat(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	table, err := domain.ReadTable(format, data)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := domain.MapColumns(table.Header, importer.Columns())
+	if err != nil {
+		return nil, err
+	}
+
+	job := &domain.ImportJob{
+		Kind:        kind,
+		FileName:    fileName,
+		Format:      format,
+		Status:      domain.ImportJobPending,
+		TotalRows:   len(table.Rows),
+		CreatedByID: userID,
+	}
+
+	if err := s.repo.Create(job); err != nil {
+		return nil, err
+	}
+
+	running := *job
+	go s.run(&running, importer, table, columns)
+
+	return job, nil
+}
+
+func (s *ImportService) run(job *domain.ImportJob, importer domain.RowImporter, table *domain.Table, columns domain.ColumnMap) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("Import job %d panicked: %v", job.ID, r)
+			job.Fail(fmt.Errorf("erro inesperado ao processar o arquivo"), time.Now())
+			if err := s.repo.Update(job); err != nil {
+				log.Printf("Error updating import job %d: %v", job.ID, err)
+			}
+		}
+	}()
+
+	job.Start(len(table.Rows), time.Now())
+	if err := s.repo.Update(job); err != nil {
+		log.Printf("Error starting import job %d: %v", job.ID, err)
+		return
+	}
+
+	rowErrors := []domain.RowError{}
+
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		for i, values := range table.Rows {
+			// the header is line 1 of the file
+			row := domain.NewRow(i+2, values, columns)
+
+			if err := s.importRow(tx, importer, row); err != nil {
+				switch {
+				case errors.Is(err, domain.ErrRowSkipped):
+					job.SkippedRows++
+				default:
+					job.FailedRows++
+					rowErrors = append(rowErrors, domain.RowError{Line: row.Line, Values: values, Message: err.Error()})
+				}
+			} else {
+				job.ImportedRows++
+			}
+
+			job.ProcessedRows++
+			if job.ProcessedRows%progressInterval == 0 {
+				if err := s.repo.UpdateProgress(job); err != nil {
+					log.Printf("Error updating progress of import job %d: %v", job.ID, err)
+				}
+			}
+		}
+
+		if len(rowErrors) > 0 {
+			return errRowsRejected
+		}
+		return nil
+	})
+
+	if err != nil && !errors.Is(err, errRowsRejected) {
+		job.Fail(fmt.Errorf("erro ao gravar a importação: %v", err), time.Now())
+	} else {
+		errorFile, fileErr := domain.BuildErrorFile(table.Header, rowErrors)
+		if fileErr != nil {
+			log.Printf("Error building error file of import job %d: %v", job.ID, fileErr)
+		}
+		job.Finish(errorFile, time.Now())
+	}
+
+	if err := s.repo.Update(job); err != nil {
+		log.Printf("Error finishing import job %d: %v", job.ID, err)
+	}
+}
+
+// importRow runs a row inside a savepoint, so a database error in one row does not abort
+// the transaction and the remaining rows are still validated
+func (s *ImportService) importRow(tx *gorm.DB, importer domain.RowImporter, row domain.Row) error {
+	savepoint := fmt.Sprintf("import_row_%d", row.Line)
+	if err := tx.SavePoint(savepoint).Error; err != nil {
+		return err
+	}
+
+	if err := importer.ImportRow(tx, row); err != nil {
+		if rollbackErr := tx.RollbackTo(savepoint).Error; rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+
+	return tx.Exec("RELEASE SAVEPOINT " + savepoint).Error
+}
@@ -19,6 +19,22 @@ import (
 	userDomain "portarius/internal/user/domain"
 
 	reminderDomain "portarius/internal/reminder/domain"
+
+	spaceDomain "portarius/internal/space/domain"
+
+	calendarDomain "portarius/internal/calendar/domain"
+
+	financeDomain "portarius/internal/finance/domain"
+
+	reconciliationDomain "portarius/internal/reconciliation/domain"
+
+	boletoDomain "portarius/internal/boleto/domain"
+
+	guestDomain "portarius/internal/guest/domain"
+
+	importerDomain "portarius/internal/importer/domain"
+
+	vehicleDomain "portarius/internal/vehicle/domain"
 )
 
 func ConnectDB() (*gorm.DB, error) {
@@ -43,14 +59,51 @@ func RunMigrations(db *gorm.DB) {
 	err := db.AutoMigrate(
 		&inventoryDomain.Inventory{},
 		&packageDomain.Package{},
+		&packageDomain.AuthorizedPickup{},
+		&packageDomain.StorageLocation{},
+		&packageDomain.PackageAttachment{},
+		&packageDomain.PackageLossReport{},
+		&packageDomain.LossReportNote{},
+		&packageDomain.PackageReturn{},
 		&residentDomain.Resident{},
 		&reservationDomain.Reservation{},
+		&reservationDomain.ReservationInspection{},
+		&reservationDomain.InspectionPhoto{},
+		&reservationDomain.ReservationSeries{},
+		&reservationDomain.ReservationSeriesException{},
 		&userDomain.User{},
 		&reminderDomain.Reminder{},
+		&spaceDomain.SpaceBlackout{},
+		&calendarDomain.CalendarFeed{},
+		&spaceDomain.CancellationPolicy{},
+		&spaceDomain.SpaceRules{},
+		&financeDomain.FinancialEntry{},
+		&reconciliationDomain.StatementImport{},
+		&reconciliationDomain.StatementCredit{},
+		&boletoDomain.Boleto{},
+		&guestDomain.Guest{},
+		&guestDomain.UnlistedArrival{},
+		&importerDomain.ImportJob{},
+		&vehicleDomain.Vehicle{},
 	)
 	if err != nil {
 		log.Fatal("Failed to run migrations:", err)
 	}
+
+	// unique indexes replaced by partial ones that ignore soft deleted rows
+	dropIndexes(db, &vehicleDomain.Vehicle{}, "idx_vehicles_plate")
+	dropIndexes(db, &packageDomain.StorageLocation{}, "idx_storage_locations_code")
+}
+
+func dropIndexes(db *gorm.DB, model interface{}, names ...string) {
+	for _, name := range names {
+		if !db.Migrator().HasIndex(model, name) {
+			continue
+		}
+		if err := db.Migrator().DropIndex(model, name); err != nil {
+			log.Fatal("Failed to drop index "+name+":", err)
+		}
+	}
 }
 
 func Paginate(page, pageSize int) func(db *gorm.DB) *gorm.DB {
@@ -3,21 +3,18 @@ package handler
 import (
 	"net/http"
 	"portarius/internal/inventory/domain"
-	"portarius/internal/inventory/interfaces"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type InventoryHandler struct {
-	repo          domain.IInventoryRepository
-	importService interfaces.ICSVInventoryImporter
+	repo domain.IInventoryRepository
 }
 
-func NewInventoryHandler(repo domain.IInventoryRepository, importer interfaces.ICSVInventoryImporter) *InventoryHandler {
+func NewInventoryHandler(repo domain.IInventoryRepository) *InventoryHandler {
 	return &InventoryHandler{
-		repo:          repo,
-		importService: importer,
+		repo: repo,
 	}
 }
 
@@ -169,32 +166,6 @@ func (c *InventoryHandler) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Item excluído com sucesso"})
 }
 
-// ImportPets 	godoc
-// @Summary Import cars from CSV
-// @Description Import pets from CSV file
-// @Tags Inventory
-// @Accept json
-// @Produce json
-// @Security BearerAuth
-// @Param file formData file true "CSV file"
-// @Success 200
-// @Failure 400
-// @Failure 401
-// @Failure 500
-// @Router /inventory/import-pets [post]
-func (c *InventoryHandler) ImportPets(ctx *gin.Context) {
-	if err := c.importService.ImportPetsFromCSV(); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
-		return
-	}
-
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Pets importados com sucesso",
-	})
-}
-
 // ListInventoryTypes 	godoc
 // @Summary List inventory types
 // @Description List avaliable inventory types
@@ -1,5 +0,0 @@
-package interfaces
-
-type ICSVInventoryImporter interface {
-	ImportPetsFromCSV() error
-}
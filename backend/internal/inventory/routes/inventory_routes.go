@@ -3,9 +3,7 @@ package handler
 import (
 	"portarius/internal/inventory/domain"
 	inventoryHandler "portarius/internal/inventory/handler"
-	"portarius/internal/inventory/interfaces"
 	"portarius/internal/inventory/repository"
-	inventoryService "portarius/internal/inventory/service"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -14,11 +12,10 @@ import (
 func RegisterInventoryRoutes(router *gin.RouterGroup, db *gorm.DB) {
 
 	var (
-		repo     domain.IInventoryRepository      = repository.NewInventoryRepository(db)
-		importer interfaces.ICSVInventoryImporter = inventoryService.NewInventoryImportService(db)
+		repo domain.IInventoryRepository = repository.NewInventoryRepository(db)
 	)
 
-	handler := inventoryHandler.NewInventoryHandler(repo, importer)
+	handler := inventoryHandler.NewInventoryHandler(repo)
 
 	inventory := router.Group("/inventory")
 	{
@@ -27,7 +24,6 @@ func RegisterInventoryRoutes(router *gin.RouterGroup, db *gorm.DB) {
 		inventory.POST("/", handler.Create)
 		inventory.PUT("/:id", handler.Update)
 		inventory.DELETE("/:id", handler.Delete)
-		inventory.POST("/import-pets", handler.ImportPets)
 		inventory.GET("/inventory-types", handler.ListInventoryTypes)
 	}
 }
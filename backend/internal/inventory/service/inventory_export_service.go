@@ -0,0 +1,81 @@
+package inventory
+
+import (
+	"net/url"
+	exportDomain "portarius/internal/export/domain"
+	"portarius/internal/inventory/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// InventoryExporter exports the inventory items with their owners, filtered by type and owner
+type InventoryExporter struct{}
+
+func NewInventoryExporter() *InventoryExporter {
+	return &InventoryExporter{}
+}
+
+func (e *InventoryExporter) Entity() exportDomain.ExportEntity {
+	return exportDomain.ExportEntityInventory
+}
+
+func (e *InventoryExporter) Columns() []exportDomain.Column {
+	return []exportDomain.Column{
+		{Key: "id", Header: "ID"},
+		{Key: "name", Header: "Nome"},
+		{Key: "description", Header: "Descrição"},
+		{Key: "quantity", Header: "Quantidade"},
+		{Key: "inventory_type", Header: "Tipo"},
+		{Key: "owner_id", Header: "ID do proprietário"},
+		{Key: "owner_name", Header: "Proprietário"},
+		{Key: "unit", Header: "Unidade"},
+		{Key: "last_updated", Header: "Atualizado em"},
+	}
+}
+
+func (e *InventoryExporter) Query(db *gorm.DB, filters url.Values) (*gorm.DB, error) {
+	query := db.Model(&domain.Inventory{}).Preload("Owner")
+
+	if inventoryType := filters.Get("inventoryType"); inventoryType != "" {
+		query = query.Where("inventory_type = ?", strings.ToUpper(inventoryType))
+	}
+
+	ownerID, err := exportDomain.FilterID(filters, "ownerId")
+	if err != nil {
+		return nil, err
+	}
+	if ownerID != nil {
+		query = query.Where("owner_id = ?", *ownerID)
+	}
+
+	return query, nil
+}
+
+func (e *InventoryExporter) Export(query *gorm.DB, write func(values []any) error) error {
+	var items []domain.Inventory
+	return query.FindInBatches(&items, exportDomain.ExportBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, item := range items {
+			var ownerName, unit string
+			if item.Owner != nil {
+				ownerName = item.Owner.Name
+				unit = item.Owner.Block + item.Owner.Apartment
+			}
+
+			if err := write([]any{
+				item.ID,
+				item.Name,
+				item.Description,
+				item.Quantity,
+				item.InventoryType,
+				item.OwnerID,
+				ownerName,
+				unit,
+				item.LastUpdated,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
@@ -1,13 +1,11 @@
 package inventory
 
 import (
-	"encoding/csv"
+	"errors"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-	"strings"
+	"time"
 
+	importerDomain "portarius/internal/importer/domain"
 	"portarius/internal/inventory/domain"
 	residentDomain "portarius/internal/resident/domain"
 	"portarius/internal/utils"
@@ -15,116 +13,68 @@ import (
 	"gorm.io/gorm"
 )
 
-type InventoryImportService struct {
-	db *gorm.DB
-}
+// PetRowImporter imports pets, resolving the owner by document. It reads the same spreadsheet as the
+// residents import, so lines without a pet are skipped.
+type PetRowImporter struct{}
 
-func NewInventoryImportService(db *gorm.DB) *InventoryImportService {
-	return &InventoryImportService{db: db}
+func NewPetRowImporter() *PetRowImporter {
+	return &PetRowImporter{}
 }
 
-func (s *InventoryImportService) ImportPetsFromCSV() error {
+func (i *PetRowImporter) Kind() importerDomain.ImportKind {
+	return importerDomain.ImportKindPets
+}
 
-	etlPath := filepath.Join("..", "resources", "ETL", "resident_inventory")
-	absPath, err := filepath.Abs(etlPath)
-	if err != nil {
-		return fmt.Errorf("error getting absolute path: %v", err)
+func (i *PetRowImporter) Columns() []importerDomain.Column {
+	return []importerDomain.Column{
+		{Field: "document", Aliases: []string{"CPF", "Documento"}, Required: true},
+		{Field: "name", Aliases: []string{"Pets", "Pet", "Nome do pet"}, Required: true},
+		{Field: "description", Aliases: []string{"Tipo de pet", "Descrição"}},
 	}
+}
 
-	log.Printf("Looking for CSV files in directory: %s", absPath)
+func (i *PetRowImporter) ImportRow(tx *gorm.DB, row importerDomain.Row) error {
+	name := row.Get("name")
+	if name == "" {
+		return importerDomain.ErrRowSkipped
+	}
 
-	files, err := filepath.Glob(filepath.Join(absPath, "*.csv"))
-	if err != nil {
-		return fmt.Errorf("error finding CSV files: %v", err)
+	document := utils.KeepOnlyNumbers(row.Get("document"))
+	if document == "" {
+		return fmt.Errorf("o documento do dono é obrigatório")
 	}
 
-	if len(files) == 0 {
-		return fmt.Errorf("no CSV files found in directory: %s", absPath)
+	var resident residentDomain.Resident
+	if err := tx.Where("document = ?", document).First(&resident).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("nenhum morador cadastrado com o documento %s", document)
+		}
+		return err
 	}
 
-	log.Printf("Found %d CSV files to process", len(files))
-	for _, file := range files {
-		log.Printf("Found file: %s", file)
+	inventory := domain.Inventory{
+		Name:          name,
+		Description:   row.Get("description"),
+		Quantity:      1,
+		OwnerID:       &resident.ID,
+		LastUpdated:   time.Now(),
+		InventoryType: domain.InventoryTypePet,
 	}
 
-	for _, file := range files {
-		log.Printf("Processing file: %s", file)
-		if err := s.processCSVFile(file); err != nil {
-			return fmt.Errorf("error processing file %s: %v", file, err)
+	var existing domain.Inventory
+	err := tx.Where("owner_id = ? AND name = ? AND inventory_type = ?", resident.ID, inventory.Name, domain.InventoryTypePet).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Create(&inventory).Error; err != nil {
+			return fmt.Errorf("erro ao criar pet: %v", err)
 		}
+		return nil
 	}
-
-	return nil
-}
-
-func (s *InventoryImportService) processCSVFile(filePath string) error {
-	file, err := os.Open(filePath)
 	if err != nil {
-		return fmt.Errorf("error opening file: %v", err)
-	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.Comma = ','
-	reader.LazyQuotes = true
-
-	if _, err := reader.Read(); err != nil {
-		return fmt.Errorf("error reading header: %v", err)
+		return err
 	}
 
-	recordsProcessed := 0
-	for {
-		record, err := reader.Read()
-		if err != nil {
-			if err.Error() == "EOF" {
-				break
-			}
-			return fmt.Errorf("error reading record: %v", err)
-		}
-
-		if len(record) < 9 || record[7] == "" {
-			continue
-		}
-
-		var resident residentDomain.Resident
-		if err := s.db.Where("document = ?", strings.TrimSpace(utils.KeepOnlyNumbers(record[4]))).First(&resident).Error; err != nil {
-			log.Printf("Resident not found with document: %s", utils.KeepOnlyNumbers(record[4]))
-			continue
-		}
-
-		inventory := domain.Inventory{
-			Name:          strings.TrimSpace(record[7]),
-			Description:   strings.TrimSpace(record[8]),
-			Quantity:      1,
-			OwnerID:       &resident.ID,
-			InventoryType: domain.InventoryTypePet,
-		}
-
-		log.Printf("Processing pet: %+v", inventory)
-
-		var existingInventory domain.Inventory
-		result := s.db.Where("owner_id = ? AND name = ? AND inventory_type = ?", resident.ID, inventory.Name, domain.InventoryTypePet).First(&existingInventory)
-		if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
-			return fmt.Errorf("error checking existing pet: %v", result.Error)
-		}
-
-		if result.Error == gorm.ErrRecordNotFound {
-			if err := s.db.Create(&inventory).Error; err != nil {
-				log.Printf("Error creating pet: %v", err)
-				return fmt.Errorf("error creating pet: %v", err)
-			}
-			log.Printf("Created new pet for resident ID: %d", resident.ID)
-		} else {
-
-			if err := s.db.Model(&existingInventory).Updates(inventory).Error; err != nil {
-				log.Printf("Error updating pet: %v", err)
-				return fmt.Errorf("error updating pet: %v", err)
-			}
-			log.Printf("Updated existing pet for resident ID: %d", resident.ID)
-		}
-		recordsProcessed++
+	if err := tx.Model(&existing).Updates(inventory).Error; err != nil {
+		return fmt.Errorf("erro ao atualizar pet: %v", err)
 	}
-
-	log.Printf("Successfully processed %d pet records from file: %s", recordsProcessed, filePath)
 	return nil
 }
@@ -1,5 +0,0 @@
-package inventory
-
-type IInventoryImporter interface {
-	ImportPetsFromCSV() error
-}
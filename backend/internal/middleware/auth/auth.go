@@ -135,3 +135,18 @@ func AdminMiddleware() gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+func GetUserID(c *gin.Context) *uint {
+	value, exists := c.Get("user_id")
+	if !exists {
+		return nil
+	}
+
+	id, ok := value.(float64)
+	if !ok {
+		return nil
+	}
+
+	userID := uint(id)
+	return &userID
+}
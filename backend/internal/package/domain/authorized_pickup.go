@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	residentDomain "portarius/internal/resident/domain"
+	"strings"
+	"time"
+	"unicode"
+
+	"gorm.io/gorm"
+)
+
+type PickupRelationship string
+
+const (
+	PickupRelationshipNeighbor    PickupRelationship = "VIZINHO"
+	PickupRelationshipHousekeeper PickupRelationship = "DIARISTA"
+	PickupRelationshipRelative    PickupRelationship = "PARENTE"
+	PickupRelationshipOther       PickupRelationship = "OUTRO"
+)
+
+var ErrPickupNotAuthorized = errors.New("pessoa não autorizada a retirar encomendas desta unidade")
+
+// AuthorizedPickup is someone a resident allowed to collect the packages of the unit, such as a
+// neighbor, housekeeper or relative, during the validity period
+type AuthorizedPickup struct {
+	gorm.Model     `swaggerignore:"true"`
+	AuthorizedByID *uint                    `json:"authorized_by_id" gorm:"not null"`
+	AuthorizedBy   *residentDomain.Resident `json:"authorized_by" gorm:"foreignKey:AuthorizedByID" swaggerignore:"true"`
+	Block          string                   `json:"block" gorm:"type:varchar(1);not null;index:idx_authorized_pickup_unit"`
+	Apartment      string                   `json:"apartment" gorm:"type:varchar(2);not null;index:idx_authorized_pickup_unit"`
+	Name           string                   `json:"name" gorm:"type:varchar(100);not null"`
+	Document       string                   `json:"document" gorm:"type:varchar(20);not null"`
+	Relationship   PickupRelationship       `json:"relationship" gorm:"type:varchar(10);not null;default:'OUTRO'"`
+	ValidFrom      time.Time                `json:"valid_from" gorm:"not null"`
+	ValidUntil     *time.Time               `json:"valid_until"`
+	Notes          string                   `json:"notes" gorm:"type:text"`
+}
+
+// PickupRecipient is the person who actually collected a package, resident or not
+type PickupRecipient struct {
+	ResidentID         *uint
+	AuthorizedPickupID *uint
+	Name               string
+	Document           string
+}
+
+// Validate normalizes the authorization and checks its fields. The unit is taken from the resident
+// who gave the authorization.
+func (a *AuthorizedPickup) Validate(authorizedBy *residentDomain.Resident, now time.Time) error {
+	if authorizedBy == nil {
+		return fmt.Errorf("o morador que autoriza é obrigatório")
+	}
+	a.AuthorizedByID = &authorizedBy.ID
+	a.Block = authorizedBy.Block
+	a.Apartment = authorizedBy.Apartment
+
+	a.Name = strings.TrimSpace(a.Name)
+	if a.Name == "" {
+		return fmt.Errorf("o nome da pessoa autorizada é obrigatório")
+	}
+
+	a.Document = NormalizePickupDocument(a.Document)
+	if len(a.Document) < 5 || len(a.Document) > 20 {
+		return fmt.Errorf("documento da pessoa autorizada inválido")
+	}
+
+	if a.Relationship == "" {
+		a.Relationship = PickupRelationshipOther
+	}
+	switch a.Relationship {
+	case PickupRelationshipNeighbor, PickupRelationshipHousekeeper, PickupRelationshipRelative, PickupRelationshipOther:
+	default:
+		return fmt.Errorf("relação inválida: %s", a.Relationship)
+	}
+
+	if a.ValidFrom.IsZero() {
+		a.ValidFrom = now
+	}
+	if a.ValidUntil != nil && !a.ValidUntil.After(a.ValidFrom) {
+		return fmt.Errorf("o fim da validade deve ser posterior ao início")
+	}
+
+	return nil
+}
+
+// IsActiveAt tells whether the authorization is within its validity period
+func (a *AuthorizedPickup) IsActiveAt(now time.Time) bool {
+	if now.Before(a.ValidFrom) {
+		return false
+	}
+	return a.ValidUntil == nil || now.Before(*a.ValidUntil)
+}
+
+// Allows tells whether the person may collect a package addressed to the resident at the given time
+func (a *AuthorizedPickup) Allows(resident *residentDomain.Resident, now time.Time) bool {
+	if resident == nil || !a.IsActiveAt(now) {
+		return false
+	}
+	return strings.EqualFold(a.Block, resident.Block) && a.Apartment == resident.Apartment
+}
+
+func (a *AuthorizedPickup) Recipient() PickupRecipient {
+	return PickupRecipient{
+		AuthorizedPickupID: &a.ID,
+		Name:               a.Name,
+		Document:           a.Document,
+	}
+}
+
+func ResidentRecipient(resident *residentDomain.Resident) PickupRecipient {
+	if resident == nil {
+		return PickupRecipient{}
+	}
+	return PickupRecipient{
+		ResidentID: &resident.ID,
+		Name:       resident.Name,
+		Document:   NormalizePickupDocument(resident.Document),
+	}
+}
+
+// NormalizePickupDocument keeps only letters and digits, so "12.345.678-X" and "12345678X" match
+func NormalizePickupDocument(document string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return -1
+	}, document)
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type IAuthorizedPickupRepository interface {
+	GetAll(page, pageSize int) ([]AuthorizedPickup, error)
+	GetByID(id uint) (*AuthorizedPickup, error)
+	FindByUnit(block, apartment string) ([]AuthorizedPickup, error)
+	FindActiveByUnit(block, apartment string, now time.Time) ([]AuthorizedPickup, error)
+	Create(pickup *AuthorizedPickup) error
+	Update(pickup *AuthorizedPickup) error
+	Delete(id uint) error
+}
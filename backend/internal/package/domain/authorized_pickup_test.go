@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	residentDomain "portarius/internal/resident/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestAuthorizedPickup_Validate(t *testing.T) {
+	now := time.Date(2025, time.November, 3, 10, 0, 0, 0, time.UTC)
+	authorizedBy := &residentDomain.Resident{Model: gorm.Model{ID: 3}, Block: "A", Apartment: "30"}
+
+	pickup := domain.AuthorizedPickup{Name: " Maria Diarista ", Document: "12.345.678-x", Relationship: domain.PickupRelationshipHousekeeper}
+	require.NoError(t, pickup.Validate(authorizedBy, now))
+	assert.Equal(t, uint(3), *pickup.AuthorizedByID)
+	assert.Equal(t, "A", pickup.Block)
+	assert.Equal(t, "30", pickup.Apartment)
+	assert.Equal(t, "Maria Diarista", pickup.Name)
+	assert.Equal(t, "12345678X", pickup.Document)
+	assert.Equal(t, now, pickup.ValidFrom)
+
+	missingDocument := domain.AuthorizedPickup{Name: "Maria"}
+	assert.Error(t, missingDocument.Validate(authorizedBy, now))
+
+	before := now.Add(-time.Hour)
+	inverted := domain.AuthorizedPickup{Name: "Maria", Document: "12345678", ValidFrom: now, ValidUntil: &before}
+	assert.Error(t, inverted.Validate(authorizedBy, now))
+
+	unknown := domain.AuthorizedPickup{Name: "Maria", Document: "12345678", Relationship: "CHEFE"}
+	assert.Error(t, unknown.Validate(authorizedBy, now))
+}
+
+func TestAuthorizedPickup_Allows(t *testing.T) {
+	from := time.Date(2025, time.November, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2025, time.November, 30, 0, 0, 0, 0, time.UTC)
+	pickup := domain.AuthorizedPickup{Model: gorm.Model{ID: 8}, Block: "A", Apartment: "30", Name: "Maria", Document: "12345678", ValidFrom: from, ValidUntil: &until}
+
+	sameUnit := &residentDomain.Resident{Block: "a", Apartment: "30"}
+	otherUnit := &residentDomain.Resident{Block: "B", Apartment: "30"}
+
+	assert.True(t, pickup.Allows(sameUnit, from.AddDate(0, 0, 5)))
+	assert.False(t, pickup.Allows(otherUnit, from.AddDate(0, 0, 5)))
+	assert.False(t, pickup.Allows(sameUnit, from.Add(-time.Minute)))
+	assert.False(t, pickup.Allows(sameUnit, until))
+	assert.False(t, pickup.Allows(nil, from.AddDate(0, 0, 5)))
+
+	recipient := pickup.Recipient()
+	assert.Equal(t, uint(8), *recipient.AuthorizedPickupID)
+	assert.Nil(t, recipient.ResidentID)
+	assert.Equal(t, "Maria", recipient.Name)
+}
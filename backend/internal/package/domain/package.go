@@ -13,8 +13,28 @@ const (
 	PackagePending   PackageStatus = "PENDENTE"
 	PackageDelivered PackageStatus = "ENTREGUE"
 	PackageLost      PackageStatus = "EXTRAVIADO"
+
+	PackageUnderInvestigation PackageStatus = "EM_INVESTIGACAO"
+	PackageReturned           PackageStatus = "DEVOLVIDA"
+
+	PackageRefused        PackageStatus = "RECUSADA"
+	PackageAwaitingReturn PackageStatus = "AGUARDANDO_DEVOLUCAO"
 )
 
+// ShelvedPackageStatuses are the statuses of the packages still kept in the mailroom: waiting for the
+// resident, or refused and waiting for the carrier to take them back
+var ShelvedPackageStatuses = []PackageStatus{PackagePending, PackageRefused, PackageAwaitingReturn}
+
+// IsShelved tells whether a package with the status takes room in a storage location
+func (s PackageStatus) IsShelved() bool {
+	for _, status := range ShelvedPackageStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
 // Package represents a package
 // swagger:model
 type Package struct {
@@ -23,9 +43,25 @@ type Package struct {
 	ResidentID    *uint                    `json:"resident_id" gorm:"not null"`
 	Resident      *residentDomain.Resident `json:"resident" gorm:"foreignKey:ResidentID" swaggerignore:"true"`
 	Description   string                   `json:"description"`
+	Carrier       Carrier                  `json:"carrier" gorm:"type:varchar(20)"`
+	TrackingCode  string                   `json:"tracking_code" gorm:"type:varchar(40);index"`
+	Sender        string                   `json:"sender" gorm:"type:varchar(100)"`
 	Status        PackageStatus            `json:"status" gorm:"not null;default:'PENDENTE'"`
 	DeliveredToID *uint                    `json:"delivered_to_id"`
 	DeliveredTo   *residentDomain.Resident `json:"delivered_to" gorm:"foreignKey:DeliveredToID" swaggerignore:"true"`
 	ReceivedAt    time.Time                `json:"received_at"`
 	DeliveredAt   time.Time                `json:"delivered_at"`
+
+	PickupCode           string `json:"-" gorm:"type:varchar(6)"`
+	PickupAttempts       int    `json:"pickup_attempts" gorm:"default:0"`
+	DeliveredByID        *uint  `json:"delivered_by_id"`
+	PickupOverrideReason string `json:"pickup_override_reason" gorm:"type:text"`
+
+	AuthorizedPickupID *uint             `json:"authorized_pickup_id"`
+	AuthorizedPickup   *AuthorizedPickup `json:"authorized_pickup,omitempty" gorm:"foreignKey:AuthorizedPickupID" swaggerignore:"true"`
+	PickedUpByName     string            `json:"picked_up_by_name" gorm:"type:varchar(100)"`
+	PickedUpByDocument string            `json:"picked_up_by_document" gorm:"type:varchar(20)"`
+
+	StorageLocationID *uint            `json:"storage_location_id" gorm:"index"`
+	StorageLocation   *StorageLocation `json:"storage_location,omitempty" gorm:"foreignKey:StorageLocationID" swaggerignore:"true"`
 }
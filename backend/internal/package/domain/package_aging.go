@@ -0,0 +1,131 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// AgingBucketLimits are the first day of each bucket of the aging report; the last bucket is open ended
+var AgingBucketLimits = []int{0, 3, 7, 15, 30}
+
+// AgingPackage is a pending package as listed in the aging report
+type AgingPackage struct {
+	ID              uint      `json:"id"`
+	ResidentID      *uint     `json:"resident_id"`
+	ResidentName    string    `json:"resident_name"`
+	Unit            string    `json:"unit"`
+	Carrier         Carrier   `json:"carrier"`
+	TrackingCode    string    `json:"tracking_code"`
+	StorageLocation string    `json:"storage_location"`
+	Quantity        int       `json:"quantity"`
+	ReceivedAt      time.Time `json:"received_at"`
+	DaysWaiting     int       `json:"days_waiting"`
+}
+
+// AgingBucket represents the pending packages waiting for a range of days
+// swagger:model
+type AgingBucket struct {
+	Label    string         `json:"label"`
+	MinDays  int            `json:"min_days"`
+	MaxDays  *int           `json:"max_days"`
+	Count    int            `json:"count"`
+	Volumes  int            `json:"volumes"`
+	Packages []AgingPackage `json:"packages"`
+}
+
+// AgingReport represents the pending packages bucketed by the days they have been waiting for pickup
+// swagger:model
+type AgingReport struct {
+	GeneratedAt        time.Time     `json:"generated_at"`
+	Total              int           `json:"total"`
+	Volumes            int           `json:"volumes"`
+	AverageDaysWaiting float64       `json:"average_days_waiting"`
+	Buckets            []AgingBucket `json:"buckets"`
+}
+
+// DaysWaiting counts the calendar days since the package was received, in the time zone of now
+func DaysWaiting(receivedAt, now time.Time) int {
+	receivedAt = receivedAt.In(now.Location())
+	from := time.Date(receivedAt.Year(), receivedAt.Month(), receivedAt.Day(), 0, 0, 0, 0, time.UTC)
+	to := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(to.Sub(from).Hours() / 24)
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// BuildAgingReport buckets the pending packages by days waiting, oldest first inside each bucket
+func BuildAgingReport(packages []Package, now time.Time) AgingReport {
+	report := AgingReport{GeneratedAt: now, Buckets: make([]AgingBucket, len(AgingBucketLimits))}
+	for i, first := range AgingBucketLimits {
+		bucket := AgingBucket{MinDays: first, Packages: []AgingPackage{}}
+		if i+1 < len(AgingBucketLimits) {
+			last := AgingBucketLimits[i+1] - 1
+			bucket.MaxDays = &last
+			bucket.Label = fmt.Sprintf("%d a %d dias", first, last)
+		} else {
+			bucket.Label = fmt.Sprintf("%d dias ou mais", first)
+		}
+		report.Buckets[i] = bucket
+	}
+
+	totalDays := 0
+	for _, pkg := range packages {
+		if pkg.Status != PackagePending {
+			continue
+		}
+
+		item := NewAgingPackage(pkg, now)
+		bucket := &report.Buckets[agingBucketIndex(item.DaysWaiting)]
+		bucket.Count++
+		bucket.Volumes += item.Quantity
+		bucket.Packages = append(bucket.Packages, item)
+
+		report.Total++
+		report.Volumes += item.Quantity
+		totalDays += item.DaysWaiting
+	}
+
+	for _, bucket := range report.Buckets {
+		sort.SliceStable(bucket.Packages, func(i, j int) bool {
+			return bucket.Packages[i].ReceivedAt.Before(bucket.Packages[j].ReceivedAt)
+		})
+	}
+
+	if report.Total > 0 {
+		report.AverageDaysWaiting = roundTwoDecimals(float64(totalDays) / float64(report.Total))
+	}
+	return report
+}
+
+func NewAgingPackage(pkg Package, now time.Time) AgingPackage {
+	item := AgingPackage{
+		ID:           pkg.ID,
+		ResidentID:   pkg.ResidentID,
+		Carrier:      pkg.Carrier,
+		TrackingCode: pkg.TrackingCode,
+		Quantity:     max(pkg.Quantity, 1),
+		ReceivedAt:   pkg.ReceivedAt,
+		DaysWaiting:  DaysWaiting(pkg.ReceivedAt, now),
+	}
+	if pkg.Resident != nil {
+		item.ResidentName = pkg.Resident.Name
+		item.Unit = pkg.Resident.Block + pkg.Resident.Apartment
+	}
+	if pkg.StorageLocation != nil {
+		item.StorageLocation = pkg.StorageLocation.Code
+	}
+	return item
+}
+
+func agingBucketIndex(days int) int {
+	for i := len(AgingBucketLimits) - 1; i > 0; i-- {
+		if days >= AgingBucketLimits[i] {
+			return i
+		}
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestDaysWaiting(t *testing.T) {
+	now := time.Date(2024, 6, 10, 8, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, 0, domain.DaysWaiting(time.Date(2024, 6, 10, 7, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, 1, domain.DaysWaiting(time.Date(2024, 6, 9, 23, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, 10, domain.DaysWaiting(time.Date(2024, 5, 31, 12, 0, 0, 0, time.UTC), now))
+	assert.Equal(t, 0, domain.DaysWaiting(now.Add(time.Hour), now))
+}
+
+func TestBuildAgingReport(t *testing.T) {
+	now := time.Date(2024, 6, 30, 18, 0, 0, 0, time.UTC)
+	received := func(id uint, daysAgo, quantity int, status domain.PackageStatus) domain.Package {
+		return domain.Package{Model: gorm.Model{ID: id}, Status: status, Quantity: quantity, ReceivedAt: now.AddDate(0, 0, -daysAgo)}
+	}
+
+	report := domain.BuildAgingReport([]domain.Package{
+		received(1, 1, 1, domain.PackagePending),
+		received(2, 5, 2, domain.PackagePending),
+		received(3, 6, 1, domain.PackagePending),
+		received(4, 20, 1, domain.PackagePending),
+		received(5, 45, 0, domain.PackagePending),
+		received(6, 40, 1, domain.PackageDelivered),
+	}, now)
+
+	assert.Equal(t, 5, report.Total)
+	assert.Equal(t, 6, report.Volumes)
+	assert.Equal(t, 15.4, report.AverageDaysWaiting)
+
+	require.Len(t, report.Buckets, 5)
+	assert.Equal(t, "0 a 2 dias", report.Buckets[0].Label)
+	assert.Equal(t, "30 dias ou mais", report.Buckets[4].Label)
+	assert.Nil(t, report.Buckets[4].MaxDays)
+
+	counts := []int{}
+	for _, bucket := range report.Buckets {
+		counts = append(counts, bucket.Count)
+	}
+	assert.Equal(t, []int{1, 2, 0, 1, 1}, counts)
+
+	assert.Equal(t, 3, report.Buckets[1].Volumes)
+	assert.Equal(t, uint(3), report.Buckets[1].Packages[0].ID)
+	assert.Equal(t, 6, report.Buckets[1].Packages[0].DaysWaiting)
+	assert.Empty(t, report.Buckets[2].Packages)
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type AttachmentKind string
+
+const (
+	AttachmentIntakePhoto   AttachmentKind = "FOTO_RECEBIMENTO"
+	AttachmentHandoverPhoto AttachmentKind = "FOTO_ENTREGA"
+	AttachmentSignature     AttachmentKind = "ASSINATURA"
+	AttachmentReturnReceipt AttachmentKind = "RECIBO_DEVOLUCAO"
+)
+
+// MaxAttachmentSize is the largest photo or signature accepted, in bytes
+const MaxAttachmentSize = 10 << 20
+
+var (
+	ErrAttachmentTooLarge = errors.New("arquivo maior que 10 MB")
+	ErrAttachmentType     = errors.New("envie uma imagem JPEG ou PNG")
+)
+
+var attachmentExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+}
+
+// PackageAttachment is a photo taken at intake, a photo or signature taken at handover, or the receipt
+// of a return to the sender, kept as evidence for lost-package disputes. The files live in the configured storage; the download URLs
+// are signed when the attachment is returned by the API.
+type PackageAttachment struct {
+	gorm.Model   `swaggerignore:"true"`
+	PackageID    uint           `json:"package_id" gorm:"not null;index"`
+	Kind         AttachmentKind `json:"kind" gorm:"type:varchar(20);not null"`
+	FileName     string         `json:"file_name" gorm:"not null"`
+	ContentType  string         `json:"content_type" gorm:"type:varchar(50)"`
+	Size         int64          `json:"size"`
+	StorageKey   string         `json:"-" gorm:"not null"`
+	ThumbnailKey string         `json:"-"`
+	UploadedByID *uint          `json:"uploaded_by_id"`
+
+	DownloadURL  string `json:"download_url,omitempty" gorm:"-"`
+	ThumbnailURL string `json:"thumbnail_url,omitempty" gorm:"-"`
+}
+
+func ParseAttachmentKind(value string) (AttachmentKind, error) {
+	kind := AttachmentKind(strings.ToUpper(strings.TrimSpace(value)))
+	switch kind {
+	case AttachmentIntakePhoto, AttachmentHandoverPhoto, AttachmentSignature, AttachmentReturnReceipt:
+		return kind, nil
+	default:
+		return "", fmt.Errorf("tipo de anexo inválido: %s", value)
+	}
+}
+
+// AttachmentExtension returns the file extension of an accepted attachment type
+func AttachmentExtension(contentType string) string {
+	return attachmentExtensions[contentType]
+}
+
+// DetectAttachmentType checks the size and sniffs the content, instead of trusting the type sent by
+// the client, returning the content type of the image
+func DetectAttachmentType(content []byte) (string, error) {
+	if len(content) == 0 {
+		return "", fmt.Errorf("arquivo vazio")
+	}
+	if len(content) > MaxAttachmentSize {
+		return "", ErrAttachmentTooLarge
+	}
+
+	contentType := http.DetectContentType(content)
+	if _, ok := attachmentExtensions[contentType]; !ok {
+		return "", ErrAttachmentType
+	}
+	return contentType, nil
+}
+
+// NewPackageAttachment builds the attachment of an uploaded file, with the keys the file and its
+// thumbnail are stored under
+func NewPackageAttachment(packageID uint, kind AttachmentKind, fileName, contentType string, size int64, uploadedByID *uint, now time.Time) *PackageAttachment {
+	name := fmt.Sprintf("packages/%d/%s_%d", packageID, strings.ToLower(string(kind)), now.UnixNano())
+
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if fileName == "." || fileName == "/" {
+		fileName = ""
+	}
+	if fileName == "" {
+		fileName = strings.ToLower(string(kind)) + attachmentExtensions[contentType]
+	}
+
+	return &PackageAttachment{
+		PackageID:    packageID,
+		Kind:         kind,
+		FileName:     fileName,
+		ContentType:  contentType,
+		Size:         size,
+		StorageKey:   name + attachmentExtensions[contentType],
+		ThumbnailKey: name + "_thumb.jpg",
+		UploadedByID: uploadedByID,
+	}
+}
@@ -0,0 +1,8 @@
+package domain
+
+type IPackageAttachmentRepository interface {
+	GetByPackage(packageID uint) ([]PackageAttachment, error)
+	GetByID(id uint) (*PackageAttachment, error)
+	Create(attachment *PackageAttachment) error
+	Delete(id uint) error
+}
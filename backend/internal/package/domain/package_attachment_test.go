@@ -0,0 +1,53 @@
+package domain_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"portarius/internal/package/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAttachmentKind(t *testing.T) {
+	kind, err := domain.ParseAttachmentKind(" assinatura ")
+	require.NoError(t, err)
+	assert.Equal(t, domain.AttachmentSignature, kind)
+
+	_, err = domain.ParseAttachmentKind("VIDEO")
+	assert.Error(t, err)
+}
+
+func TestDetectAttachmentType(t *testing.T) {
+	var encoded bytes.Buffer
+	require.NoError(t, png.Encode(&encoded, image.NewGray(image.Rect(0, 0, 2, 2))))
+
+	contentType, err := domain.DetectAttachmentType(encoded.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", contentType)
+
+	_, err = domain.DetectAttachmentType([]byte("%PDF-1.4 recibo"))
+	assert.ErrorIs(t, err, domain.ErrAttachmentType)
+
+	_, err = domain.DetectAttachmentType(make([]byte, domain.MaxAttachmentSize+1))
+	assert.ErrorIs(t, err, domain.ErrAttachmentTooLarge)
+
+	_, err = domain.DetectAttachmentType(nil)
+	assert.Error(t, err)
+}
+
+func TestNewPackageAttachment(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+
+	attachment := domain.NewPackageAttachment(12, domain.AttachmentIntakePhoto, `C:\fotos\caixa.jpeg`, "image/jpeg", 2048, nil, now)
+	assert.Equal(t, "caixa.jpeg", attachment.FileName)
+	assert.Equal(t, "packages/12/foto_recebimento_1700000000000000000.jpg", attachment.StorageKey)
+	assert.Equal(t, "packages/12/foto_recebimento_1700000000000000000_thumb.jpg", attachment.ThumbnailKey)
+
+	signature := domain.NewPackageAttachment(12, domain.AttachmentSignature, "", "image/png", 512, nil, now)
+	assert.Equal(t, "assinatura.png", signature.FileName)
+	assert.Equal(t, "packages/12/assinatura_1700000000000000000.png", signature.StorageKey)
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaxBatchSize is the largest number of packages accepted in a single intake
+const MaxBatchSize = 200
+
+var (
+	ErrBatchTooLarge = fmt.Errorf("o lote aceita no máximo %d encomendas", MaxBatchSize)
+	ErrInvalidBatch  = errors.New("lote com encomendas inválidas")
+)
+
+// BatchIntakeRequest represents a delivery of many packages registered at once, such as the daily
+// drop of a carrier van
+// swagger:model
+type BatchIntakeRequest struct {
+	Packages []Package `json:"packages" binding:"required,min=1"`
+}
+
+// BatchItemError points to the package of the batch that was rejected
+type BatchItemError struct {
+	Index int    `json:"index"`
+	Error string `json:"error"`
+}
+
+// BatchIntakeResult represents the packages created by a batch intake and how many notifications
+// were sent for them, one per resident
+// swagger:model
+type BatchIntakeResult struct {
+	Packages      []Package `json:"packages"`
+	Notifications int       `json:"notifications"`
+}
+
+// ResidentPackages are the packages of a batch that belong to the same resident
+type ResidentPackages struct {
+	ResidentID uint
+	Indexes    []int
+}
+
+// ValidateBatch normalizes the tracking codes of the packages and reports every package that cannot
+// be registered, so the whole batch can be fixed at once. Tracking codes repeated inside the batch
+// are rejected, as the same box was scanned twice.
+func ValidateBatch(packages []Package) []BatchItemError {
+	errs := []BatchItemError{}
+	seen := map[string]int{}
+	for i := range packages {
+		pkg := &packages[i]
+
+		if pkg.ResidentID == nil || *pkg.ResidentID == 0 {
+			errs = append(errs, BatchItemError{Index: i, Error: "morador não informado"})
+			continue
+		}
+
+		if err := pkg.NormalizeTracking(); err != nil {
+			errs = append(errs, BatchItemError{Index: i, Error: err.Error()})
+			continue
+		}
+
+		if pkg.TrackingCode == "" {
+			continue
+		}
+		if first, ok := seen[pkg.TrackingCode]; ok {
+			errs = append(errs, BatchItemError{Index: i, Error: fmt.Sprintf("código %s repetido no lote (item %d)", pkg.TrackingCode, first)})
+			continue
+		}
+		seen[pkg.TrackingCode] = i
+	}
+
+	return errs
+}
+
+// GroupByResident groups the packages of the batch per resident, in the order each resident first
+// appears
+func GroupByResident(packages []Package) []ResidentPackages {
+	groups := []ResidentPackages{}
+	positions := map[uint]int{}
+	for i, pkg := range packages {
+		if pkg.ResidentID == nil {
+			continue
+		}
+
+		position, ok := positions[*pkg.ResidentID]
+		if !ok {
+			position = len(groups)
+			positions[*pkg.ResidentID] = position
+			groups = append(groups, ResidentPackages{ResidentID: *pkg.ResidentID})
+		}
+		groups[position].Indexes = append(groups[position].Indexes, i)
+	}
+	return groups
+}
+
+// PrepareBatch readies the validated packages to be saved: they are pending and received now, and
+// the packages of each resident share one pickup code, sent in the single notification of the batch
+func PrepareBatch(packages []Package, groups []ResidentPackages, now time.Time) error {
+	for _, group := range groups {
+		code, err := GeneratePickupCode()
+		if err != nil {
+			return err
+		}
+
+		for _, index := range group.Indexes {
+			pkg := &packages[index]
+			pkg.StartPending()
+			pkg.ReceivedAt = now
+			pkg.PickupCode = code
+			if pkg.Quantity <= 0 {
+				pkg.Quantity = 1
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateBatch(t *testing.T) {
+	packages := []domain.Package{
+		{ResidentID: uintPtr(1), TrackingCode: "12345678901"},
+		{ResidentID: uintPtr(2), TrackingCode: "123.456.789-01"},
+		{TrackingCode: "TBA123456789012"},
+		{ResidentID: uintPtr(3), Carrier: domain.CarrierAmazon, TrackingCode: "XYZ"},
+		{ResidentID: uintPtr(3)},
+	}
+
+	errs := domain.ValidateBatch(packages)
+	require.Len(t, errs, 3)
+	assert.Equal(t, 1, errs[0].Index)
+	assert.Contains(t, errs[0].Error, "(item 0)")
+	assert.Equal(t, 2, errs[1].Index)
+	assert.Equal(t, 3, errs[2].Index)
+
+	assert.Equal(t, "12345678901", packages[1].TrackingCode)
+	assert.Equal(t, domain.CarrierMercadoLivre, packages[0].Carrier)
+}
+
+func TestGroupByResident(t *testing.T) {
+	packages := []domain.Package{
+		{ResidentID: uintPtr(7)},
+		{ResidentID: uintPtr(3)},
+		{ResidentID: uintPtr(7)},
+		{ResidentID: uintPtr(7)},
+	}
+
+	groups := domain.GroupByResident(packages)
+	require.Len(t, groups, 2)
+	assert.Equal(t, domain.ResidentPackages{ResidentID: 7, Indexes: []int{0, 2, 3}}, groups[0])
+	assert.Equal(t, domain.ResidentPackages{ResidentID: 3, Indexes: []int{1}}, groups[1])
+}
+
+func TestPrepareBatch(t *testing.T) {
+	now := time.Date(2024, 5, 10, 17, 0, 0, 0, time.UTC)
+	packages := []domain.Package{
+		{ResidentID: uintPtr(7)},
+		{ResidentID: uintPtr(3), Quantity: 2},
+		{ResidentID: uintPtr(7)},
+	}
+
+	require.NoError(t, domain.PrepareBatch(packages, domain.GroupByResident(packages), now))
+
+	assert.Len(t, packages[0].PickupCode, domain.PickupCodeLength)
+	assert.Equal(t, packages[0].PickupCode, packages[2].PickupCode)
+	assert.NotEmpty(t, packages[1].PickupCode)
+	for _, pkg := range packages {
+		assert.Equal(t, domain.PackagePending, pkg.Status)
+		assert.Equal(t, now, pkg.ReceivedAt)
+	}
+	assert.Equal(t, 1, packages[0].Quantity)
+	assert.Equal(t, 2, packages[1].Quantity)
+}
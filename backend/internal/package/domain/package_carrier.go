@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+type Carrier string
+
+const (
+	CarrierCorreios     Carrier = "CORREIOS"
+	CarrierJadlog       Carrier = "JADLOG"
+	CarrierMercadoLivre Carrier = "MERCADO_LIVRE"
+	CarrierAmazon       Carrier = "AMAZON"
+	CarrierOther        Carrier = "OUTRA"
+)
+
+var (
+	// Correios follows the UPU S10 standard: two letters, eight digits, a check digit and the country
+	correiosCodePattern     = regexp.MustCompile(`^[A-Z]{2}[0-9]{9}[A-Z]{2}$`)
+	jadlogCodePattern       = regexp.MustCompile(`^[0-9]{14}$`)
+	mercadoLivreCodePattern = regexp.MustCompile(`^[0-9]{11}$`)
+	amazonCodePattern       = regexp.MustCompile(`^TBA[0-9]{12}$`)
+	otherCodePattern        = regexp.MustCompile(`^[A-Z0-9]{4,40}$`)
+)
+
+var correiosCheckWeights = []int{8, 6, 4, 2, 3, 5, 9, 7}
+
+// PackageLookup is the answer to a scanned barcode: the package already registered with the code,
+// or a new package prefilled with what the code tells about it
+type PackageLookup struct {
+	Found   bool     `json:"found"`
+	Package *Package `json:"package"`
+}
+
+func ParseCarrier(value string) (Carrier, error) {
+	carrier := Carrier(strings.ToUpper(strings.TrimSpace(value)))
+	switch carrier {
+	case CarrierCorreios, CarrierJadlog, CarrierMercadoLivre, CarrierAmazon, CarrierOther:
+		return carrier, nil
+	default:
+		return "", fmt.Errorf("transportadora inválida: %s", value)
+	}
+}
+
+// NormalizeTrackingCode reads the code out of a scanned barcode. Mercado Livre labels carry a QR code
+// with a JSON payload whose id is the shipment; other labels carry the code itself, which is kept
+// without spaces, dashes and dots.
+func NormalizeTrackingCode(barcode string) string {
+	barcode = strings.TrimSpace(barcode)
+
+	if strings.HasPrefix(barcode, "{") {
+		var payload struct {
+			ID json.RawMessage `json:"id"`
+		}
+		if err := json.Unmarshal([]byte(barcode), &payload); err == nil && len(payload.ID) > 0 {
+			barcode = strings.Trim(string(payload.ID), `"`)
+		}
+	}
+
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '/':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(barcode))
+}
+
+// DetectCarrier finds the carrier of a normalized tracking code by its format
+func DetectCarrier(code string) (Carrier, bool) {
+	switch {
+	case correiosCodePattern.MatchString(code):
+		return CarrierCorreios, true
+	case amazonCodePattern.MatchString(code):
+		return CarrierAmazon, true
+	case jadlogCodePattern.MatchString(code):
+		return CarrierJadlog, true
+	case mercadoLivreCodePattern.MatchString(code):
+		return CarrierMercadoLivre, true
+	default:
+		return "", false
+	}
+}
+
+// ValidateTrackingCode checks a normalized code against the format of the carrier
+func ValidateTrackingCode(carrier Carrier, code string) error {
+	switch carrier {
+	case CarrierCorreios:
+		if !correiosCodePattern.MatchString(code) {
+			return fmt.Errorf("código de rastreio dos Correios inválido, use o formato AA123456789BR: %s", code)
+		}
+		if !validCorreiosCheckDigit(code) {
+			return fmt.Errorf("dígito verificador do código dos Correios inválido: %s", code)
+		}
+	case CarrierJadlog:
+		if !jadlogCodePattern.MatchString(code) {
+			return fmt.Errorf("código de rastreio da Jadlog inválido, use os 14 dígitos do envio: %s", code)
+		}
+	case CarrierMercadoLivre:
+		if !mercadoLivreCodePattern.MatchString(code) {
+			return fmt.Errorf("código de rastreio do Mercado Livre inválido, use os 11 dígitos do envio: %s", code)
+		}
+	case CarrierAmazon:
+		if !amazonCodePattern.MatchString(code) {
+			return fmt.Errorf("código de rastreio da Amazon inválido, use o formato TBA123456789012: %s", code)
+		}
+	case CarrierOther:
+		if !otherCodePattern.MatchString(code) {
+			return fmt.Errorf("código de rastreio inválido: %s", code)
+		}
+	default:
+		return fmt.Errorf("transportadora inválida: %s", carrier)
+	}
+	return nil
+}
+
+func validCorreiosCheckDigit(code string) bool {
+	sum := 0
+	for i, weight := range correiosCheckWeights {
+		sum += int(code[2+i]-'0') * weight
+	}
+
+	digit := 11 - sum%11
+	switch digit {
+	case 10:
+		digit = 0
+	case 11:
+		digit = 5
+	}
+
+	return int(code[10]-'0') == digit
+}
+
+// NormalizeTracking validates the carrier and tracking code of the package, detecting the carrier
+// from the code when it was not informed. Packages without a tracking code are left as they are.
+func (p *Package) NormalizeTracking() error {
+	p.Sender = strings.TrimSpace(p.Sender)
+
+	if strings.TrimSpace(p.TrackingCode) == "" {
+		p.TrackingCode = ""
+		if p.Carrier != "" {
+			carrier, err := ParseCarrier(string(p.Carrier))
+			if err != nil {
+				return err
+			}
+			p.Carrier = carrier
+		}
+		return nil
+	}
+
+	p.TrackingCode = NormalizeTrackingCode(p.TrackingCode)
+
+	if p.Carrier == "" {
+		carrier, ok := DetectCarrier(p.TrackingCode)
+		if !ok {
+			carrier = CarrierOther
+		}
+		p.Carrier = carrier
+	}
+
+	carrier, err := ParseCarrier(string(p.Carrier))
+	if err != nil {
+		return err
+	}
+	p.Carrier = carrier
+
+	return ValidateTrackingCode(p.Carrier, p.TrackingCode)
+}
+
+// PrefillPackage builds a new package from a scanned barcode for the porter to complete
+func PrefillPackage(barcode string) (*Package, error) {
+	code := NormalizeTrackingCode(barcode)
+	if code == "" {
+		return nil, fmt.Errorf("código de barras vazio")
+	}
+
+	pkg := &Package{Quantity: 1, Status: PackagePending, TrackingCode: code}
+	if carrier, ok := DetectCarrier(code); ok && ValidateTrackingCode(carrier, code) == nil {
+		pkg.Carrier = carrier
+	} else {
+		pkg.Carrier = CarrierOther
+	}
+
+	if err := ValidateTrackingCode(pkg.Carrier, code); err != nil {
+		return nil, err
+	}
+
+	if pkg.Carrier == CarrierAmazon || pkg.Carrier == CarrierMercadoLivre {
+		pkg.Sender = pkg.Carrier.DisplayName()
+	}
+
+	return pkg, nil
+}
+
+func (c Carrier) DisplayName() string {
+	switch c {
+	case CarrierCorreios:
+		return "Correios"
+	case CarrierJadlog:
+		return "Jadlog"
+	case CarrierMercadoLivre:
+		return "Mercado Livre"
+	case CarrierAmazon:
+		return "Amazon"
+	default:
+		return "Outra"
+	}
+}
@@ -0,0 +1,80 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeTrackingCode(t *testing.T) {
+	assert.Equal(t, "SS987654326BR", domain.NormalizeTrackingCode(" ss 987.654.326-br "))
+	assert.Equal(t, "41234567890", domain.NormalizeTrackingCode(`{"id":"41234567890","t":"lm"}`))
+	assert.Equal(t, "41234567890", domain.NormalizeTrackingCode(`{"id":41234567890}`))
+}
+
+func TestDetectCarrier(t *testing.T) {
+	tests := []struct {
+		code    string
+		carrier domain.Carrier
+		ok      bool
+	}{
+		{code: "SS987654326BR", carrier: domain.CarrierCorreios, ok: true},
+		{code: "12345678901234", carrier: domain.CarrierJadlog, ok: true},
+		{code: "41234567890", carrier: domain.CarrierMercadoLivre, ok: true},
+		{code: "TBA123456789012", carrier: domain.CarrierAmazon, ok: true},
+		{code: "ABC123", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			carrier, ok := domain.DetectCarrier(tt.code)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.carrier, carrier)
+		})
+	}
+}
+
+func TestValidateTrackingCode(t *testing.T) {
+	assert.NoError(t, domain.ValidateTrackingCode(domain.CarrierCorreios, "SS987654326BR"))
+	assert.EqualError(t, domain.ValidateTrackingCode(domain.CarrierCorreios, "SS987654321BR"),
+		"dígito verificador do código dos Correios inválido: SS987654321BR")
+	assert.Error(t, domain.ValidateTrackingCode(domain.CarrierCorreios, "12345678901234"))
+	assert.Error(t, domain.ValidateTrackingCode(domain.CarrierJadlog, "1234"))
+	assert.Error(t, domain.ValidateTrackingCode(domain.CarrierAmazon, "TBA12345"))
+	assert.NoError(t, domain.ValidateTrackingCode(domain.CarrierOther, "LOGGI12345"))
+}
+
+func TestPackage_NormalizeTracking(t *testing.T) {
+	pkg := domain.Package{TrackingCode: "tba 123456789012", Sender: " Amazon "}
+	require.NoError(t, pkg.NormalizeTracking())
+	assert.Equal(t, domain.CarrierAmazon, pkg.Carrier)
+	assert.Equal(t, "TBA123456789012", pkg.TrackingCode)
+	assert.Equal(t, "Amazon", pkg.Sender)
+
+	pkg = domain.Package{Carrier: "jadlog", TrackingCode: "SS987654326BR"}
+	assert.Error(t, pkg.NormalizeTracking())
+
+	pkg = domain.Package{Description: "caixa"}
+	require.NoError(t, pkg.NormalizeTracking())
+	assert.Empty(t, pkg.Carrier)
+}
+
+func TestPrefillPackage(t *testing.T) {
+	pkg, err := domain.PrefillPackage(`{"id":"41234567890","t":"lm"}`)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CarrierMercadoLivre, pkg.Carrier)
+	assert.Equal(t, "41234567890", pkg.TrackingCode)
+	assert.Equal(t, "Mercado Livre", pkg.Sender)
+	assert.Equal(t, domain.PackagePending, pkg.Status)
+	assert.Equal(t, 1, pkg.Quantity)
+
+	pkg, err = domain.PrefillPackage("LOGGI12345")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CarrierOther, pkg.Carrier)
+	assert.Empty(t, pkg.Sender)
+
+	_, err = domain.PrefillPackage("  ")
+	assert.Error(t, err)
+}
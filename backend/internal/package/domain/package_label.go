@@ -0,0 +1,136 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type LabelFormat string
+
+const (
+	LabelFormatPDF LabelFormat = "PDF"
+	LabelFormatZPL LabelFormat = "ZPL"
+)
+
+// MaxLabelsPerRequest is the largest number of labels printed at once, about a full van delivery
+const MaxLabelsPerRequest = 200
+
+// labelCodePrefix marks the barcodes printed by the mailroom, so a scan at handover is told apart
+// from a carrier tracking code
+const labelCodePrefix = "PKG"
+
+// ids are padded to 8 digits and may grow up to the 10 digits of a 32-bit id
+var labelCodePattern = regexp.MustCompile(`^PKG([0-9]{8,10})$`)
+
+// PackageLabel is what is printed on the label stuck to a package at intake
+type PackageLabel struct {
+	PackageID    uint
+	Code         string
+	Unit         string
+	ResidentName string
+	Location     string
+	Carrier      string
+	ReceivedAt   string
+	Quantity     int
+}
+
+func ParseLabelFormat(value string) (LabelFormat, error) {
+	format := LabelFormat(strings.ToUpper(strings.TrimSpace(value)))
+	switch format {
+	case "":
+		return LabelFormatPDF, nil
+	case LabelFormatPDF, LabelFormatZPL:
+		return format, nil
+	default:
+		return "", fmt.Errorf("formato de etiqueta inválido: %s", value)
+	}
+}
+
+func (f LabelFormat) ContentType() string {
+	if f == LabelFormatZPL {
+		return "text/plain; charset=utf-8"
+	}
+	return "application/pdf"
+}
+
+func (f LabelFormat) Extension() string {
+	return strings.ToLower(string(f))
+}
+
+// LabelCode is the content of the barcode and QR code of the label, e.g. PKG00000123
+func LabelCode(packageID uint) string {
+	return fmt.Sprintf("%s%08d", labelCodePrefix, packageID)
+}
+
+// ParseLabelCode reads the package id out of a scanned label barcode
+func ParseLabelCode(barcode string) (uint, bool) {
+	match := labelCodePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(barcode)))
+	if match == nil {
+		return 0, false
+	}
+
+	id, err := strconv.ParseUint(match[1], 10, 32)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func NewPackageLabel(pkg *Package) PackageLabel {
+	label := PackageLabel{
+		PackageID:  pkg.ID,
+		Code:       LabelCode(pkg.ID),
+		ReceivedAt: pkg.ReceivedAt.Format("02/01/2006 15:04"),
+		Quantity:   max(pkg.Quantity, 1),
+	}
+	if pkg.Carrier != "" {
+		label.Carrier = pkg.Carrier.DisplayName()
+	}
+	if pkg.Resident != nil {
+		label.Unit = fmt.Sprintf("Bloco %s - Apto %s", pkg.Resident.Block, pkg.Resident.Apartment)
+		label.ResidentName = pkg.Resident.Name
+	}
+	if pkg.StorageLocation != nil {
+		label.Location = pkg.StorageLocation.Code
+	}
+	return label
+}
+
+// RenderZPL writes the labels for Zebra thermal printers, 100 x 50 mm at 203 dpi. The printer draws
+// the Code 128 and the QR code itself.
+func RenderZPL(labels []PackageLabel) string {
+	var builder strings.Builder
+	for _, label := range labels {
+		builder.WriteString("^XA\n^CI28\n^PW799\n^LL400\n^LH0,0\n")
+		fmt.Fprintf(&builder, "^FO24,20^A0N,48,48^FB560,1,0,L^FD%s^FS\n", zplText(label.Unit))
+		fmt.Fprintf(&builder, "^FO24,76^A0N,32,32^FB560,1,0,L^FD%s^FS\n", zplText(label.ResidentName))
+		fmt.Fprintf(&builder, "^FO24,120^A0N,26,26^FB560,1,0,L^FD%s^FS\n", zplText(labelDetails(label)))
+		if label.Location != "" {
+			fmt.Fprintf(&builder, "^FO600,20^GB176,96,4^FS\n^FO600,44^A0N,56,56^FB176,1,0,C^FD%s^FS\n", zplText(label.Location))
+		}
+		fmt.Fprintf(&builder, "^FO24,170^BY3^BCN,140,Y,N,N^FD%s^FS\n", label.Code)
+		fmt.Fprintf(&builder, "^FO600,150^BQN,2,7^FDMA,%s^FS\n", label.Code)
+		builder.WriteString("^XZ\n")
+	}
+	return builder.String()
+}
+
+// labelDetails is the small print line: package id, volumes, carrier and when it arrived
+func labelDetails(label PackageLabel) string {
+	details := []string{fmt.Sprintf("#%d", label.PackageID)}
+	if label.Quantity > 1 {
+		details = append(details, fmt.Sprintf("%d volumes", label.Quantity))
+	}
+	if label.Carrier != "" {
+		details = append(details, label.Carrier)
+	}
+	details = append(details, label.ReceivedAt)
+	return strings.Join(details, " - ")
+}
+
+// zplText keeps free text from being read as ZPL commands
+func zplText(value string) string {
+	return strings.NewReplacer("^", " ", "~", " ", "\n", " ", "\r", " ").Replace(value)
+}
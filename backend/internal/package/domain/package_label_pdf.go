@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"bytes"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/qr"
+	"github.com/go-pdf/fpdf"
+)
+
+// Labels are laid out on A4 sheets, two columns of five 100 x 55 mm labels, with cut marks
+const (
+	labelWidthMM     = 100.0
+	labelHeightMM    = 55.0
+	labelColumns     = 2
+	labelRows        = 5
+	labelMarginX     = 5.0
+	labelMarginY     = 11.0
+	labelPadding     = 4.0
+	labelBarcodeW    = 58.0
+	labelBarcodeH    = 14.0
+	labelQRCodeSize  = 24.0
+	labelsPerPDFPage = labelColumns * labelRows
+)
+
+// RenderLabelsPDF draws the labels for regular printers. The Code 128 and the QR code are drawn as
+// vector shapes, so they stay sharp enough to be scanned at handover on any printer.
+func RenderLabelsPDF(labels []PackageLabel) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(0, 0, 0)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	for i, label := range labels {
+		position := i % labelsPerPDFPage
+		if position == 0 {
+			pdf.AddPage()
+		}
+
+		x := labelMarginX + float64(position%labelColumns)*labelWidthMM
+		y := labelMarginY + float64(position/labelColumns)*labelHeightMM
+
+		pdf.SetDrawColor(180, 180, 180)
+		pdf.SetDashPattern([]float64{1, 1}, 0)
+		pdf.Rect(x, y, labelWidthMM, labelHeightMM, "D")
+		pdf.SetDashPattern([]float64{}, 0)
+		pdf.SetDrawColor(0, 0, 0)
+
+		left := x + labelPadding
+		textWidth := labelWidthMM - 2*labelPadding - labelQRCodeSize - 2
+
+		pdf.SetXY(left, y+labelPadding)
+		pdf.SetFont("Helvetica", "B", 15)
+		pdf.CellFormat(textWidth, 7, tr(label.Unit), "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(textWidth, 5, tr(label.ResidentName), "", 2, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 7)
+		pdf.CellFormat(textWidth, 4, tr(labelDetails(label)), "", 2, "L", false, 0, "")
+
+		qrX := x + labelWidthMM - labelPadding - labelQRCodeSize
+		if label.Location != "" {
+			pdf.SetXY(qrX, y+labelPadding)
+			pdf.SetFont("Helvetica", "B", 14)
+			pdf.CellFormat(labelQRCodeSize, 8, tr(label.Location), "1", 0, "C", false, 0, "")
+		}
+
+		if err := drawQRCode(pdf, label.Code, qrX, y+labelPadding+10, labelQRCodeSize); err != nil {
+			return nil, err
+		}
+
+		barcodeY := y + labelHeightMM - labelPadding - labelBarcodeH - 4
+		if err := drawCode128(pdf, label.Code, left, barcodeY); err != nil {
+			return nil, err
+		}
+		pdf.SetXY(left, barcodeY+labelBarcodeH)
+		pdf.SetFont("Courier", "", 9)
+		pdf.CellFormat(labelBarcodeW, 4, label.Code, "", 0, "C", false, 0, "")
+	}
+
+	if err := pdf.Error(); err != nil {
+		return nil, err
+	}
+
+	var buffer bytes.Buffer
+	if err := pdf.Output(&buffer); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func drawCode128(pdf *fpdf.Fpdf, content string, x, y float64) error {
+	code, err := code128.Encode(content)
+	if err != nil {
+		return err
+	}
+
+	bounds := code.Bounds()
+	moduleWidth := labelBarcodeW / float64(bounds.Dx())
+
+	pdf.SetFillColor(0, 0, 0)
+	for i := bounds.Min.X; i < bounds.Max.X; i++ {
+		if isDark(code, i, bounds.Min.Y) {
+			pdf.Rect(x+float64(i-bounds.Min.X)*moduleWidth, y, moduleWidth, labelBarcodeH, "F")
+		}
+	}
+	return nil
+}
+
+func drawQRCode(pdf *fpdf.Fpdf, content string, x, y, size float64) error {
+	code, err := qr.Encode(content, qr.M, qr.Auto)
+	if err != nil {
+		return err
+	}
+
+	bounds := code.Bounds()
+	moduleSize := size / float64(bounds.Dx())
+
+	pdf.SetFillColor(0, 0, 0)
+	for row := bounds.Min.Y; row < bounds.Max.Y; row++ {
+		for column := bounds.Min.X; column < bounds.Max.X; column++ {
+			if isDark(code, column, row) {
+				pdf.Rect(x+float64(column-bounds.Min.X)*moduleSize, y+float64(row-bounds.Min.Y)*moduleSize, moduleSize, moduleSize, "F")
+			}
+		}
+	}
+	return nil
+}
+
+func isDark(code barcode.Barcode, x, y int) bool {
+	r, _, _, _ := code.At(x, y).RGBA()
+	return r == 0
+}
@@ -0,0 +1,97 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	residentDomain "portarius/internal/resident/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestParseLabelFormat(t *testing.T) {
+	format, err := domain.ParseLabelFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, domain.LabelFormatPDF, format)
+
+	format, err = domain.ParseLabelFormat(" zpl ")
+	require.NoError(t, err)
+	assert.Equal(t, domain.LabelFormatZPL, format)
+	assert.Equal(t, "zpl", format.Extension())
+	assert.Equal(t, "application/pdf", domain.LabelFormatPDF.ContentType())
+
+	_, err = domain.ParseLabelFormat("png")
+	assert.Error(t, err)
+}
+
+func TestLabelCode(t *testing.T) {
+	code := domain.LabelCode(123)
+	assert.Equal(t, "PKG00000123", code)
+
+	id, ok := domain.ParseLabelCode(" pkg00000123 ")
+	assert.True(t, ok)
+	assert.Equal(t, uint(123), id)
+
+	for _, large := range []uint{123456789, 4294967295} {
+		id, ok := domain.ParseLabelCode(domain.LabelCode(large))
+		assert.True(t, ok, large)
+		assert.Equal(t, large, id)
+	}
+
+	for _, barcode := range []string{"PKG00000000", "PKG123", "PKG9999999999", "PKG12345678901", "AA123456789BR", ""} {
+		_, ok := domain.ParseLabelCode(barcode)
+		assert.False(t, ok, barcode)
+	}
+}
+
+func newLabelPackage() *domain.Package {
+	return &domain.Package{
+		Model:           gorm.Model{ID: 42},
+		Resident:        &residentDomain.Resident{Name: "Maria ^Souza", Block: "B", Apartment: "204"},
+		StorageLocation: &domain.StorageLocation{Code: "P3"},
+		Carrier:         domain.CarrierCorreios,
+		Quantity:        2,
+		ReceivedAt:      time.Date(2024, 6, 10, 14, 30, 0, 0, time.UTC),
+	}
+}
+
+func TestNewPackageLabel(t *testing.T) {
+	label := domain.NewPackageLabel(newLabelPackage())
+
+	assert.Equal(t, "PKG00000042", label.Code)
+	assert.Equal(t, "Bloco B - Apto 204", label.Unit)
+	assert.Equal(t, "P3", label.Location)
+	assert.Equal(t, "Correios", label.Carrier)
+	assert.Equal(t, "10/06/2024 14:30", label.ReceivedAt)
+
+	label = domain.NewPackageLabel(&domain.Package{Model: gorm.Model{ID: 7}})
+	assert.Empty(t, label.Unit)
+	assert.Empty(t, label.Carrier)
+	assert.Equal(t, 1, label.Quantity)
+}
+
+func TestRenderZPL(t *testing.T) {
+	zpl := domain.RenderZPL([]domain.PackageLabel{domain.NewPackageLabel(newLabelPackage())})
+
+	assert.True(t, strings.HasPrefix(zpl, "^XA"))
+	assert.Contains(t, zpl, "^BCN,140,Y,N,N^FDPKG00000042^FS")
+	assert.Contains(t, zpl, "^BQN,2,7^FDMA,PKG00000042^FS")
+	assert.Contains(t, zpl, "^FDMaria  Souza^FS")
+	assert.Contains(t, zpl, "2 volumes - Correios")
+	assert.Equal(t, 1, strings.Count(zpl, "^XZ"))
+}
+
+func TestRenderLabelsPDF(t *testing.T) {
+	labels := make([]domain.PackageLabel, 11)
+	for i := range labels {
+		labels[i] = domain.NewPackageLabel(newLabelPackage())
+	}
+
+	pdf, err := domain.RenderLabelsPDF(labels)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(pdf), "%PDF"))
+	assert.Contains(t, string(pdf), "/Count 2")
+}
@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type LossReportStatus string
+
+const (
+	LossReportOpen          LossReportStatus = "ABERTA"
+	LossReportInvestigating LossReportStatus = "EM_INVESTIGACAO"
+	LossReportResolved      LossReportStatus = "RESOLVIDA"
+)
+
+type LossResolution string
+
+const (
+	LossResolutionFound    LossResolution = "ENCONTRADA"
+	LossResolutionLost     LossResolution = "EXTRAVIADA"
+	LossResolutionReturned LossResolution = "DEVOLVIDA_REMETENTE"
+)
+
+var (
+	ErrLossAlreadyReported = errors.New("a encomenda já tem uma ocorrência de extravio em aberto")
+	ErrLossReportResolved  = errors.New("a ocorrência de extravio já foi resolvida")
+	ErrLossNotesRequired   = errors.New("descreva a ocorrência nas observações")
+)
+
+// PackageLossReport is the investigation of a package that could not be found, from the report to
+// its resolution. While it is open the package is under investigation and cannot be delivered.
+type PackageLossReport struct {
+	gorm.Model     `swaggerignore:"true"`
+	PackageID      uint             `json:"package_id" gorm:"not null;index"`
+	Package        *Package         `json:"package,omitempty" gorm:"foreignKey:PackageID" swaggerignore:"true"`
+	Status         LossReportStatus `json:"status" gorm:"type:varchar(20);not null;default:'ABERTA'"`
+	Resolution     LossResolution   `json:"resolution" gorm:"type:varchar(20)"`
+	PreviousStatus PackageStatus    `json:"previous_status" gorm:"type:varchar(20)"`
+	ReportedByID   *uint            `json:"reported_by_id"`
+	ReportedAt     time.Time        `json:"reported_at"`
+	ResolvedByID   *uint            `json:"resolved_by_id"`
+	ResolvedAt     *time.Time       `json:"resolved_at"`
+	Notes          []LossReportNote `json:"notes" gorm:"foreignKey:ReportID"`
+}
+
+// LossReportNote is an entry of the investigation trail
+type LossReportNote struct {
+	gorm.Model `swaggerignore:"true"`
+	ReportID   uint             `json:"report_id" gorm:"not null;index"`
+	Status     LossReportStatus `json:"status" gorm:"type:varchar(20)"`
+	Text       string           `json:"text" gorm:"type:text;not null"`
+	AuthorID   *uint            `json:"author_id"`
+}
+
+// LossReportRequest represents the notes of a step of the investigation
+// swagger:model
+type LossReportRequest struct {
+	Notes string `json:"notes"`
+}
+
+// LossResolutionRequest represents the outcome of an investigation
+// swagger:model
+type LossResolutionRequest struct {
+	Resolution LossResolution `json:"resolution" binding:"required"`
+	Notes      string         `json:"notes"`
+}
+
+func ParseLossResolution(value string) (LossResolution, error) {
+	resolution := LossResolution(strings.ToUpper(strings.TrimSpace(value)))
+	switch resolution {
+	case LossResolutionFound, LossResolutionLost, LossResolutionReturned:
+		return resolution, nil
+	default:
+		return "", fmt.Errorf("resolução inválida: %s", value)
+	}
+}
+
+// ReportLoss opens the investigation of a package that is missing from the mailroom, or that the
+// resident says was never received. The package is under investigation until the report is resolved.
+func ReportLoss(pkg *Package, notes string, reportedByID *uint, now time.Time) (*PackageLossReport, error) {
+	if pkg.Status != PackagePending && pkg.Status != PackageDelivered {
+		if pkg.Status == PackageUnderInvestigation {
+			return nil, ErrLossAlreadyReported
+		}
+		return nil, fmt.Errorf("encomendas com status %s não podem ser reportadas como extraviadas", pkg.Status)
+	}
+
+	report := &PackageLossReport{
+		PackageID:      pkg.ID,
+		Status:         LossReportOpen,
+		PreviousStatus: pkg.Status,
+		ReportedByID:   reportedByID,
+		ReportedAt:     now,
+	}
+	report.AddNote("Extravio reportado", notes, reportedByID)
+
+	pkg.Status = PackageUnderInvestigation
+	return report, nil
+}
+
+// Investigate records that the search for the package started
+func (r *PackageLossReport) Investigate(notes string, authorID *uint) error {
+	if r.Status == LossReportResolved {
+		return ErrLossReportResolved
+	}
+	if strings.TrimSpace(notes) == "" {
+		return ErrLossNotesRequired
+	}
+
+	r.Status = LossReportInvestigating
+	r.AddNote("", notes, authorID)
+	return nil
+}
+
+// Resolve closes the investigation. A package found goes back to the status it had when reported,
+// otherwise it ends lost or returned to the sender.
+func (r *PackageLossReport) Resolve(pkg *Package, resolution LossResolution, notes string, resolvedByID *uint, now time.Time) error {
+	if r.Status == LossReportResolved {
+		return ErrLossReportResolved
+	}
+	if _, err := ParseLossResolution(string(resolution)); err != nil {
+		return err
+	}
+	if resolution != LossResolutionFound && strings.TrimSpace(notes) == "" {
+		return ErrLossNotesRequired
+	}
+
+	switch resolution {
+	case LossResolutionFound:
+		pkg.Status = r.PreviousStatus
+		if pkg.Status == "" {
+			pkg.Status = PackagePending
+		}
+	case LossResolutionLost:
+		pkg.Status = PackageLost
+	case LossResolutionReturned:
+		pkg.Status = PackageReturned
+	}
+
+	r.Status = LossReportResolved
+	r.Resolution = resolution
+	r.ResolvedByID = resolvedByID
+	r.ResolvedAt = &now
+	r.AddNote("Resolvida: "+resolution.Label(), notes, resolvedByID)
+	return nil
+}
+
+// AddNote appends an entry to the investigation trail; prefix describes the step, when any
+func (r *PackageLossReport) AddNote(prefix, text string, authorID *uint) {
+	text = strings.TrimSpace(text)
+	switch {
+	case prefix != "" && text != "":
+		text = prefix + ": " + text
+	case prefix != "":
+		text = prefix
+	}
+	if text == "" {
+		return
+	}
+
+	r.Notes = append(r.Notes, LossReportNote{ReportID: r.ID, Status: r.Status, Text: text, AuthorID: authorID})
+}
+
+func (r LossResolution) Label() string {
+	switch r {
+	case LossResolutionFound:
+		return "encomenda encontrada"
+	case LossResolutionLost:
+		return "encomenda extraviada"
+	case LossResolutionReturned:
+		return "devolvida ao remetente"
+	default:
+		return string(r)
+	}
+}
+
+// CarrierLossStats represents the loss reports of the packages of a carrier received in a period
+// swagger:model
+type CarrierLossStats struct {
+	Carrier    Carrier `json:"carrier"`
+	Received   int     `json:"received"`
+	Reported   int     `json:"reported"`
+	Open       int     `json:"open"`
+	Found      int     `json:"found"`
+	Lost       int     `json:"lost"`
+	Returned   int     `json:"returned"`
+	LossRate   float64 `json:"loss_rate"`
+	ReportRate float64 `json:"report_rate"`
+}
+
+// LossStats represents the loss reports of the packages received in a period, per carrier
+// swagger:model
+type LossStats struct {
+	From     string             `json:"from"`
+	To       string             `json:"to"`
+	Carriers []CarrierLossStats `json:"carriers"`
+}
+
+// BuildLossStats breaks the loss reports down by the carrier of the package, against the packages each
+// carrier delivered to the mailroom; rates are percentages of the received packages. Packages with no
+// carrier are counted as OUTRA.
+func BuildLossStats(received map[Carrier]int, reports []PackageLossReport) []CarrierLossStats {
+	stats := map[Carrier]*CarrierLossStats{}
+	get := func(carrier Carrier) *CarrierLossStats {
+		if carrier == "" {
+			carrier = CarrierOther
+		}
+		if stats[carrier] == nil {
+			stats[carrier] = &CarrierLossStats{Carrier: carrier}
+		}
+		return stats[carrier]
+	}
+
+	for carrier, count := range received {
+		get(carrier).Received += count
+	}
+
+	for _, report := range reports {
+		var carrier Carrier
+		if report.Package != nil {
+			carrier = report.Package.Carrier
+		}
+
+		row := get(carrier)
+		row.Reported++
+		switch {
+		case report.Status != LossReportResolved:
+			row.Open++
+		case report.Resolution == LossResolutionFound:
+			row.Found++
+		case report.Resolution == LossResolutionLost:
+			row.Lost++
+		case report.Resolution == LossResolutionReturned:
+			row.Returned++
+		}
+	}
+
+	rows := make([]CarrierLossStats, 0, len(stats))
+	for _, row := range stats {
+		if row.Received > 0 {
+			row.LossRate = roundTwoDecimals(float64(row.Lost) / float64(row.Received) * 100)
+			row.ReportRate = roundTwoDecimals(float64(row.Reported) / float64(row.Received) * 100)
+		}
+		rows = append(rows, *row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Lost != rows[j].Lost {
+			return rows[i].Lost > rows[j].Lost
+		}
+		return rows[i].Carrier < rows[j].Carrier
+	})
+	return rows
+}
+
+func roundTwoDecimals(value float64) float64 {
+	return math.Round(value*100) / 100
+}
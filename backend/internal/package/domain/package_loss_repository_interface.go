@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+type IPackageLossRepository interface {
+	GetAll(status LossReportStatus) ([]PackageLossReport, error)
+	GetByID(id uint) (*PackageLossReport, error)
+	FindByPackage(packageID uint) ([]PackageLossReport, error)
+	FindOpenByPackage(packageID uint) (*PackageLossReport, error)
+	Save(report *PackageLossReport, pkg *Package) error
+	CountReceivedByCarrier(from, to time.Time) (map[Carrier]int, error)
+	FindByPackagesReceived(from, to time.Time) ([]PackageLossReport, error)
+}
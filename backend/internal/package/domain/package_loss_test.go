@@ -0,0 +1,82 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestLossReportWorkflow(t *testing.T) {
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	pkg := &domain.Package{Model: gorm.Model{ID: 9}, Status: domain.PackagePending}
+
+	report, err := domain.ReportLoss(pkg, "não está na prateleira A-01", uintPtr(1), now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PackageUnderInvestigation, pkg.Status)
+	assert.Equal(t, domain.LossReportOpen, report.Status)
+	require.Len(t, report.Notes, 1)
+	assert.Equal(t, "Extravio reportado: não está na prateleira A-01", report.Notes[0].Text)
+
+	_, err = domain.ReportLoss(pkg, "", nil, now)
+	assert.ErrorIs(t, err, domain.ErrLossAlreadyReported)
+
+	assert.ErrorIs(t, report.Investigate(" ", nil), domain.ErrLossNotesRequired)
+	require.NoError(t, report.Investigate("conferindo as câmeras da portaria", uintPtr(2)))
+	assert.Equal(t, domain.LossReportInvestigating, report.Status)
+
+	assert.ErrorIs(t, report.Resolve(pkg, domain.LossResolutionLost, "", nil, now), domain.ErrLossNotesRequired)
+	require.NoError(t, report.Resolve(pkg, domain.LossResolutionFound, "", uintPtr(2), now.Add(time.Hour)))
+	assert.Equal(t, domain.PackagePending, pkg.Status)
+	assert.Equal(t, domain.LossReportResolved, report.Status)
+	assert.Equal(t, domain.LossResolutionFound, report.Resolution)
+	require.NotNil(t, report.ResolvedAt)
+	assert.Len(t, report.Notes, 3)
+
+	assert.ErrorIs(t, report.Resolve(pkg, domain.LossResolutionLost, "tarde demais", nil, now), domain.ErrLossReportResolved)
+}
+
+func TestLossReportResolutions(t *testing.T) {
+	now := time.Now()
+
+	delivered := &domain.Package{Status: domain.PackageDelivered}
+	report, err := domain.ReportLoss(delivered, "morador diz que não recebeu", nil, now)
+	require.NoError(t, err)
+	require.NoError(t, report.Resolve(delivered, domain.LossResolutionLost, "não localizada", nil, now))
+	assert.Equal(t, domain.PackageLost, delivered.Status)
+
+	pending := &domain.Package{Status: domain.PackagePending}
+	report, err = domain.ReportLoss(pending, "", nil, now)
+	require.NoError(t, err)
+	require.NoError(t, report.Resolve(pending, domain.LossResolutionReturned, "recolhida pelos Correios", nil, now))
+	assert.Equal(t, domain.PackageReturned, pending.Status)
+
+	_, err = domain.ReportLoss(&domain.Package{Status: domain.PackageLost}, "", nil, now)
+	assert.Error(t, err)
+}
+
+func TestBuildLossStats(t *testing.T) {
+	resolved := func(carrier domain.Carrier, resolution domain.LossResolution) domain.PackageLossReport {
+		return domain.PackageLossReport{Status: domain.LossReportResolved, Resolution: resolution, Package: &domain.Package{Carrier: carrier}}
+	}
+
+	stats := domain.BuildLossStats(
+		map[domain.Carrier]int{domain.CarrierCorreios: 200, domain.CarrierJadlog: 50, "": 10},
+		[]domain.PackageLossReport{
+			resolved(domain.CarrierJadlog, domain.LossResolutionLost),
+			resolved(domain.CarrierJadlog, domain.LossResolutionLost),
+			resolved(domain.CarrierJadlog, domain.LossResolutionFound),
+			resolved(domain.CarrierCorreios, domain.LossResolutionReturned),
+			{Status: domain.LossReportInvestigating, Package: &domain.Package{Carrier: domain.CarrierCorreios}},
+		},
+	)
+
+	require.Len(t, stats, 3)
+	assert.Equal(t, domain.CarrierLossStats{Carrier: domain.CarrierJadlog, Received: 50, Reported: 3, Found: 1, Lost: 2, LossRate: 4, ReportRate: 6}, stats[0])
+	assert.Equal(t, domain.CarrierLossStats{Carrier: domain.CarrierCorreios, Received: 200, Reported: 2, Open: 1, Returned: 1, ReportRate: 1}, stats[1])
+	assert.Equal(t, domain.CarrierOther, stats[2].Carrier)
+	assert.Equal(t, 10, stats[2].Received)
+}
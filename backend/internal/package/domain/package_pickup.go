@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// PickupCodeLength is the number of digits of the code the resident shows at pickup
+const PickupCodeLength = 6
+
+// MaxPickupAttempts is how many wrong codes are accepted before the package can only be
+// handed over with a staff override
+const MaxPickupAttempts = 5
+
+var (
+	ErrPackageNotPending       = errors.New("a encomenda não está aguardando retirada")
+	ErrPickupCodeRequired      = errors.New("informe o código de retirada ou o motivo da liberação sem código")
+	ErrInvalidPickupCode       = errors.New("código de retirada inválido")
+	ErrPickupAttemptsExceeded  = errors.New("tentativas de código esgotadas, a entrega exige liberação pela portaria com motivo")
+	ErrOverrideReasonTooShort  = errors.New("o motivo da liberação sem código deve ter ao menos 10 caracteres")
+	ErrPickupRecipientRequired = errors.New("informe quem retirou a encomenda")
+)
+
+// PickupRequest is what the porter informs when handing over a package: the code given by the
+// resident or, without it, the reason the handover was allowed anyway, and who is collecting it.
+// Without DeliveredToID or AuthorizedPickupID the package goes to the resident it is addressed to.
+type PickupRequest struct {
+	PickupCode         string `json:"pickup_code"`
+	OverrideReason     string `json:"override_reason"`
+	DeliveredToID      *uint  `json:"delivered_to_id"`
+	AuthorizedPickupID *uint  `json:"authorized_pickup_id"`
+}
+
+// GeneratePickupCode draws a random numeric code with PickupCodeLength digits
+func GeneratePickupCode() (string, error) {
+	limit := big.NewInt(1)
+	for i := 0; i < PickupCodeLength; i++ {
+		limit.Mul(limit, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", fmt.Errorf("erro ao gerar código de retirada: %v", err)
+	}
+
+	return fmt.Sprintf("%0*d", PickupCodeLength, n.Int64()), nil
+}
+
+// AssignPickupCode gives the package a new pickup code, to be sent with the arrival notification
+func (p *Package) AssignPickupCode() error {
+	code, err := GeneratePickupCode()
+	if err != nil {
+		return err
+	}
+
+	p.PickupCode = code
+	p.PickupAttempts = 0
+	return nil
+}
+
+// Deliver hands the package over to the recipient after checking the pickup code, or records the
+// override reason when the staff releases it without one. A wrong code counts as an attempt, so the
+// caller must save the package even when ErrInvalidPickupCode is returned. Packages registered
+// before pickup codes existed have none and are delivered without it.
+func (p *Package) Deliver(request PickupRequest, recipient PickupRecipient, handedOverByID *uint, now time.Time) error {
+	if p.Status != PackagePending {
+		return ErrPackageNotPending
+	}
+
+	code := strings.TrimSpace(request.PickupCode)
+	reason := strings.TrimSpace(request.OverrideReason)
+
+	switch {
+	case code != "":
+		if p.PickupCode != "" {
+			if p.PickupAttempts >= MaxPickupAttempts {
+				return ErrPickupAttemptsExceeded
+			}
+			if subtle.ConstantTimeCompare([]byte(code), []byte(p.PickupCode)) != 1 {
+				p.PickupAttempts++
+				return ErrInvalidPickupCode
+			}
+		}
+		reason = ""
+	case reason != "":
+		if len([]rune(reason)) < 10 {
+			return ErrOverrideReasonTooShort
+		}
+	case p.PickupCode != "":
+		return ErrPickupCodeRequired
+	}
+
+	if strings.TrimSpace(recipient.Name) == "" {
+		return ErrPickupRecipientRequired
+	}
+
+	p.Status = PackageDelivered
+	p.DeliveredAt = now
+	p.DeliveredToID = recipient.ResidentID
+	p.AuthorizedPickupID = recipient.AuthorizedPickupID
+	p.PickedUpByName = strings.TrimSpace(recipient.Name)
+	p.PickedUpByDocument = recipient.Document
+	p.DeliveredByID = handedOverByID
+	p.PickupOverrideReason = reason
+	return nil
+}
+
+// StartPending readies a package registered at intake: it waits for pickup and no handover field
+// sent by the client is kept, since only Deliver fills them
+func (p *Package) StartPending() {
+	p.Status = PackagePending
+	p.PickupAttempts = 0
+	p.DeliveredAt = time.Time{}
+	p.DeliveredToID = nil
+	p.DeliveredTo = nil
+	p.DeliveredByID = nil
+	p.PickupOverrideReason = ""
+	p.AuthorizedPickupID = nil
+	p.AuthorizedPickup = nil
+	p.PickedUpByName = ""
+	p.PickedUpByDocument = ""
+}
+
+// KeepHandover copies the status, pickup code and handover fields of the stored package, so an
+// edit cannot deliver the package or rewrite who collected it; only Deliver changes them
+func (p *Package) KeepHandover(existing *Package) {
+	p.Status = existing.Status
+	p.PickupCode = existing.PickupCode
+	p.PickupAttempts = existing.PickupAttempts
+	p.DeliveredAt = existing.DeliveredAt
+	p.DeliveredToID = existing.DeliveredToID
+	p.DeliveredTo = nil
+	p.DeliveredByID = existing.DeliveredByID
+	p.PickupOverrideReason = existing.PickupOverrideReason
+	p.AuthorizedPickupID = existing.AuthorizedPickupID
+	p.AuthorizedPickup = nil
+	p.PickedUpByName = existing.PickedUpByName
+	p.PickedUpByDocument = existing.PickedUpByDocument
+}
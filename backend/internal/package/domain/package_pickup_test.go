@@ -0,0 +1,137 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var resident = domain.PickupRecipient{ResidentID: uintPtr(3), Name: "Fulano", Document: "12345678900"}
+
+func uintPtr(value uint) *uint {
+	return &value
+}
+
+func pendingPackage(t *testing.T) *domain.Package {
+	t.Helper()
+
+	pkg := &domain.Package{ResidentID: uintPtr(3), Status: domain.PackagePending}
+	require.NoError(t, pkg.AssignPickupCode())
+	return pkg
+}
+
+func TestGeneratePickupCode(t *testing.T) {
+	code, err := domain.GeneratePickupCode()
+	require.NoError(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^[0-9]{6}$`), code)
+}
+
+func TestPackage_DeliverWithCode(t *testing.T) {
+	now := time.Date(2025, time.November, 3, 18, 0, 0, 0, time.UTC)
+	porterID := uint(9)
+
+	pkg := pendingPackage(t)
+	err := pkg.Deliver(domain.PickupRequest{PickupCode: "wrong"}, resident, &porterID, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidPickupCode)
+	assert.Equal(t, 1, pkg.PickupAttempts)
+	assert.Equal(t, domain.PackagePending, pkg.Status)
+
+	require.NoError(t, pkg.Deliver(domain.PickupRequest{PickupCode: " " + pkg.PickupCode + " "}, resident, &porterID, now))
+	assert.Equal(t, domain.PackageDelivered, pkg.Status)
+	assert.Equal(t, now, pkg.DeliveredAt)
+	assert.Equal(t, uint(3), *pkg.DeliveredToID)
+	assert.Equal(t, porterID, *pkg.DeliveredByID)
+	assert.Empty(t, pkg.PickupOverrideReason)
+
+	assert.ErrorIs(t, pkg.Deliver(domain.PickupRequest{PickupCode: pkg.PickupCode}, resident, &porterID, now), domain.ErrPackageNotPending)
+}
+
+func TestPackage_DeliverAttemptsExceeded(t *testing.T) {
+	now := time.Date(2025, time.November, 3, 18, 0, 0, 0, time.UTC)
+
+	pkg := pendingPackage(t)
+	pkg.PickupAttempts = domain.MaxPickupAttempts
+	assert.ErrorIs(t, pkg.Deliver(domain.PickupRequest{PickupCode: pkg.PickupCode}, resident, nil, now), domain.ErrPickupAttemptsExceeded)
+
+	require.NoError(t, pkg.Deliver(domain.PickupRequest{OverrideReason: "morador sem celular, documento conferido"}, resident, nil, now))
+	assert.Equal(t, domain.PackageDelivered, pkg.Status)
+	assert.Equal(t, "morador sem celular, documento conferido", pkg.PickupOverrideReason)
+}
+
+func TestPackage_DeliverWithoutCode(t *testing.T) {
+	now := time.Date(2025, time.November, 3, 18, 0, 0, 0, time.UTC)
+
+	pkg := pendingPackage(t)
+	assert.ErrorIs(t, pkg.Deliver(domain.PickupRequest{}, resident, nil, now), domain.ErrPickupCodeRequired)
+	assert.ErrorIs(t, pkg.Deliver(domain.PickupRequest{OverrideReason: "urgente"}, resident, nil, now), domain.ErrOverrideReasonTooShort)
+
+	neighbor := domain.PickupRecipient{ResidentID: uintPtr(4), Name: "Beltrano"}
+	require.NoError(t, pkg.Deliver(domain.PickupRequest{OverrideReason: "vizinho autorizado por telefone", DeliveredToID: uintPtr(4)}, neighbor, nil, now))
+	assert.Equal(t, uint(4), *pkg.DeliveredToID)
+	assert.Equal(t, "Beltrano", pkg.PickedUpByName)
+
+	legacy := &domain.Package{ResidentID: uintPtr(3), Status: domain.PackagePending}
+	assert.ErrorIs(t, legacy.Deliver(domain.PickupRequest{}, domain.PickupRecipient{}, nil, now), domain.ErrPickupRecipientRequired)
+	require.NoError(t, legacy.Deliver(domain.PickupRequest{}, resident, nil, now))
+	assert.Equal(t, domain.PackageDelivered, legacy.Status)
+}
+
+func TestPackage_KeepHandover(t *testing.T) {
+	existing := pendingPackage(t)
+	existing.PickupAttempts = 2
+
+	edited := &domain.Package{
+		Description:          "caixa grande",
+		Status:               domain.PackageDelivered,
+		PickupCode:           "000000",
+		DeliveredByID:        uintPtr(9),
+		DeliveredToID:        uintPtr(9),
+		PickupOverrideReason: "sem motivo nenhum",
+		AuthorizedPickupID:   uintPtr(5),
+		PickedUpByName:       "Fulano",
+		PickedUpByDocument:   "123",
+	}
+	edited.KeepHandover(existing)
+
+	assert.Equal(t, "caixa grande", edited.Description)
+	assert.Equal(t, domain.PackagePending, edited.Status)
+	assert.Equal(t, existing.PickupCode, edited.PickupCode)
+	assert.Equal(t, 2, edited.PickupAttempts)
+	assert.Nil(t, edited.DeliveredByID)
+	assert.Nil(t, edited.DeliveredToID)
+	assert.Nil(t, edited.AuthorizedPickupID)
+	assert.Empty(t, edited.PickupOverrideReason)
+	assert.Empty(t, edited.PickedUpByName)
+	assert.Empty(t, edited.PickedUpByDocument)
+}
+
+func TestPackage_StartPending(t *testing.T) {
+	pkg := &domain.Package{
+		ResidentID:           uintPtr(3),
+		Status:               domain.PackageDelivered,
+		PickupAttempts:       4,
+		DeliveredAt:          time.Date(2025, time.November, 3, 18, 0, 0, 0, time.UTC),
+		DeliveredByID:        uintPtr(9),
+		DeliveredToID:        uintPtr(9),
+		PickupOverrideReason: "entregue sem código",
+		AuthorizedPickupID:   uintPtr(5),
+		PickedUpByName:       "Fulano",
+		PickedUpByDocument:   "123",
+	}
+	pkg.StartPending()
+
+	assert.Equal(t, domain.PackagePending, pkg.Status)
+	assert.Zero(t, pkg.PickupAttempts)
+	assert.True(t, pkg.DeliveredAt.IsZero())
+	assert.Nil(t, pkg.DeliveredByID)
+	assert.Nil(t, pkg.DeliveredToID)
+	assert.Nil(t, pkg.AuthorizedPickupID)
+	assert.Empty(t, pkg.PickupOverrideReason)
+	assert.Empty(t, pkg.PickedUpByName)
+	assert.Empty(t, pkg.PickedUpByDocument)
+	assert.Equal(t, uint(3), *pkg.ResidentID)
+}
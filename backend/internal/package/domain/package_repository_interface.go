@@ -3,9 +3,13 @@ package domain
 type IPackageRepository interface {
 	GetAll(page, pageSize int) ([]Package, error)
 	GetByID(id uint) (*Package, error)
+	GetByIDs(ids []uint) ([]Package, error)
 	Create(pkg *Package) error
+	CreateBatch(packages []Package) error
 	Update(pkg *Package) error
 	Delete(id uint) error
 	MarkAsDelivered(id uint) error
-	MarkAsLost(id uint) error
+	Deliver(id uint, deliver func(pkg *Package) error) (*Package, error)
+	FindByTrackingCode(code string) (*Package, error)
+	GetPending() ([]Package, error)
 }
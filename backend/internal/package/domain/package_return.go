@@ -0,0 +1,279 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type ReturnReason string
+
+const (
+	ReturnRefused          ReturnReason = "RECUSADA"
+	ReturnMovedOut         ReturnReason = "MUDOU_SE"
+	ReturnUnknownRecipient ReturnReason = "DESCONHECIDO"
+	ReturnOther            ReturnReason = "OUTRO"
+)
+
+type ReturnStatus string
+
+const (
+	ReturnAwaitingPickup ReturnStatus = "AGUARDANDO_COLETA"
+	ReturnCompleted      ReturnStatus = "DEVOLVIDA"
+	ReturnCancelled      ReturnStatus = "CANCELADA"
+)
+
+var (
+	ErrReturnNotPending     = errors.New("somente encomendas pendentes podem ser devolvidas")
+	ErrReturnNotAwaiting    = errors.New("a devolução não está aguardando a coleta da transportadora")
+	ErrReturnNotesRequired  = errors.New("descreva o motivo da devolução nas observações")
+	ErrReturnAgentRequired  = errors.New("informe o nome de quem coletou a encomenda pela transportadora")
+	ErrReturnProofRequired  = errors.New("informe o código do comprovante de devolução")
+	ErrReturnPickupInFuture = errors.New("a data da coleta não pode estar no futuro")
+	ErrReturnPickupTooEarly = errors.New("a data da coleta não pode ser anterior ao registro da devolução")
+)
+
+// PackageReturn is a package that goes back to the sender, because the resident refused it or no
+// longer lives in the condominium. While it waits for the carrier it is out of the pending queue,
+// but still takes room on its shelf.
+type PackageReturn struct {
+	gorm.Model     `swaggerignore:"true"`
+	PackageID      uint          `json:"package_id" gorm:"not null;index"`
+	Package        *Package      `json:"package,omitempty" gorm:"foreignKey:PackageID" swaggerignore:"true"`
+	Reason         ReturnReason  `json:"reason" gorm:"type:varchar(20);not null"`
+	Status         ReturnStatus  `json:"status" gorm:"type:varchar(20);not null;default:'AGUARDANDO_COLETA'"`
+	Notes          string        `json:"notes" gorm:"type:text"`
+	PreviousStatus PackageStatus `json:"previous_status" gorm:"type:varchar(20)"`
+	RegisteredByID *uint         `json:"registered_by_id"`
+	RegisteredAt   time.Time     `json:"registered_at"`
+
+	CarrierPickupAt      *time.Time `json:"carrier_pickup_at"`
+	CarrierAgentName     string     `json:"carrier_agent_name" gorm:"type:varchar(100)"`
+	CarrierAgentDocument string     `json:"carrier_agent_document" gorm:"type:varchar(20)"`
+	ProofCode            string     `json:"proof_code" gorm:"type:varchar(60)"`
+	HandedOverByID       *uint      `json:"handed_over_by_id"`
+}
+
+// ReturnRequest represents why a package goes back to the sender
+// swagger:model
+type ReturnRequest struct {
+	Reason ReturnReason `json:"reason" binding:"required"`
+	Notes  string       `json:"notes"`
+}
+
+// CarrierPickupRequest represents the collection of a returned package by the carrier; the proof is
+// the code of the return receipt or reverse logistics authorization, and a photo of the receipt can
+// be attached as RECIBO_DEVOLUCAO
+// swagger:model
+type CarrierPickupRequest struct {
+	PickedUpAt    *time.Time `json:"picked_up_at"`
+	AgentName     string     `json:"agent_name" binding:"required"`
+	AgentDocument string     `json:"agent_document"`
+	ProofCode     string     `json:"proof_code"`
+	Notes         string     `json:"notes"`
+}
+
+// ReturnCancelRequest represents why a return was called off
+// swagger:model
+type ReturnCancelRequest struct {
+	Notes string `json:"notes"`
+}
+
+func ParseReturnReason(value string) (ReturnReason, error) {
+	reason := ReturnReason(strings.ToUpper(strings.TrimSpace(value)))
+	switch reason {
+	case ReturnRefused, ReturnMovedOut, ReturnUnknownRecipient, ReturnOther:
+		return reason, nil
+	default:
+		return "", fmt.Errorf("motivo de devolução inválido: %s", value)
+	}
+}
+
+func (r ReturnReason) Label() string {
+	switch r {
+	case ReturnRefused:
+		return "recusada pelo morador"
+	case ReturnMovedOut:
+		return "morador mudou-se"
+	case ReturnUnknownRecipient:
+		return "destinatário desconhecido"
+	case ReturnOther:
+		return "outro motivo"
+	default:
+		return string(r)
+	}
+}
+
+// ReturnPackage takes a pending package out of the pickup queue to be returned to the sender. A
+// refused package becomes RECUSADA and any other AGUARDANDO_DEVOLUCAO, until the carrier collects it.
+func ReturnPackage(pkg *Package, reason ReturnReason, notes string, registeredByID *uint, now time.Time) (*PackageReturn, error) {
+	if pkg.Status != PackagePending {
+		return nil, ErrReturnNotPending
+	}
+	if _, err := ParseReturnReason(string(reason)); err != nil {
+		return nil, err
+	}
+	notes = strings.TrimSpace(notes)
+	if reason == ReturnOther && notes == "" {
+		return nil, ErrReturnNotesRequired
+	}
+
+	packageReturn := &PackageReturn{
+		PackageID:      pkg.ID,
+		Reason:         reason,
+		Status:         ReturnAwaitingPickup,
+		Notes:          notes,
+		PreviousStatus: pkg.Status,
+		RegisteredByID: registeredByID,
+		RegisteredAt:   now,
+	}
+
+	pkg.Status = PackageAwaitingReturn
+	if reason == ReturnRefused {
+		pkg.Status = PackageRefused
+	}
+	return packageReturn, nil
+}
+
+// ConfirmCarrierPickup records who collected the package for the carrier and when, with the proof of
+// the return. The package ends returned (DEVOLVIDA) and leaves its shelf.
+func (r *PackageReturn) ConfirmCarrierPickup(pkg *Package, request CarrierPickupRequest, handedOverByID *uint, now time.Time) error {
+	if r.Status != ReturnAwaitingPickup {
+		return ErrReturnNotAwaiting
+	}
+
+	agentName := strings.TrimSpace(request.AgentName)
+	if agentName == "" {
+		return ErrReturnAgentRequired
+	}
+	proofCode := strings.TrimSpace(request.ProofCode)
+	if proofCode == "" {
+		return ErrReturnProofRequired
+	}
+
+	pickedUpAt := now
+	if request.PickedUpAt != nil {
+		pickedUpAt = *request.PickedUpAt
+	}
+	if pickedUpAt.After(now) {
+		return ErrReturnPickupInFuture
+	}
+	if pickedUpAt.Before(r.RegisteredAt) {
+		return ErrReturnPickupTooEarly
+	}
+
+	r.Status = ReturnCompleted
+	r.CarrierPickupAt = &pickedUpAt
+	r.CarrierAgentName = agentName
+	r.CarrierAgentDocument = strings.TrimSpace(request.AgentDocument)
+	r.ProofCode = proofCode
+	r.HandedOverByID = handedOverByID
+	r.appendNotes(request.Notes)
+
+	pkg.Status = PackageReturned
+	pkg.StorageLocationID = nil
+	pkg.StorageLocation = nil
+	return nil
+}
+
+// Cancel puts the package back in the pickup queue, when the resident changes their mind before the
+// carrier collects it
+func (r *PackageReturn) Cancel(pkg *Package, notes string) error {
+	if r.Status != ReturnAwaitingPickup {
+		return ErrReturnNotAwaiting
+	}
+
+	r.Status = ReturnCancelled
+	r.appendNotes(notes)
+
+	pkg.Status = r.PreviousStatus
+	if pkg.Status == "" {
+		pkg.Status = PackagePending
+	}
+	return nil
+}
+
+func (r *PackageReturn) appendNotes(notes string) {
+	notes = strings.TrimSpace(notes)
+	switch {
+	case notes == "":
+	case r.Notes == "":
+		r.Notes = notes
+	default:
+		r.Notes += "\n" + notes
+	}
+}
+
+// ReturnReasonCount represents the returns of a reason in a period
+// swagger:model
+type ReturnReasonCount struct {
+	Reason ReturnReason `json:"reason"`
+	Label  string       `json:"label"`
+	Count  int          `json:"count"`
+}
+
+// ReturnStats represents the packages returned to the sender registered in a period
+// swagger:model
+type ReturnStats struct {
+	From                    string              `json:"from"`
+	To                      string              `json:"to"`
+	Total                   int                 `json:"total"`
+	AwaitingPickup          int                 `json:"awaiting_pickup"`
+	Returned                int                 `json:"returned"`
+	Cancelled               int                 `json:"cancelled"`
+	AverageDaysToPickup     float64             `json:"average_days_to_pickup"`
+	OldestAwaitingSinceDays int                 `json:"oldest_awaiting_since_days"`
+	Reasons                 []ReturnReasonCount `json:"reasons"`
+	Carriers                map[Carrier]int     `json:"carriers"`
+}
+
+// BuildReturnStats counts the returns by status, reason and carrier of the package, and how long the
+// carriers take to collect them
+func BuildReturnStats(returns []PackageReturn, now time.Time) ReturnStats {
+	stats := ReturnStats{Reasons: []ReturnReasonCount{}, Carriers: map[Carrier]int{}}
+	reasons := map[ReturnReason]int{}
+
+	pickupDays := 0
+	for _, packageReturn := range returns {
+		stats.Total++
+		reasons[packageReturn.Reason]++
+
+		carrier := CarrierOther
+		if packageReturn.Package != nil && packageReturn.Package.Carrier != "" {
+			carrier = packageReturn.Package.Carrier
+		}
+		stats.Carriers[carrier]++
+
+		switch packageReturn.Status {
+		case ReturnAwaitingPickup:
+			stats.AwaitingPickup++
+			stats.OldestAwaitingSinceDays = max(stats.OldestAwaitingSinceDays, DaysWaiting(packageReturn.RegisteredAt, now))
+		case ReturnCompleted:
+			stats.Returned++
+			if packageReturn.CarrierPickupAt != nil {
+				pickupDays += DaysWaiting(packageReturn.RegisteredAt, *packageReturn.CarrierPickupAt)
+			}
+		case ReturnCancelled:
+			stats.Cancelled++
+		}
+	}
+
+	for reason, count := range reasons {
+		stats.Reasons = append(stats.Reasons, ReturnReasonCount{Reason: reason, Label: reason.Label(), Count: count})
+	}
+	sort.Slice(stats.Reasons, func(i, j int) bool {
+		if stats.Reasons[i].Count != stats.Reasons[j].Count {
+			return stats.Reasons[i].Count > stats.Reasons[j].Count
+		}
+		return stats.Reasons[i].Reason < stats.Reasons[j].Reason
+	})
+
+	if stats.Returned > 0 {
+		stats.AverageDaysToPickup = roundTwoDecimals(float64(pickupDays) / float64(stats.Returned))
+	}
+	return stats
+}
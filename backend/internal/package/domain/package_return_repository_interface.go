@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+type IPackageReturnRepository interface {
+	GetAll(status ReturnStatus) ([]PackageReturn, error)
+	GetByID(id uint) (*PackageReturn, error)
+	FindByPackage(packageID uint) ([]PackageReturn, error)
+	FindRegistered(from, to time.Time) ([]PackageReturn, error)
+	Save(packageReturn *PackageReturn, pkg *Package) error
+}
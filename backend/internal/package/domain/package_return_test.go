@@ -0,0 +1,94 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestReturnPackageWorkflow(t *testing.T) {
+	now := time.Date(2024, 6, 3, 10, 0, 0, 0, time.UTC)
+	pkg := &domain.Package{Model: gorm.Model{ID: 9}, Status: domain.PackagePending, StorageLocationID: uintPtr(4)}
+
+	packageReturn, err := domain.ReturnPackage(pkg, domain.ReturnRefused, "morador não fez a compra", uintPtr(1), now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PackageRefused, pkg.Status)
+	assert.True(t, pkg.Status.IsShelved())
+	assert.Equal(t, domain.ReturnAwaitingPickup, packageReturn.Status)
+
+	_, err = domain.ReturnPackage(pkg, domain.ReturnRefused, "", nil, now)
+	assert.ErrorIs(t, err, domain.ErrReturnNotPending)
+
+	pickup := domain.CarrierPickupRequest{AgentName: "João Carteiro", ProofCode: "LR123"}
+	assert.ErrorIs(t, packageReturn.ConfirmCarrierPickup(pkg, domain.CarrierPickupRequest{AgentName: " "}, nil, now), domain.ErrReturnAgentRequired)
+	assert.ErrorIs(t, packageReturn.ConfirmCarrierPickup(pkg, domain.CarrierPickupRequest{AgentName: "João"}, nil, now), domain.ErrReturnProofRequired)
+
+	future := now.Add(time.Hour)
+	pickup.PickedUpAt = &future
+	assert.ErrorIs(t, packageReturn.ConfirmCarrierPickup(pkg, pickup, nil, now), domain.ErrReturnPickupInFuture)
+	early := now.Add(-time.Hour)
+	pickup.PickedUpAt = &early
+	assert.ErrorIs(t, packageReturn.ConfirmCarrierPickup(pkg, pickup, nil, now), domain.ErrReturnPickupTooEarly)
+
+	pickup.PickedUpAt = nil
+	pickup.Notes = "coleta na portaria"
+	later := now.AddDate(0, 0, 2)
+	require.NoError(t, packageReturn.ConfirmCarrierPickup(pkg, pickup, uintPtr(2), later))
+	assert.Equal(t, domain.PackageReturned, pkg.Status)
+	assert.Nil(t, pkg.StorageLocationID)
+	assert.Equal(t, domain.ReturnCompleted, packageReturn.Status)
+	assert.Equal(t, later, *packageReturn.CarrierPickupAt)
+	assert.Equal(t, "morador não fez a compra\ncoleta na portaria", packageReturn.Notes)
+
+	assert.ErrorIs(t, packageReturn.Cancel(pkg, ""), domain.ErrReturnNotAwaiting)
+}
+
+func TestReturnPackageReasons(t *testing.T) {
+	now := time.Now()
+
+	movedOut := &domain.Package{Status: domain.PackagePending}
+	packageReturn, err := domain.ReturnPackage(movedOut, domain.ReturnMovedOut, "", nil, now)
+	require.NoError(t, err)
+	assert.Equal(t, domain.PackageAwaitingReturn, movedOut.Status)
+
+	require.NoError(t, packageReturn.Cancel(movedOut, "morador voltou"))
+	assert.Equal(t, domain.PackagePending, movedOut.Status)
+	assert.Equal(t, domain.ReturnCancelled, packageReturn.Status)
+
+	_, err = domain.ReturnPackage(&domain.Package{Status: domain.PackagePending}, domain.ReturnOther, " ", nil, now)
+	assert.ErrorIs(t, err, domain.ErrReturnNotesRequired)
+
+	_, err = domain.ReturnPackage(&domain.Package{Status: domain.PackagePending}, "EXTRAVIADA", "", nil, now)
+	assert.Error(t, err)
+
+	reason, err := domain.ParseReturnReason(" mudou_se ")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReturnMovedOut, reason)
+}
+
+func TestBuildReturnStats(t *testing.T) {
+	now := time.Date(2024, 6, 30, 12, 0, 0, 0, time.UTC)
+	pickedUp := now.AddDate(0, 0, -6)
+
+	stats := domain.BuildReturnStats([]domain.PackageReturn{
+		{Reason: domain.ReturnRefused, Status: domain.ReturnAwaitingPickup, RegisteredAt: now.AddDate(0, 0, -5), Package: &domain.Package{Carrier: domain.CarrierCorreios}},
+		{Reason: domain.ReturnRefused, Status: domain.ReturnCompleted, RegisteredAt: now.AddDate(0, 0, -10), CarrierPickupAt: &pickedUp, Package: &domain.Package{Carrier: domain.CarrierCorreios}},
+		{Reason: domain.ReturnMovedOut, Status: domain.ReturnCancelled, RegisteredAt: now.AddDate(0, 0, -2)},
+	}, now)
+
+	assert.Equal(t, 3, stats.Total)
+	assert.Equal(t, 1, stats.AwaitingPickup)
+	assert.Equal(t, 1, stats.Returned)
+	assert.Equal(t, 1, stats.Cancelled)
+	assert.Equal(t, 4.0, stats.AverageDaysToPickup)
+	assert.Equal(t, 5, stats.OldestAwaitingSinceDays)
+	assert.Equal(t, 2, stats.Carriers[domain.CarrierCorreios])
+	assert.Equal(t, 1, stats.Carriers[domain.CarrierOther])
+	require.Len(t, stats.Reasons, 2)
+	assert.Equal(t, domain.ReturnRefused, stats.Reasons[0].Reason)
+	assert.Equal(t, 2, stats.Reasons[0].Count)
+}
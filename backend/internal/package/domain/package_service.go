@@ -31,7 +31,3 @@ func (s *PackageService) GetAllPackages(page, pageSize int) ([]Package, error) {
 func (s *PackageService) MarkAsDelivered(id uint) error {
 	return s.repo.MarkAsDelivered(id)
 }
-
-func (s *PackageService) MarkAsLost(id uint) error {
-	return s.repo.MarkAsLost(id)
-}
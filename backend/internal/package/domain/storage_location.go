@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+var storageCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9-]{0,19}$`)
+
+var (
+	ErrNoStorageAvailable  = errors.New("nenhum local de armazenamento com espaço livre")
+	ErrStorageLocationFull = errors.New("local de armazenamento sem espaço livre")
+)
+
+// StorageLocation is a shelf or bin of the mailroom. Capacity is counted in volumes, the quantity of
+// the pending packages stored there. The code is unique among locations not deleted, so it can be
+// reused after a location is removed.
+type StorageLocation struct {
+	gorm.Model  `swaggerignore:"true"`
+	Code        string `json:"code" gorm:"type:varchar(20);not null;uniqueIndex:idx_storage_locations_code_active,where:deleted_at IS NULL"`
+	Description string `json:"description"`
+	Capacity    int    `json:"capacity" gorm:"not null"`
+	Active      bool   `json:"active" gorm:"not null;default:true"`
+}
+
+// StorageOccupancy is how full a location is with pending packages
+type StorageOccupancy struct {
+	Location   StorageLocation `json:"location"`
+	Used       int             `json:"used"`
+	Free       int             `json:"free"`
+	Percentage float64         `json:"percentage"`
+}
+
+func (l *StorageLocation) Validate() error {
+	l.Code = strings.ToUpper(strings.TrimSpace(l.Code))
+	if !storageCodePattern.MatchString(l.Code) {
+		return fmt.Errorf("código de local inválido, use letras, números e hífen: %s", l.Code)
+	}
+	if l.Capacity <= 0 {
+		return fmt.Errorf("a capacidade deve ser maior que zero")
+	}
+	return nil
+}
+
+func NewStorageOccupancy(location StorageLocation, used int) StorageOccupancy {
+	occupancy := StorageOccupancy{Location: location, Used: used, Free: location.Capacity - used}
+	if occupancy.Free < 0 {
+		occupancy.Free = 0
+	}
+	if location.Capacity > 0 {
+		occupancy.Percentage = float64(used) / float64(location.Capacity) * 100
+	}
+	return occupancy
+}
+
+// Fits tells whether the location can take the given number of volumes
+func (o StorageOccupancy) Fits(quantity int) bool {
+	return o.Location.Active && o.Free >= quantity
+}
+
+// SuggestStorageLocation picks where to store a package with the given quantity. A location that
+// already holds packages of the same unit is preferred, so the resident's packages stay together;
+// otherwise the location with the least free space that still fits, keeping larger spaces for larger
+// deliveries.
+func SuggestStorageLocation(occupancies []StorageOccupancy, quantity int, unitLocationIDs []uint) (*StorageLocation, error) {
+	if quantity <= 0 {
+		quantity = 1
+	}
+
+	candidates := make([]StorageOccupancy, 0, len(occupancies))
+	for _, occupancy := range occupancies {
+		if occupancy.Fits(quantity) {
+			candidates = append(candidates, occupancy)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoStorageAvailable
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Free != candidates[j].Free {
+			return candidates[i].Free < candidates[j].Free
+		}
+		return candidates[i].Location.Code < candidates[j].Location.Code
+	})
+
+	for _, candidate := range candidates {
+		for _, id := range unitLocationIDs {
+			if candidate.Location.ID == id {
+				location := candidate.Location
+				return &location, nil
+			}
+		}
+	}
+
+	location := candidates[0].Location
+	return &location, nil
+}
@@ -0,0 +1,13 @@
+package domain
+
+type IStorageLocationRepository interface {
+	GetAll() ([]StorageLocation, error)
+	GetByID(id uint) (*StorageLocation, error)
+	Create(location *StorageLocation) error
+	Update(location *StorageLocation) error
+	Delete(id uint) error
+	GetOccupancy() ([]StorageOccupancy, error)
+	GetLocationOccupancy(id uint) (*StorageOccupancy, error)
+	FindUnitLocationIDs(block, apartment string) ([]uint, error)
+	GetPendingPackages(id uint) ([]Package, error)
+}
@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"portarius/internal/package/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func location(id uint, code string, capacity int) domain.StorageLocation {
+	return domain.StorageLocation{Model: gorm.Model{ID: id}, Code: code, Capacity: capacity, Active: true}
+}
+
+func TestStorageLocation_Validate(t *testing.T) {
+	shelf := domain.StorageLocation{Code: " a-01 ", Capacity: 10}
+	require.NoError(t, shelf.Validate())
+	assert.Equal(t, "A-01", shelf.Code)
+
+	assert.Error(t, (&domain.StorageLocation{Code: "A 01", Capacity: 10}).Validate())
+	assert.Error(t, (&domain.StorageLocation{Code: "A-01"}).Validate())
+}
+
+func TestNewStorageOccupancy(t *testing.T) {
+	occupancy := domain.NewStorageOccupancy(location(1, "A-01", 8), 6)
+	assert.Equal(t, 2, occupancy.Free)
+	assert.Equal(t, 75.0, occupancy.Percentage)
+	assert.True(t, occupancy.Fits(2))
+	assert.False(t, occupancy.Fits(3))
+
+	over := domain.NewStorageOccupancy(location(1, "A-01", 8), 9)
+	assert.Equal(t, 0, over.Free)
+}
+
+func TestSuggestStorageLocation(t *testing.T) {
+	inactive := location(4, "D-01", 50)
+	inactive.Active = false
+
+	occupancies := []domain.StorageOccupancy{
+		domain.NewStorageOccupancy(location(1, "A-01", 10), 2),
+		domain.NewStorageOccupancy(location(2, "B-01", 10), 7),
+		domain.NewStorageOccupancy(location(3, "C-01", 10), 10),
+		domain.NewStorageOccupancy(inactive, 0),
+	}
+
+	suggested, err := domain.SuggestStorageLocation(occupancies, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "B-01", suggested.Code, "tightest location that fits")
+
+	suggested, err = domain.SuggestStorageLocation(occupancies, 5, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "A-01", suggested.Code)
+
+	suggested, err = domain.SuggestStorageLocation(occupancies, 1, []uint{1})
+	require.NoError(t, err)
+	assert.Equal(t, "A-01", suggested.Code, "location already holding packages of the unit")
+
+	suggested, err = domain.SuggestStorageLocation(occupancies, 5, []uint{2})
+	require.NoError(t, err)
+	assert.Equal(t, "A-01", suggested.Code, "unit location without room is skipped")
+
+	_, err = domain.SuggestStorageLocation(occupancies, 9, nil)
+	assert.ErrorIs(t, err, domain.ErrNoStorageAvailable)
+}
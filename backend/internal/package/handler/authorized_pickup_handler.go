@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"net/http"
+	"portarius/internal/package/domain"
+	residentDomain "portarius/internal/resident/domain"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuthorizedPickupHandler struct {
+	repo         domain.IAuthorizedPickupRepository
+	residentRepo residentDomain.IResidentRepository
+}
+
+func NewAuthorizedPickupHandler(repo domain.IAuthorizedPickupRepository, residentRepo residentDomain.IResidentRepository) *AuthorizedPickupHandler {
+	return &AuthorizedPickupHandler{
+		repo:         repo,
+		residentRepo: residentRepo,
+	}
+}
+
+// GetAll godoc
+// @Summary List authorized pickup people
+// @Description Get paginated list of the people residents authorized to collect their packages
+// @Tags Authorized Pickups
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param pageSize query int false "Items per page" minimum(1) maximum(100) default(10)
+// @Success 200 {array} domain.AuthorizedPickup
+// @Failure 401
+// @Failure 500
+// @Router /authorized-pickups [get]
+func (c *AuthorizedPickupHandler) GetAll(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.Query("page"))
+	pageSize, _ := strconv.Atoi(ctx.Query("pageSize"))
+
+	pickups, err := c.repo.GetAll(page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pickups)
+}
+
+// GetByID godoc
+// @Summary Get an authorized pickup person
+// @Description Get an authorized pickup person by ID
+// @Tags Authorized Pickups
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Authorized pickup ID"
+// @Success 200 {object} domain.AuthorizedPickup
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /authorized-pickups/{id} [get]
+func (c *AuthorizedPickupHandler) GetByID(ctx *gin.Context) {
+	pickup, ok := c.findPickup(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pickup)
+}
+
+// GetByUnit godoc
+// @Summary List the authorized pickup people of a unit
+// @Description Lists the people allowed to collect the packages of a unit. By default only the authorizations valid now are returned, for the porter to choose from at handover.
+// @Tags Authorized Pickups
+// @Produce json
+// @Security BearerAuth
+// @Param block path string true "Block"
+// @Param apartment path string true "Apartment"
+// @Param all query bool false "Include expired and future authorizations"
+// @Success 200 {array} domain.AuthorizedPickup
+// @Failure 401
+// @Failure 500
+// @Router /authorized-pickups/unit/{block}/{apartment} [get]
+func (c *AuthorizedPickupHandler) GetByUnit(ctx *gin.Context) {
+	block := ctx.Param("block")
+	apartment := ctx.Param("apartment")
+
+	var pickups []domain.AuthorizedPickup
+	var err error
+	if ctx.Query("all") == "true" {
+		pickups, err = c.repo.FindByUnit(block, apartment)
+	} else {
+		pickups, err = c.repo.FindActiveByUnit(block, apartment, time.Now())
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pickups)
+}
+
+// Create godoc
+// @Summary Authorize someone to collect packages
+// @Description Registers a person allowed to collect the packages of the unit of the resident who authorizes, with name, document and validity period. Without valid_from the authorization starts now; without valid_until it does not expire.
+// @Tags Authorized Pickups
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param pickup body domain.AuthorizedPickup true "Authorized pickup person"
+// @Success 201 {object} domain.AuthorizedPickup
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /authorized-pickups [post]
+func (c *AuthorizedPickupHandler) Create(ctx *gin.Context) {
+	var pickup domain.AuthorizedPickup
+	if err := ctx.ShouldBindJSON(&pickup); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !c.validate(ctx, &pickup) {
+		return
+	}
+
+	if err := c.repo.Create(&pickup); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, pickup)
+}
+
+// Update godoc
+// @Summary Update an authorized pickup person
+// @Description Updates the data or the validity period of an authorization
+// @Tags Authorized Pickups
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Authorized pickup ID"
+// @Param pickup body domain.AuthorizedPickup true "Authorized pickup person"
+// @Success 200 {object} domain.AuthorizedPickup
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /authorized-pickups/{id} [put]
+func (c *AuthorizedPickupHandler) Update(ctx *gin.Context) {
+	existing, ok := c.findPickup(ctx)
+	if !ok {
+		return
+	}
+
+	var pickup domain.AuthorizedPickup
+	if err := ctx.ShouldBindJSON(&pickup); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pickup.ID = existing.ID
+	pickup.CreatedAt = existing.CreatedAt
+	if pickup.AuthorizedByID == nil {
+		pickup.AuthorizedByID = existing.AuthorizedByID
+	}
+	if pickup.ValidFrom.IsZero() {
+		pickup.ValidFrom = existing.ValidFrom
+	}
+
+	if !c.validate(ctx, &pickup) {
+		return
+	}
+
+	if err := c.repo.Update(&pickup); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pickup)
+}
+
+// Delete godoc
+// @Summary Remove an authorized pickup person
+// @Description Removes an authorization; past deliveries keep the name and document of who collected them
+// @Tags Authorized Pickups
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Authorized pickup ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /authorized-pickups/{id} [delete]
+func (c *AuthorizedPickupHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := c.repo.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Autorização excluída com sucesso"})
+}
+
+// ListRelationships godoc
+// @Summary List pickup relationships
+// @Description Returns the relationships an authorized pickup person may have with the unit
+// @Tags Authorized Pickups
+// @Produce json
+// @Success 200 {array} domain.PickupRelationship "List of relationships"
+// @Router /authorized-pickups/relationships [get]
+func (c *AuthorizedPickupHandler) ListRelationships(ctx *gin.Context) {
+	relationships := []domain.PickupRelationship{
+		domain.PickupRelationshipNeighbor,
+		domain.PickupRelationshipHousekeeper,
+		domain.PickupRelationshipRelative,
+		domain.PickupRelationshipOther,
+	}
+
+	ctx.JSON(http.StatusOK, relationships)
+}
+
+func (c *AuthorizedPickupHandler) validate(ctx *gin.Context, pickup *domain.AuthorizedPickup) bool {
+	if pickup.AuthorizedByID == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "O morador que autoriza é obrigatório"})
+		return false
+	}
+
+	resident, err := c.residentRepo.GetByID(*pickup.AuthorizedByID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Morador não encontrado"})
+		return false
+	}
+
+	if err := pickup.Validate(resident, time.Now()); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	return true
+}
+
+func (c *AuthorizedPickupHandler) findPickup(ctx *gin.Context) (*domain.AuthorizedPickup, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return nil, false
+	}
+
+	pickup, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Autorização não encontrada"})
+		return nil, false
+	}
+
+	return pickup, true
+}
@@ -0,0 +1,235 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	middleware "portarius/internal/middleware/auth"
+	"portarius/internal/package/domain"
+	packageService "portarius/internal/package/service"
+	"portarius/internal/storage"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PackageAttachmentHandler struct {
+	repo        domain.IPackageRepository
+	attachments *packageService.AttachmentService
+}
+
+func NewPackageAttachmentHandler(repo domain.IPackageRepository, attachments *packageService.AttachmentService) *PackageAttachmentHandler {
+	return &PackageAttachmentHandler{repo: repo, attachments: attachments}
+}
+
+// Upload godoc
+// @Summary Attach a photo or signature to a package
+// @Description Uploads a JPEG or PNG of up to 10 MB: the photo taken at intake (FOTO_RECEBIMENTO), or the photo (FOTO_ENTREGA) or signature (ASSINATURA) taken at handover. A thumbnail is generated and the response carries signed download links.
+// @Tags Package
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Param kind formData string true "Attachment kind (FOTO_RECEBIMENTO, FOTO_ENTREGA, ASSINATURA, RECIBO_DEVOLUCAO)"
+// @Param file formData file true "Image"
+// @Success 201 {object} domain.PackageAttachment
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 413
+// @Failure 500
+// @Router /packages/{id}/attachments [post]
+func (c *PackageAttachmentHandler) Upload(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	pkg, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	kind, err := domain.ParseAttachmentKind(ctx.PostForm("kind"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo não enviado"})
+		return
+	}
+	if fileHeader.Size > domain.MaxAttachmentSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": domain.ErrAttachmentTooLarge.Error()})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao abrir arquivo"})
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, domain.MaxAttachmentSize+1))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Erro ao ler arquivo"})
+		return
+	}
+
+	attachment, err := c.attachments.Upload(ctx.Request.Context(), pkg, kind, fileHeader.Filename, content, middleware.GetUserID(ctx))
+	if errors.Is(err, domain.ErrAttachmentTooLarge) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, domain.ErrAttachmentType) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, attachment)
+}
+
+// GetByPackage godoc
+// @Summary List the attachments of a package
+// @Description Lists the photos and signatures of a package with signed links to the files and thumbnails, valid for a few minutes
+// @Tags Package
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Success 200 {array} domain.PackageAttachment
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /packages/{id}/attachments [get]
+func (c *PackageAttachmentHandler) GetByPackage(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if _, err := c.repo.GetByID(id); err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	attachments, err := c.attachments.GetByPackage(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, attachments)
+}
+
+// Delete godoc
+// @Summary Delete a package attachment
+// @Description Removes a photo or signature of a package and its files from the storage
+// @Tags Package
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Param attachmentId path int true "Attachment ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /packages/{id}/attachments/{attachmentId} [delete]
+func (c *PackageAttachmentHandler) Delete(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	attachmentID, err := strconv.ParseUint(ctx.Param("attachmentId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID do anexo inválido"})
+		return
+	}
+
+	attachment, err := c.attachments.GetByID(uint(attachmentID))
+	if err != nil || attachment.PackageID != id {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Anexo não encontrado"})
+		return
+	}
+
+	if err := c.attachments.Delete(ctx.Request.Context(), attachment); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Download godoc
+// @Summary Download a package attachment
+// @Description Returns the file of a photo or signature. It needs no token, only the expires and signature parameters of a link returned by the attachments endpoints.
+// @Tags Package
+// @Produce octet-stream
+// @Param id path int true "Attachment ID"
+// @Param expires query int true "Expiration of the link (Unix time)"
+// @Param signature query string true "Signature of the link"
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Router /package-attachments/{id}/file [get]
+func (c *PackageAttachmentHandler) Download(ctx *gin.Context) {
+	c.serve(ctx, packageService.AttachmentFile)
+}
+
+// DownloadThumbnail godoc
+// @Summary Download the thumbnail of a package attachment
+// @Description Returns a JPEG thumbnail of a photo or signature. It needs no token, only the expires and signature parameters of a link returned by the attachments endpoints.
+// @Tags Package
+// @Produce jpeg
+// @Param id path int true "Attachment ID"
+// @Param expires query int true "Expiration of the link (Unix time)"
+// @Param signature query string true "Signature of the link"
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 403
+// @Failure 404
+// @Router /package-attachments/{id}/thumbnail [get]
+func (c *PackageAttachmentHandler) DownloadThumbnail(ctx *gin.Context) {
+	c.serve(ctx, packageService.AttachmentThumbnail)
+}
+
+func (c *PackageAttachmentHandler) serve(ctx *gin.Context, variant string) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.attachments.VerifyDownload(ctx.Request.URL.Path, ctx.Query("expires"), ctx.Query("signature")); err != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachment, content, contentType, err := c.attachments.Open(ctx.Request.Context(), id, variant)
+	if errors.Is(err, storage.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Anexo não encontrado"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	size := int64(-1)
+	headers := map[string]string{"Cache-Control": "private, max-age=300"}
+	if variant == packageService.AttachmentFile {
+		size = attachment.Size
+		headers["Content-Disposition"] = "inline; filename=" + strconv.Quote(attachment.FileName)
+	}
+	ctx.DataFromReader(http.StatusOK, size, contentType, content, headers)
+}
@@ -1,22 +1,45 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"portarius/internal/eventbus"
+	middleware "portarius/internal/middleware/auth"
 	"portarius/internal/package/domain"
+	packageService "portarius/internal/package/service"
 	reminderDomain "portarius/internal/reminder/domain"
+	residentDomain "portarius/internal/resident/domain"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type PackageHandler struct {
-	repo domain.IPackageRepository
+	repo         domain.IPackageRepository
+	pickupRepo   domain.IAuthorizedPickupRepository
+	residentRepo residentDomain.IResidentRepository
+	storage      *packageService.StorageService
+	intake       *packageService.IntakeService
 }
 
-func NewPackageHandler(repo domain.IPackageRepository) *PackageHandler {
-	return &PackageHandler{repo: repo}
+// MoveStorageRequest represents the location a package is moved to
+// swagger:model
+type MoveStorageRequest struct {
+	StorageLocationID uint `json:"storage_location_id" binding:"required"`
+}
+
+func NewPackageHandler(repo domain.IPackageRepository, pickupRepo domain.IAuthorizedPickupRepository, residentRepo residentDomain.IResidentRepository, storage *packageService.StorageService, intake *packageService.IntakeService) *PackageHandler {
+	return &PackageHandler{
+		repo:         repo,
+		pickupRepo:   pickupRepo,
+		residentRepo: residentRepo,
+		storage:      storage,
+		intake:       intake,
+	}
 }
 
 // GetAll godoc
@@ -78,7 +101,7 @@ func (c *PackageHandler) GetByID(ctx *gin.Context) {
 
 // Create 	godoc
 // @Summary Create a new package item
-// @Description Create a new package item
+// @Description Create a new package item. The tracking code is validated against the format of the carrier, which is detected from the code when not informed. The package starts pending, whatever status and handover fields are sent, and a pickup code is generated and sent to the resident with the arrival notification. Without storage_location_id the package is stored at the suggested free location, if any.
 // @Tags Package
 // @Accept json
 // @Produce json
@@ -87,6 +110,7 @@ func (c *PackageHandler) GetByID(ctx *gin.Context) {
 // @Success 201 {object} domain.Package
 // @Failure 400
 // @Failure 401
+// @Failure 409
 // @Failure 500
 // @Router /package [post]
 func (c *PackageHandler) Create(ctx *gin.Context) {
@@ -96,26 +120,104 @@ func (c *PackageHandler) Create(ctx *gin.Context) {
 		return
 	}
 
+	pkg.StartPending()
+
+	if err := pkg.NormalizeTracking(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !c.checkTrackingCode(ctx, &pkg) {
+		return
+	}
+
+	if err := c.storage.Assign(&pkg); err != nil {
+		c.respondStorageError(ctx, err)
+		return
+	}
+
+	if err := pkg.AssignPickupCode(); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
 	pkg.ReceivedAt = time.Now()
 	if err := c.repo.Create(&pkg); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	if pkg.Status == domain.PackagePending {
+	eventbus.Publish("PackageCreated", &eventbus.PackageCreatedEvent{
+		PackageID: &pkg.ID,
+		Channel:   string(reminderDomain.ReminderChannelWhatsApp),
+	})
 
-		eventbus.Publish("PackageCreated", &eventbus.PackageCreatedEvent{
-			PackageID: &pkg.ID,
-			Channel:   string(reminderDomain.ReminderChannelWhatsApp),
+	ctx.JSON(http.StatusCreated, pkg)
+}
+
+// CreateBatch godoc
+// @Summary Register a batch of packages
+// @Description Registers many packages of the same delivery in a single transaction, such as the daily drop of a carrier van. Every package is validated as in the single intake and nothing is saved when any of them is rejected; the errors point to the index of each rejected package. The packages of a resident share one pickup code and the resident gets a single notification with the count of packages.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param batch body domain.BatchIntakeRequest true "Packages of the delivery"
+// @Success 201 {object} domain.BatchIntakeResult
+// @Failure 400
+// @Failure 401
+// @Failure 422
+// @Failure 500
+// @Router /packages/batch [post]
+func (c *PackageHandler) CreateBatch(ctx *gin.Context) {
+	var request domain.BatchIntakeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	groups, itemErrors, err := c.intake.ReceiveBatch(request.Packages, time.Now())
+	if errors.Is(err, domain.ErrInvalidBatch) {
+		ctx.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error(), "items": itemErrors})
+		return
+	}
+	if errors.Is(err, domain.ErrBatchTooLarge) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	for _, group := range groups {
+		if len(group.Indexes) == 1 {
+			eventbus.Publish("PackageCreated", &eventbus.PackageCreatedEvent{
+				PackageID: &request.Packages[group.Indexes[0]].ID,
+				Channel:   string(reminderDomain.ReminderChannelWhatsApp),
+			})
+			continue
+		}
+
+		packageIDs := make([]uint, 0, len(group.Indexes))
+		for _, index := range group.Indexes {
+			packageIDs = append(packageIDs, request.Packages[index].ID)
+		}
+
+		residentID := group.ResidentID
+		eventbus.Publish("PackagesBatchCreated", &eventbus.PackagesBatchCreatedEvent{
+			ResidentID: &residentID,
+			PackageIDs: packageIDs,
+			Channel:    string(reminderDomain.ReminderChannelWhatsApp),
 		})
 	}
 
-	ctx.JSON(http.StatusCreated, pkg)
+	ctx.JSON(http.StatusCreated, domain.BatchIntakeResult{Packages: request.Packages, Notifications: len(groups)})
 }
 
 // Update 	godoc
 // @Summary Update an package item
-// @Description Update an package item
+// @Description Update an package item. The status, the handover fields and the storage location are kept; packages are delivered through the deliver endpoint and moved through the location endpoint, which checks the capacity of the shelf. A tracking code already registered for another package is rejected.
 // @Tags Package
 // @Accept json
 // @Produce json
@@ -126,6 +228,7 @@ func (c *PackageHandler) Create(ctx *gin.Context) {
 // @Failure 400
 // @Failure 401
 // @Failure 404
+// @Failure 409
 // @Failure 500
 // @Router /package/{id} [put]
 func (c *PackageHandler) Update(ctx *gin.Context) {
@@ -141,7 +244,26 @@ func (c *PackageHandler) Update(ctx *gin.Context) {
 		return
 	}
 
-	pkg.ID = uint(id)
+	if err := pkg.NormalizeTracking(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	pkg.ID = existing.ID
+	if !c.checkTrackingCode(ctx, &pkg) {
+		return
+	}
+
+	pkg.CreatedAt = existing.CreatedAt
+	pkg.StorageLocationID = existing.StorageLocationID
+	pkg.StorageLocation = nil
+	pkg.KeepHandover(existing)
 	if err := c.repo.Update(&pkg); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -179,15 +301,19 @@ func (c *PackageHandler) Delete(ctx *gin.Context) {
 
 // MarkAsDelivered 	godoc
 // @Summary Mark a package as delivered
-// @Description Change the package status
+// @Description Hands the package over after checking the pickup code sent to the resident. Without the code, the porter must give the reason the package was released anyway. After 5 wrong codes only the release with a reason is accepted. The package goes to the resident it is addressed to, to another resident (delivered_to_id) or to someone the unit authorized (authorized_pickup_id); the name and document of who collected it and the user who handed it over are recorded.
 // @Tags Package
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Package ID"
+// @Param request body domain.PickupRequest true "Pickup code or override reason"
 // @Success 200 {object} domain.Package
 // @Failure 400
 // @Failure 401
+// @Failure 403
+// @Failure 404
+// @Failure 409
 // @Failure 500
 // @Router /package/{id}/deliver [put]
 func (c *PackageHandler) MarkAsDelivered(ctx *gin.Context) {
@@ -197,49 +323,91 @@ func (c *PackageHandler) MarkAsDelivered(ctx *gin.Context) {
 		return
 	}
 
+	var request domain.PickupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	pkg, err := c.repo.GetByID(uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
 		return
 	}
 
-	pkg.Status = domain.PackageDelivered
-	pkg.DeliveredAt = time.Now()
-	if err := c.repo.Update(pkg); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	now := time.Now()
+	recipient, ok := c.resolveRecipient(ctx, pkg, request, now)
+	if !ok {
 		return
 	}
-	ctx.JSON(http.StatusOK, pkg)
+
+	userID := middleware.GetUserID(ctx)
+	delivered, err := c.repo.Deliver(pkg.ID, func(locked *domain.Package) error {
+		return locked.Deliver(request, recipient, userID, now)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidPickupCode):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error(), "remaining_attempts": domain.MaxPickupAttempts - delivered.PickupAttempts})
+		case errors.Is(err, domain.ErrPickupAttemptsExceeded):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPackageNotPending):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, domain.ErrPickupCodeRequired), errors.Is(err, domain.ErrOverrideReasonTooShort), errors.Is(err, domain.ErrPickupRecipientRequired):
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, delivered)
 }
 
-// MarkAsLost 	godoc
-// @Summary Mark a package as lost
-// @Description Change the package status
+// MoveStorage 	godoc
+// @Summary Move a package to another storage location
+// @Description Stores the package at another shelf or bin, if it has room for the package
 // @Tags Package
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param id path int true "Package ID"
+// @Param request body MoveStorageRequest true "New storage location"
 // @Success 200 {object} domain.Package
 // @Failure 400
 // @Failure 401
+// @Failure 404
+// @Failure 409
 // @Failure 500
-// @Router /package/{id}/lost [put]
-func (c *PackageHandler) MarkAsLost(ctx *gin.Context) {
+// @Router /packages/{id}/location [put]
+func (c *PackageHandler) MoveStorage(ctx *gin.Context) {
 	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
 		return
 	}
 
+	var request MoveStorageRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	pkg, err := c.repo.GetByID(uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
 		return
 	}
 
-	pkg.Status = domain.PackageLost
-	pkg.DeliveredAt = time.Now()
+	if pkg.Status != domain.PackagePending {
+		ctx.JSON(http.StatusConflict, gin.H{"error": domain.ErrPackageNotPending.Error()})
+		return
+	}
+
+	if err := c.storage.MoveTo(pkg, request.StorageLocationID); err != nil {
+		c.respondStorageError(ctx, err)
+		return
+	}
+
 	if err := c.repo.Update(pkg); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -247,6 +415,201 @@ func (c *PackageHandler) MarkAsLost(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, pkg)
 }
 
+// GetAuthorizedPickups 	godoc
+// @Summary List who may collect a package
+// @Description Lists the people the unit of the package authorized to collect it, valid now, for the porter to choose from at handover
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Success 200 {array} domain.AuthorizedPickup
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /packages/{id}/authorized-pickups [get]
+func (c *PackageHandler) GetAuthorizedPickups(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	pkg, err := c.repo.GetByID(uint(id))
+	if err != nil || pkg.Resident == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	pickups, err := c.pickupRepo.FindActiveByUnit(pkg.Resident.Block, pkg.Resident.Apartment, time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, pickups)
+}
+
+// resolveRecipient finds who is collecting the package: an authorized person of the unit, another
+// resident or, by default, the resident the package is addressed to
+func (c *PackageHandler) resolveRecipient(ctx *gin.Context, pkg *domain.Package, request domain.PickupRequest, now time.Time) (domain.PickupRecipient, bool) {
+	switch {
+	case request.AuthorizedPickupID != nil && request.DeliveredToID != nil:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Informe o morador ou a pessoa autorizada, não ambos"})
+		return domain.PickupRecipient{}, false
+	case request.AuthorizedPickupID != nil:
+		pickup, err := c.pickupRepo.GetByID(*request.AuthorizedPickupID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Pessoa autorizada não encontrada"})
+			return domain.PickupRecipient{}, false
+		}
+		if !pickup.Allows(pkg.Resident, now) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": domain.ErrPickupNotAuthorized.Error()})
+			return domain.PickupRecipient{}, false
+		}
+		return pickup.Recipient(), true
+	case request.DeliveredToID != nil:
+		resident, err := c.residentRepo.GetByID(*request.DeliveredToID)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Morador não encontrado"})
+			return domain.PickupRecipient{}, false
+		}
+		return domain.ResidentRecipient(resident), true
+	default:
+		return domain.ResidentRecipient(pkg.Resident), true
+	}
+}
+
+// Aging godoc
+// @Summary Aging report of pending packages
+// @Description Buckets the packages waiting for pickup by the calendar days since they were received (0-2, 3-6, 7-14, 15-29 and 30 or more), oldest first inside each bucket
+// @Tags Package
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} domain.AgingReport
+// @Failure 401
+// @Failure 500
+// @Router /packages/aging [get]
+func (c *PackageHandler) Aging(ctx *gin.Context) {
+	packages, err := c.repo.GetPending()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.BuildAgingReport(packages, time.Now()))
+}
+
+// Label godoc
+// @Summary Print the label of a package
+// @Description Renders the label stuck to the package at intake, with the unit, resident, package id, storage location and a Code 128 and QR code of the package, scanned again at handover through the lookup endpoint. ZPL is for Zebra thermal printers (100 x 50 mm, 203 dpi) and PDF for regular printers.
+// @Tags Package
+// @Produce application/pdf
+// @Produce plain
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Param format query string false "Label format (PDF, ZPL)" default(PDF)
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /packages/{id}/label [get]
+func (c *PackageHandler) Label(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	pkg, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	c.respondLabels(ctx, []domain.Package{*pkg}, fmt.Sprintf("etiqueta-%d", pkg.ID))
+}
+
+// Labels godoc
+// @Summary Print the labels of many packages
+// @Description Renders the labels of the packages in the order of the ids, such as the packages of a batch intake. The PDF lays them out ten per A4 sheet.
+// @Tags Package
+// @Produce application/pdf
+// @Produce plain
+// @Security BearerAuth
+// @Param ids query string true "Comma separated package IDs"
+// @Param format query string false "Label format (PDF, ZPL)" default(PDF)
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /packages/labels [get]
+func (c *PackageHandler) Labels(ctx *gin.Context) {
+	ids := []uint{}
+	for _, value := range strings.Split(ctx.Query("ids"), ",") {
+		if value = strings.TrimSpace(value); value == "" {
+			continue
+		}
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido: " + value})
+			return
+		}
+		ids = append(ids, uint(id))
+	}
+
+	if len(ids) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Informe os IDs das encomendas"})
+		return
+	}
+	if len(ids) > domain.MaxLabelsPerRequest {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Máximo de %d etiquetas por impressão", domain.MaxLabelsPerRequest)})
+		return
+	}
+
+	packages, err := c.repo.GetByIDs(ids)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if len(packages) == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Nenhuma encomenda encontrada"})
+		return
+	}
+
+	c.respondLabels(ctx, packages, "etiquetas")
+}
+
+func (c *PackageHandler) respondLabels(ctx *gin.Context, packages []domain.Package, fileName string) {
+	format, err := domain.ParseLabelFormat(ctx.Query("format"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	labels := make([]domain.PackageLabel, 0, len(packages))
+	for i := range packages {
+		labels = append(labels, domain.NewPackageLabel(&packages[i]))
+	}
+
+	var content []byte
+	if format == domain.LabelFormatZPL {
+		content = []byte(domain.RenderZPL(labels))
+	} else {
+		content, err = domain.RenderLabelsPDF(labels)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf("inline; filename=%s.%s", fileName, format.Extension()))
+	ctx.Data(http.StatusOK, format.ContentType(), content)
+}
+
 // ListPackageStatus 	godoc
 // @Summary List package status
 // @Description List avaliable package status
@@ -264,7 +627,113 @@ func (c *PackageHandler) ListPackageStatus(ctx *gin.Context) {
 		domain.PackagePending,
 		domain.PackageDelivered,
 		domain.PackageLost,
+		domain.PackageUnderInvestigation,
+		domain.PackageReturned,
+		domain.PackageRefused,
+		domain.PackageAwaitingReturn,
 	}
 
 	ctx.JSON(http.StatusOK, status)
 }
+
+// Lookup 	godoc
+// @Summary Look up a scanned barcode
+// @Description Finds the package of a scanned barcode: the label printed at intake (PKG followed by the package id) or the carrier tracking code. When there is none, returns a new package prefilled with the code, the carrier detected from its format and, for Amazon and Mercado Livre, the sender, ready to be completed and created.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param barcode query string true "Scanned barcode"
+// @Success 200 {object} domain.PackageLookup
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /packages/lookup [get]
+func (c *PackageHandler) Lookup(ctx *gin.Context) {
+	barcode := ctx.Query("barcode")
+	if barcode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Código de barras é obrigatório"})
+		return
+	}
+
+	if id, ok := domain.ParseLabelCode(barcode); ok {
+		pkg, err := c.repo.GetByID(id)
+		if err != nil {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda da etiqueta não encontrada"})
+			return
+		}
+		ctx.JSON(http.StatusOK, domain.PackageLookup{Found: true, Package: pkg})
+		return
+	}
+
+	pkg, err := c.repo.FindByTrackingCode(domain.NormalizeTrackingCode(barcode))
+	if err == nil {
+		ctx.JSON(http.StatusOK, domain.PackageLookup{Found: true, Package: pkg})
+		return
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	prefilled, err := domain.PrefillPackage(barcode)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.PackageLookup{Found: false, Package: prefilled})
+}
+
+// ListCarriers 	godoc
+// @Summary List package carriers
+// @Description List the carriers whose tracking codes are recognized
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.Carrier
+// @Failure 401
+// @Router /packages/carriers [get]
+func (c *PackageHandler) ListCarriers(ctx *gin.Context) {
+	carriers := []domain.Carrier{
+		domain.CarrierCorreios,
+		domain.CarrierJadlog,
+		domain.CarrierMercadoLivre,
+		domain.CarrierAmazon,
+		domain.CarrierOther,
+	}
+
+	ctx.JSON(http.StatusOK, carriers)
+}
+
+// checkTrackingCode answers 409 when another package was already registered with the tracking code of pkg
+func (c *PackageHandler) checkTrackingCode(ctx *gin.Context, pkg *domain.Package) bool {
+	if pkg.TrackingCode == "" {
+		return true
+	}
+
+	existing, err := c.repo.FindByTrackingCode(pkg.TrackingCode)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return true
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	if existing.ID == pkg.ID {
+		return true
+	}
+
+	ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Encomenda com o código %s já registrada (ID %d)", pkg.TrackingCode, existing.ID)})
+	return false
+}
+
+func (c *PackageHandler) respondStorageError(ctx *gin.Context, err error) {
+	if errors.Is(err, domain.ErrStorageLocationFull) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
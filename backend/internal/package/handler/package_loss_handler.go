@@ -0,0 +1,402 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	middleware "portarius/internal/middleware/auth"
+	"portarius/internal/package/domain"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultLossStatsDays is the period of the loss statistics when no dates are given
+const defaultLossStatsDays = 90
+
+type PackageLossHandler struct {
+	repo        domain.IPackageLossRepository
+	packageRepo domain.IPackageRepository
+}
+
+func NewPackageLossHandler(repo domain.IPackageLossRepository, packageRepo domain.IPackageRepository) *PackageLossHandler {
+	return &PackageLossHandler{repo: repo, packageRepo: packageRepo}
+}
+
+// Report godoc
+// @Summary Report a package as lost
+// @Description Opens the investigation of a pending package missing from the mailroom, or of a delivered package the resident says was never received. The package stays under investigation (EM_INVESTIGACAO), and cannot be delivered, until the report is resolved.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Param report body domain.LossReportRequest false "What is known about the loss"
+// @Success 201 {object} domain.PackageLossReport
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /packages/{id}/lost [put]
+func (c *PackageLossHandler) Report(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request domain.LossReportRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	pkg, err := c.packageRepo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	if _, err := c.repo.FindOpenByPackage(pkg.ID); err == nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": domain.ErrLossAlreadyReported.Error()})
+		return
+	}
+
+	report, err := domain.ReportLoss(pkg, request.Notes, middleware.GetUserID(ctx), time.Now())
+	if err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.repo.Save(report, pkg); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	report.Package = pkg
+	ctx.JSON(http.StatusCreated, report)
+}
+
+// GetByPackage godoc
+// @Summary List the loss reports of a package
+// @Description Lists the loss investigations of a package with their notes, newest first
+// @Tags Package
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Success 200 {array} domain.PackageLossReport
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /packages/{id}/loss-reports [get]
+func (c *PackageLossHandler) GetByPackage(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	reports, err := c.repo.FindByPackage(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// GetAll godoc
+// @Summary List loss reports
+// @Description Lists the loss investigations, newest first, optionally filtered by status
+// @Tags Loss Reports
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Report status (ABERTA, EM_INVESTIGACAO, RESOLVIDA)"
+// @Success 200 {array} domain.PackageLossReport
+// @Failure 401
+// @Failure 500
+// @Router /loss-reports [get]
+func (c *PackageLossHandler) GetAll(ctx *gin.Context) {
+	status := domain.LossReportStatus(strings.ToUpper(ctx.Query("status")))
+
+	reports, err := c.repo.GetAll(status)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, reports)
+}
+
+// GetByID godoc
+// @Summary Get a loss report
+// @Description Returns a loss investigation with the package and the notes of every step
+// @Tags Loss Reports
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Loss report ID"
+// @Success 200 {object} domain.PackageLossReport
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /loss-reports/{id} [get]
+func (c *PackageLossHandler) GetByID(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	report, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Ocorrência de extravio não encontrada"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// Investigate godoc
+// @Summary Start investigating a loss
+// @Description Moves the report to EM_INVESTIGACAO, recording what is being done to find the package
+// @Tags Loss Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Loss report ID"
+// @Param notes body domain.LossReportRequest true "Investigation notes"
+// @Success 200 {object} domain.PackageLossReport
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /loss-reports/{id}/investigate [put]
+func (c *PackageLossHandler) Investigate(ctx *gin.Context) {
+	report, request, ok := c.loadForUpdate(ctx)
+	if !ok {
+		return
+	}
+
+	if err := report.Investigate(request.Notes, middleware.GetUserID(ctx)); err != nil {
+		c.respondLossError(ctx, err)
+		return
+	}
+
+	c.save(ctx, report)
+}
+
+// AddNote godoc
+// @Summary Add a note to a loss report
+// @Description Appends an entry to the investigation trail of an unresolved report
+// @Tags Loss Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Loss report ID"
+// @Param notes body domain.LossReportRequest true "Note"
+// @Success 200 {object} domain.PackageLossReport
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /loss-reports/{id}/notes [post]
+func (c *PackageLossHandler) AddNote(ctx *gin.Context) {
+	report, request, ok := c.loadForUpdate(ctx)
+	if !ok {
+		return
+	}
+
+	if report.Status == domain.LossReportResolved {
+		c.respondLossError(ctx, domain.ErrLossReportResolved)
+		return
+	}
+	if strings.TrimSpace(request.Notes) == "" {
+		c.respondLossError(ctx, domain.ErrLossNotesRequired)
+		return
+	}
+
+	report.AddNote("", request.Notes, middleware.GetUserID(ctx))
+	c.save(ctx, report)
+}
+
+// Resolve godoc
+// @Summary Resolve a loss report
+// @Description Closes the investigation. ENCONTRADA puts the package back in the status it had when reported; EXTRAVIADA marks it lost and DEVOLVIDA_REMETENTE returned to the sender, both requiring notes.
+// @Tags Loss Reports
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Loss report ID"
+// @Param resolution body domain.LossResolutionRequest true "Outcome of the investigation"
+// @Success 200 {object} domain.PackageLossReport
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /loss-reports/{id}/resolve [put]
+func (c *PackageLossHandler) Resolve(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request domain.LossResolutionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resolution, err := domain.ParseLossResolution(string(request.Resolution))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Ocorrência de extravio não encontrada"})
+		return
+	}
+
+	pkg := report.Package
+	if pkg == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	if err := report.Resolve(pkg, resolution, request.Notes, middleware.GetUserID(ctx), time.Now()); err != nil {
+		c.respondLossError(ctx, err)
+		return
+	}
+
+	c.save(ctx, report)
+}
+
+// Stats godoc
+// @Summary Loss statistics by carrier
+// @Description Breaks down the loss reports of the packages received in the period by carrier, with the loss rate against the packages each carrier delivered. Without dates the last 90 days are used.
+// @Tags Loss Reports
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date in format yyyy-MM-dd"
+// @Param end_date query string false "End date in format yyyy-MM-dd"
+// @Success 200 {object} domain.LossStats
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /loss-reports/stats [get]
+func (c *PackageLossHandler) Stats(ctx *gin.Context) {
+	start, end, ok := parsePeriod(ctx, defaultLossStatsDays)
+	if !ok {
+		return
+	}
+
+	received, err := c.repo.CountReceivedByCarrier(start, end.AddDate(0, 0, 1))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	reports, err := c.repo.FindByPackagesReceived(start, end.AddDate(0, 0, 1))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, domain.LossStats{
+		From:     start.Format("2006-01-02"),
+		To:       end.Format("2006-01-02"),
+		Carriers: domain.BuildLossStats(received, reports),
+	})
+}
+
+// ListResolutions godoc
+// @Summary List loss resolutions
+// @Description Returns the possible outcomes of a loss investigation
+// @Tags Loss Reports
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.LossResolution
+// @Router /loss-reports/resolutions [get]
+func (c *PackageLossHandler) ListResolutions(ctx *gin.Context) {
+	resolutions := []domain.LossResolution{
+		domain.LossResolutionFound,
+		domain.LossResolutionLost,
+		domain.LossResolutionReturned,
+	}
+
+	ctx.JSON(http.StatusOK, resolutions)
+}
+
+// parsePeriod reads the start_date and end_date of a report, both inclusive, defaulting to the last
+// days before today
+func parsePeriod(ctx *gin.Context, defaultDays int) (time.Time, time.Time, bool) {
+	now := time.Now()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	start := end.AddDate(0, 0, -defaultDays)
+
+	if value := ctx.Query("start_date"); value != "" {
+		date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data inicial inválida"})
+			return start, end, false
+		}
+		start = date
+	}
+	if value := ctx.Query("end_date"); value != "" {
+		date, err := time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data final inválida"})
+			return start, end, false
+		}
+		end = date
+	}
+	if end.Before(start) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A data final deve ser posterior à inicial"})
+		return start, end, false
+	}
+
+	return start, end, true
+}
+
+func (c *PackageLossHandler) loadForUpdate(ctx *gin.Context) (*domain.PackageLossReport, domain.LossReportRequest, bool) {
+	var request domain.LossReportRequest
+
+	id, ok := parseID(ctx)
+	if !ok {
+		return nil, request, false
+	}
+
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, request, false
+	}
+
+	report, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Ocorrência de extravio não encontrada"})
+		return nil, request, false
+	}
+
+	return report, request, true
+}
+
+func (c *PackageLossHandler) save(ctx *gin.Context, report *domain.PackageLossReport) {
+	if err := c.repo.Save(report, report.Package); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func (c *PackageLossHandler) respondLossError(ctx *gin.Context, err error) {
+	if errors.Is(err, domain.ErrLossReportResolved) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,327 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"portarius/internal/eventbus"
+	middleware "portarius/internal/middleware/auth"
+	"portarius/internal/package/domain"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultReturnStatsDays is the period of the return statistics when no dates are given
+const defaultReturnStatsDays = 90
+
+type PackageReturnHandler struct {
+	repo        domain.IPackageReturnRepository
+	packageRepo domain.IPackageRepository
+}
+
+func NewPackageReturnHandler(repo domain.IPackageReturnRepository, packageRepo domain.IPackageRepository) *PackageReturnHandler {
+	return &PackageReturnHandler{repo: repo, packageRepo: packageRepo}
+}
+
+// Register godoc
+// @Summary Return a package to the sender
+// @Description Takes a pending package out of the pickup queue because the resident refused it (RECUSADA) or it cannot be delivered, such as a resident who moved out (AGUARDANDO_DEVOLUCAO). The package stays on its shelf until the carrier collects it, and its notification reminders are cancelled.
+// @Tags Package
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Param return body domain.ReturnRequest true "Reason of the return"
+// @Success 201 {object} domain.PackageReturn
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /packages/{id}/return [put]
+func (c *PackageReturnHandler) Register(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request domain.ReturnRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reason, err := domain.ParseReturnReason(string(request.Reason))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pkg, err := c.packageRepo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return
+	}
+
+	packageReturn, err := domain.ReturnPackage(pkg, reason, request.Notes, middleware.GetUserID(ctx), time.Now())
+	if err != nil {
+		c.respondReturnError(ctx, err)
+		return
+	}
+
+	if err := c.repo.Save(packageReturn, pkg); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	eventbus.Publish("PackageReturnRegistered", &eventbus.PackageReturnRegisteredEvent{
+		PackageID: &pkg.ID,
+	})
+
+	packageReturn.Package = pkg
+	ctx.JSON(http.StatusCreated, packageReturn)
+}
+
+// GetByPackage godoc
+// @Summary List the returns of a package
+// @Description Lists the returns registered for a package, including the cancelled ones, newest first
+// @Tags Package
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Package ID"
+// @Success 200 {array} domain.PackageReturn
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /packages/{id}/returns [get]
+func (c *PackageReturnHandler) GetByPackage(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	returns, err := c.repo.FindByPackage(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, returns)
+}
+
+// GetAll godoc
+// @Summary List package returns
+// @Description Lists the packages returned or to be returned to the sender, the ones waiting longest for the carrier first, optionally filtered by status
+// @Tags Package Returns
+// @Produce json
+// @Security BearerAuth
+// @Param status query string false "Return status (AGUARDANDO_COLETA, DEVOLVIDA, CANCELADA)"
+// @Success 200 {array} domain.PackageReturn
+// @Failure 401
+// @Failure 500
+// @Router /package-returns [get]
+func (c *PackageReturnHandler) GetAll(ctx *gin.Context) {
+	status := domain.ReturnStatus(strings.ToUpper(ctx.Query("status")))
+
+	returns, err := c.repo.GetAll(status)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, returns)
+}
+
+// GetByID godoc
+// @Summary Get a package return
+// @Description Returns a return to the sender with its package and, once collected, the carrier pickup and proof
+// @Tags Package Returns
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Success 200 {object} domain.PackageReturn
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /package-returns/{id} [get]
+func (c *PackageReturnHandler) GetByID(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	packageReturn, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Devolução não encontrada"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, packageReturn)
+}
+
+// CarrierPickup godoc
+// @Summary Confirm the carrier collected a returned package
+// @Description Records when and by whom the carrier collected the package, with the code of the return receipt as proof; a photo of the receipt can be uploaded as a RECIBO_DEVOLUCAO attachment. The package becomes DEVOLVIDA and leaves its shelf.
+// @Tags Package Returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Param pickup body domain.CarrierPickupRequest true "Carrier pickup"
+// @Success 200 {object} domain.PackageReturn
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /package-returns/{id}/carrier-pickup [put]
+func (c *PackageReturnHandler) CarrierPickup(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request domain.CarrierPickupRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	packageReturn, pkg, ok := c.load(ctx, id)
+	if !ok {
+		return
+	}
+
+	if err := packageReturn.ConfirmCarrierPickup(pkg, request, middleware.GetUserID(ctx), time.Now()); err != nil {
+		c.respondReturnError(ctx, err)
+		return
+	}
+
+	c.save(ctx, packageReturn, pkg)
+}
+
+// Cancel godoc
+// @Summary Cancel a package return
+// @Description Puts the package back in the pickup queue when the resident changes their mind before the carrier collects it
+// @Tags Package Returns
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Return ID"
+// @Param cancel body domain.ReturnCancelRequest false "Why the return was cancelled"
+// @Success 200 {object} domain.PackageReturn
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /package-returns/{id}/cancel [put]
+func (c *PackageReturnHandler) Cancel(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	var request domain.ReturnCancelRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	packageReturn, pkg, ok := c.load(ctx, id)
+	if !ok {
+		return
+	}
+
+	if err := packageReturn.Cancel(pkg, request.Notes); err != nil {
+		c.respondReturnError(ctx, err)
+		return
+	}
+
+	c.save(ctx, packageReturn, pkg)
+}
+
+// Stats godoc
+// @Summary Package return statistics
+// @Description Counts the returns registered in the period by status, reason and carrier, with the average days the carriers took to collect them. Without dates the last 90 days are used.
+// @Tags Package Returns
+// @Produce json
+// @Security BearerAuth
+// @Param start_date query string false "Start date in format yyyy-MM-dd"
+// @Param end_date query string false "End date in format yyyy-MM-dd"
+// @Success 200 {object} domain.ReturnStats
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /package-returns/stats [get]
+func (c *PackageReturnHandler) Stats(ctx *gin.Context) {
+	start, end, ok := parsePeriod(ctx, defaultReturnStatsDays)
+	if !ok {
+		return
+	}
+
+	returns, err := c.repo.FindRegistered(start, end.AddDate(0, 0, 1))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	stats := domain.BuildReturnStats(returns, time.Now())
+	stats.From = start.Format("2006-01-02")
+	stats.To = end.Format("2006-01-02")
+	ctx.JSON(http.StatusOK, stats)
+}
+
+// ListReasons godoc
+// @Summary List return reasons
+// @Description Returns the reasons a package can be returned to the sender
+// @Tags Package Returns
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.ReturnReason
+// @Router /package-returns/reasons [get]
+func (c *PackageReturnHandler) ListReasons(ctx *gin.Context) {
+	reasons := []domain.ReturnReason{
+		domain.ReturnRefused,
+		domain.ReturnMovedOut,
+		domain.ReturnUnknownRecipient,
+		domain.ReturnOther,
+	}
+
+	ctx.JSON(http.StatusOK, reasons)
+}
+
+func (c *PackageReturnHandler) load(ctx *gin.Context, id uint) (*domain.PackageReturn, *domain.Package, bool) {
+	packageReturn, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Devolução não encontrada"})
+		return nil, nil, false
+	}
+
+	if packageReturn.Package == nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Encomenda não encontrada"})
+		return nil, nil, false
+	}
+
+	return packageReturn, packageReturn.Package, true
+}
+
+func (c *PackageReturnHandler) save(ctx *gin.Context, packageReturn *domain.PackageReturn, pkg *domain.Package) {
+	if err := c.repo.Save(packageReturn, pkg); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, packageReturn)
+}
+
+func (c *PackageReturnHandler) respondReturnError(ctx *gin.Context, err error) {
+	if errors.Is(err, domain.ErrReturnNotPending) || errors.Is(err, domain.ErrReturnNotAwaiting) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"portarius/internal/package/domain"
+	packageService "portarius/internal/package/service"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StorageLocationHandler struct {
+	repo    domain.IStorageLocationRepository
+	storage *packageService.StorageService
+}
+
+func NewStorageLocationHandler(repo domain.IStorageLocationRepository, storage *packageService.StorageService) *StorageLocationHandler {
+	return &StorageLocationHandler{repo: repo, storage: storage}
+}
+
+// GetAll godoc
+// @Summary List storage locations
+// @Description Lists the shelves and bins of the mailroom ordered by code
+// @Tags Storage Locations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.StorageLocation
+// @Failure 401
+// @Failure 500
+// @Router /storage-locations [get]
+func (c *StorageLocationHandler) GetAll(ctx *gin.Context) {
+	locations, err := c.repo.GetAll()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, locations)
+}
+
+// GetByID godoc
+// @Summary Get a storage location
+// @Description Returns a storage location with its occupancy
+// @Tags Storage Locations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Storage location ID"
+// @Success 200 {object} domain.StorageOccupancy
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /storage-locations/{id} [get]
+func (c *StorageLocationHandler) GetByID(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	occupancy, err := c.repo.GetLocationOccupancy(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Local de armazenamento não encontrado"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, occupancy)
+}
+
+// GetPackages godoc
+// @Summary List the packages of a storage location
+// @Description Lists the packages stored at a shelf or bin, oldest first: the pending ones and the refused ones waiting for the carrier
+// @Tags Storage Locations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Storage location ID"
+// @Success 200 {array} domain.Package
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /storage-locations/{id}/packages [get]
+func (c *StorageLocationHandler) GetPackages(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	packages, err := c.repo.GetPendingPackages(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, packages)
+}
+
+// GetOccupancy godoc
+// @Summary Show how full each storage location is
+// @Description Returns every location with the volumes of pending packages stored there, the free space and the percentage used
+// @Tags Storage Locations
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.StorageOccupancy
+// @Failure 401
+// @Failure 500
+// @Router /storage-locations/occupancy [get]
+func (c *StorageLocationHandler) GetOccupancy(ctx *gin.Context) {
+	occupancies, err := c.repo.GetOccupancy()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, occupancies)
+}
+
+// Suggest godoc
+// @Summary Suggest where to store a package
+// @Description Suggests a free location for a package being received, preferring the location that already holds packages of the same unit
+// @Tags Storage Locations
+// @Produce json
+// @Security BearerAuth
+// @Param residentId query int false "Resident the package is addressed to"
+// @Param quantity query int false "Number of volumes" default(1)
+// @Success 200 {object} domain.StorageLocation
+// @Failure 400
+// @Failure 401
+// @Failure 409
+// @Router /storage-locations/suggest [get]
+func (c *StorageLocationHandler) Suggest(ctx *gin.Context) {
+	var residentID *uint
+	if value := ctx.Query("residentId"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID do morador inválido"})
+			return
+		}
+		parsed := uint(id)
+		residentID = &parsed
+	}
+
+	quantity, _ := strconv.Atoi(ctx.Query("quantity"))
+
+	location, err := c.storage.Suggest(residentID, quantity)
+	if errors.Is(err, domain.ErrNoStorageAvailable) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, location)
+}
+
+// Create godoc
+// @Summary Create a storage location
+// @Description Registers a shelf or bin with its code and capacity in volumes
+// @Tags Storage Locations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param location body domain.StorageLocation true "Storage location"
+// @Success 201 {object} domain.StorageLocation
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /storage-locations [post]
+func (c *StorageLocationHandler) Create(ctx *gin.Context) {
+	location := domain.StorageLocation{Active: true}
+	if err := ctx.ShouldBindJSON(&location); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := location.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.repo.Create(&location); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, location)
+}
+
+// Update godoc
+// @Summary Update a storage location
+// @Description Updates the code, capacity or status of a location. Inactive locations are not suggested nor accept new packages.
+// @Tags Storage Locations
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Storage location ID"
+// @Param location body domain.StorageLocation true "Storage location"
+// @Success 200 {object} domain.StorageLocation
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /storage-locations/{id} [put]
+func (c *StorageLocationHandler) Update(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	existing, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Local de armazenamento não encontrado"})
+		return
+	}
+
+	location := *existing
+	if err := ctx.ShouldBindJSON(&location); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	location.ID = existing.ID
+
+	if err := location.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.repo.Update(&location); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, location)
+}
+
+// Delete godoc
+// @Summary Delete a storage location
+// @Description Deletes a storage location that holds no pending packages
+// @Tags Storage Locations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Storage location ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /storage-locations/{id} [delete]
+func (c *StorageLocationHandler) Delete(ctx *gin.Context) {
+	id, ok := parseID(ctx)
+	if !ok {
+		return
+	}
+
+	occupancy, err := c.repo.GetLocationOccupancy(id)
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Local de armazenamento não encontrado"})
+		return
+	}
+
+	if occupancy.Used > 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "O local ainda guarda encomendas pendentes"})
+		return
+	}
+
+	if err := c.repo.Delete(id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Local de armazenamento excluído com sucesso"})
+}
+
+func parseID(ctx *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"portarius/internal/infra"
+	"portarius/internal/package/domain"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type authorizedPickupRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorizedPickupRepository(db *gorm.DB) domain.IAuthorizedPickupRepository {
+	return &authorizedPickupRepository{db: db}
+}
+
+func (r *authorizedPickupRepository) GetAll(page, pageSize int) ([]domain.AuthorizedPickup, error) {
+	var pickups []domain.AuthorizedPickup
+	err := r.db.Preload("AuthorizedBy").Scopes(infra.Paginate(page, pageSize)).Find(&pickups).Error
+	return pickups, err
+}
+
+func (r *authorizedPickupRepository) GetByID(id uint) (*domain.AuthorizedPickup, error) {
+	var pickup domain.AuthorizedPickup
+	err := r.db.Preload("AuthorizedBy").First(&pickup, id).Error
+	return &pickup, err
+}
+
+func (r *authorizedPickupRepository) FindByUnit(block, apartment string) ([]domain.AuthorizedPickup, error) {
+	var pickups []domain.AuthorizedPickup
+	err := r.db.Where("block = ? AND apartment = ?", strings.ToUpper(block), apartment).
+		Order("name").
+		Find(&pickups).Error
+	return pickups, err
+}
+
+// FindActiveByUnit returns the people allowed to collect the packages of the unit at the given time
+func (r *authorizedPickupRepository) FindActiveByUnit(block, apartment string, now time.Time) ([]domain.AuthorizedPickup, error) {
+	var pickups []domain.AuthorizedPickup
+	err := r.db.Where("block = ? AND apartment = ?", strings.ToUpper(block), apartment).
+		Where("valid_from <= ? AND (valid_until IS NULL OR valid_until > ?)", now, now).
+		Order("name").
+		Find(&pickups).Error
+	return pickups, err
+}
+
+func (r *authorizedPickupRepository) Create(pickup *domain.AuthorizedPickup) error {
+	return r.db.Create(pickup).Error
+}
+
+func (r *authorizedPickupRepository) Update(pickup *domain.AuthorizedPickup) error {
+	return r.db.Save(pickup).Error
+}
+
+func (r *authorizedPickupRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.AuthorizedPickup{}, id).Error
+}
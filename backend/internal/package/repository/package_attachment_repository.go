@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"portarius/internal/package/domain"
+
+	"gorm.io/gorm"
+)
+
+type packageAttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewPackageAttachmentRepository(db *gorm.DB) domain.IPackageAttachmentRepository {
+	return &packageAttachmentRepository{db: db}
+}
+
+func (r *packageAttachmentRepository) GetByPackage(packageID uint) ([]domain.PackageAttachment, error) {
+	var attachments []domain.PackageAttachment
+	err := r.db.Where("package_id = ?", packageID).Order("created_at").Find(&attachments).Error
+	return attachments, err
+}
+
+func (r *packageAttachmentRepository) GetByID(id uint) (*domain.PackageAttachment, error) {
+	var attachment domain.PackageAttachment
+	err := r.db.First(&attachment, id).Error
+	return &attachment, err
+}
+
+func (r *packageAttachmentRepository) Create(attachment *domain.PackageAttachment) error {
+	return r.db.Create(attachment).Error
+}
+
+func (r *packageAttachmentRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.PackageAttachment{}, id).Error
+}
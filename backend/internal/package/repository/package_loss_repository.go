@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"portarius/internal/package/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type packageLossRepository struct {
+	db *gorm.DB
+}
+
+type carrierCount struct {
+	Carrier domain.Carrier
+	Count   int
+}
+
+func NewPackageLossRepository(db *gorm.DB) domain.IPackageLossRepository {
+	return &packageLossRepository{db: db}
+}
+
+func (r *packageLossRepository) GetAll(status domain.LossReportStatus) ([]domain.PackageLossReport, error) {
+	var reports []domain.PackageLossReport
+	query := r.db.Preload("Package.Resident").Order("reported_at DESC")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&reports).Error
+	return reports, err
+}
+
+func (r *packageLossRepository) GetByID(id uint) (*domain.PackageLossReport, error) {
+	var report domain.PackageLossReport
+	err := r.db.Preload("Package.Resident").Preload("Notes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at")
+	}).First(&report, id).Error
+	return &report, err
+}
+
+func (r *packageLossRepository) FindByPackage(packageID uint) ([]domain.PackageLossReport, error) {
+	var reports []domain.PackageLossReport
+	err := r.db.Preload("Notes", func(db *gorm.DB) *gorm.DB {
+		return db.Order("created_at")
+	}).Where("package_id = ?", packageID).Order("reported_at DESC").Find(&reports).Error
+	return reports, err
+}
+
+func (r *packageLossRepository) FindOpenByPackage(packageID uint) (*domain.PackageLossReport, error) {
+	var report domain.PackageLossReport
+	err := r.db.Where("package_id = ? AND status <> ?", packageID, domain.LossReportResolved).First(&report).Error
+	return &report, err
+}
+
+// Save stores the report with its new notes and the status it left the package in, together; a
+// report whose package was deleted only keeps its notes
+func (r *packageLossRepository) Save(report *domain.PackageLossReport, pkg *domain.Package) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Package").Save(report).Error; err != nil {
+			return err
+		}
+		if pkg == nil {
+			return nil
+		}
+		return tx.Model(&domain.Package{}).Where("id = ?", pkg.ID).Update("status", pkg.Status).Error
+	})
+}
+
+// CountReceivedByCarrier counts the packages received in the period per carrier
+func (r *packageLossRepository) CountReceivedByCarrier(from, to time.Time) (map[domain.Carrier]int, error) {
+	var rows []carrierCount
+	err := r.db.Model(&domain.Package{}).
+		Select("carrier, COUNT(*) AS count").
+		Where("received_at >= ? AND received_at < ?", from, to).
+		Group("carrier").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[domain.Carrier]int, len(rows))
+	for _, row := range rows {
+		counts[row.Carrier] += row.Count
+	}
+	return counts, nil
+}
+
+// FindByPackagesReceived returns the loss reports of the packages received in the period
+func (r *packageLossRepository) FindByPackagesReceived(from, to time.Time) ([]domain.PackageLossReport, error) {
+	var reports []domain.PackageLossReport
+	err := r.db.Preload("Package").
+		Joins("JOIN packages ON packages.id = package_loss_reports.package_id").
+		Where("packages.received_at >= ? AND packages.received_at < ?", from, to).
+		Find(&reports).Error
+	return reports, err
+}
@@ -1,10 +1,12 @@
 package repository
 
 import (
+	"errors"
 	"portarius/internal/infra"
 	"portarius/internal/package/domain"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type packageRepository struct {
@@ -17,20 +19,48 @@ func NewPackageRepository(db *gorm.DB) domain.IPackageRepository {
 
 func (r *packageRepository) GetAll(page, pageSize int) ([]domain.Package, error) {
 	var packages []domain.Package
-	err := r.db.Scopes(infra.Paginate(page, pageSize)).Find(&packages).Error
+	err := r.db.Preload("StorageLocation").Scopes(infra.Paginate(page, pageSize)).Find(&packages).Error
 	return packages, err
 }
 
 func (r *packageRepository) GetByID(id uint) (*domain.Package, error) {
 	var pkg domain.Package
-	err := r.db.Preload("Resident").First(&pkg, id).Error
+	err := r.db.Preload("Resident").Preload("StorageLocation").First(&pkg, id).Error
 	return &pkg, err
 }
 
+// GetByIDs returns the packages in the order of the ids; ids not found are skipped
+func (r *packageRepository) GetByIDs(ids []uint) ([]domain.Package, error) {
+	var found []domain.Package
+	if err := r.db.Preload("Resident").Preload("StorageLocation").Where("id IN ?", ids).Find(&found).Error; err != nil {
+		return nil, err
+	}
+
+	byID := make(map[uint]domain.Package, len(found))
+	for _, pkg := range found {
+		byID[pkg.ID] = pkg
+	}
+
+	packages := make([]domain.Package, 0, len(found))
+	for _, id := range ids {
+		if pkg, ok := byID[id]; ok {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, nil
+}
+
 func (r *packageRepository) Create(pkg *domain.Package) error {
 	return r.db.Create(pkg).Error
 }
 
+// CreateBatch saves all the packages in one transaction, so a failed batch leaves nothing behind
+func (r *packageRepository) CreateBatch(packages []domain.Package) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(&packages, 100).Error
+	})
+}
+
 func (r *packageRepository) Update(pkg *domain.Package) error {
 	return r.db.Save(pkg).Error
 }
@@ -39,20 +69,59 @@ func (r *packageRepository) Delete(id uint) error {
 	return r.db.Delete(&domain.Package{}, id).Error
 }
 
-func (r *packageRepository) MarkAsDelivered(id uint) error {
-	pkg, err := r.GetByID(id)
+// Deliver locks the package while deliver checks the handover, so concurrent pickup attempts are counted
+// one after the other. The package is saved when deliver succeeds and when it counts a wrong pickup code;
+// the error of deliver is returned after the transaction is committed.
+func (r *packageRepository) Deliver(id uint, deliver func(pkg *domain.Package) error) (*domain.Package, error) {
+	var pkg *domain.Package
+	var deliverErr error
+
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&domain.Package{}, id).Error; err != nil {
+			return err
+		}
+
+		repo := &packageRepository{db: tx}
+		locked, err := repo.GetByID(id)
+		if err != nil {
+			return err
+		}
+		pkg = locked
+
+		deliverErr = deliver(pkg)
+		if deliverErr != nil && !errors.Is(deliverErr, domain.ErrInvalidPickupCode) {
+			return nil
+		}
+		return repo.Update(pkg)
+	})
 	if err != nil {
-		return err
+		return nil, err
 	}
-	pkg.Status = domain.PackageDelivered
-	return r.Update(pkg)
+	return pkg, deliverErr
 }
 
-func (r *packageRepository) MarkAsLost(id uint) error {
+func (r *packageRepository) MarkAsDelivered(id uint) error {
 	pkg, err := r.GetByID(id)
 	if err != nil {
 		return err
 	}
-	pkg.Status = domain.PackageLost
+	pkg.Status = domain.PackageDelivered
 	return r.Update(pkg)
 }
+
+// FindByTrackingCode returns the most recent package with the tracking code
+func (r *packageRepository) FindByTrackingCode(code string) (*domain.Package, error) {
+	var pkg domain.Package
+	err := r.db.Preload("Resident").Preload("StorageLocation").Where("tracking_code = ?", code).Order("created_at DESC").First(&pkg).Error
+	return &pkg, err
+}
+
+// GetPending returns the packages waiting for pickup, oldest first
+func (r *packageRepository) GetPending() ([]domain.Package, error) {
+	var packages []domain.Package
+	err := r.db.Preload("Resident").Preload("StorageLocation").
+		Where("status = ?", domain.PackagePending).
+		Order("received_at").
+		Find(&packages).Error
+	return packages, err
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"portarius/internal/package/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type packageReturnRepository struct {
+	db *gorm.DB
+}
+
+func NewPackageReturnRepository(db *gorm.DB) domain.IPackageReturnRepository {
+	return &packageReturnRepository{db: db}
+}
+
+// GetAll returns the returns, the ones waiting longest for the carrier first
+func (r *packageReturnRepository) GetAll(status domain.ReturnStatus) ([]domain.PackageReturn, error) {
+	var returns []domain.PackageReturn
+	query := r.db.Preload("Package.Resident").Preload("Package.StorageLocation").Order("registered_at")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+	err := query.Find(&returns).Error
+	return returns, err
+}
+
+func (r *packageReturnRepository) GetByID(id uint) (*domain.PackageReturn, error) {
+	var packageReturn domain.PackageReturn
+	err := r.db.Preload("Package.Resident").Preload("Package.StorageLocation").First(&packageReturn, id).Error
+	return &packageReturn, err
+}
+
+func (r *packageReturnRepository) FindByPackage(packageID uint) ([]domain.PackageReturn, error) {
+	var returns []domain.PackageReturn
+	err := r.db.Where("package_id = ?", packageID).Order("registered_at DESC").Find(&returns).Error
+	return returns, err
+}
+
+// FindRegistered returns the returns registered in the period, with their packages
+func (r *packageReturnRepository) FindRegistered(from, to time.Time) ([]domain.PackageReturn, error) {
+	var returns []domain.PackageReturn
+	err := r.db.Preload("Package").
+		Where("registered_at >= ? AND registered_at < ?", from, to).
+		Find(&returns).Error
+	return returns, err
+}
+
+// Save stores the return together with the status and location it left the package in
+func (r *packageReturnRepository) Save(packageReturn *domain.PackageReturn, pkg *domain.Package) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Package").Save(packageReturn).Error; err != nil {
+			return err
+		}
+		if pkg == nil {
+			return nil
+		}
+		return tx.Model(&domain.Package{}).Where("id = ?", pkg.ID).
+			Updates(map[string]interface{}{"status": pkg.Status, "storage_location_id": pkg.StorageLocationID}).Error
+	})
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"portarius/internal/package/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type storageLocationRepository struct {
+	db *gorm.DB
+}
+
+type locationUsage struct {
+	StorageLocationID uint
+	Used              int
+}
+
+func NewStorageLocationRepository(db *gorm.DB) domain.IStorageLocationRepository {
+	return &storageLocationRepository{db: db}
+}
+
+func (r *storageLocationRepository) GetAll() ([]domain.StorageLocation, error) {
+	var locations []domain.StorageLocation
+	err := r.db.Order("code").Find(&locations).Error
+	return locations, err
+}
+
+func (r *storageLocationRepository) GetByID(id uint) (*domain.StorageLocation, error) {
+	var location domain.StorageLocation
+	err := r.db.First(&location, id).Error
+	return &location, err
+}
+
+func (r *storageLocationRepository) Create(location *domain.StorageLocation) error {
+	return r.db.Create(location).Error
+}
+
+func (r *storageLocationRepository) Update(location *domain.StorageLocation) error {
+	return r.db.Save(location).Error
+}
+
+func (r *storageLocationRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.StorageLocation{}, id).Error
+}
+
+// GetOccupancy returns every location with the volumes of the pending packages stored there
+func (r *storageLocationRepository) GetOccupancy() ([]domain.StorageOccupancy, error) {
+	locations, err := r.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := r.usage(r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	occupancies := make([]domain.StorageOccupancy, 0, len(locations))
+	for _, location := range locations {
+		occupancies = append(occupancies, domain.NewStorageOccupancy(location, usage[location.ID]))
+	}
+	return occupancies, nil
+}
+
+func (r *storageLocationRepository) GetLocationOccupancy(id uint) (*domain.StorageOccupancy, error) {
+	location, err := r.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := r.usage(r.db.Where("storage_location_id = ?", id))
+	if err != nil {
+		return nil, err
+	}
+
+	occupancy := domain.NewStorageOccupancy(*location, usage[id])
+	return &occupancy, nil
+}
+
+// FindUnitLocationIDs returns the locations holding pending packages of the unit
+func (r *storageLocationRepository) FindUnitLocationIDs(block, apartment string) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.Package{}).
+		Joins("JOIN residents ON residents.id = packages.resident_id").
+		Where("residents.block = ? AND residents.apartment = ?", strings.ToUpper(block), apartment).
+		Where("packages.status = ? AND packages.storage_location_id IS NOT NULL", domain.PackagePending).
+		Distinct().
+		Pluck("packages.storage_location_id", &ids).Error
+	return ids, err
+}
+
+func (r *storageLocationRepository) GetPendingPackages(id uint) ([]domain.Package, error) {
+	var packages []domain.Package
+	err := r.db.Preload("Resident").
+		Where("storage_location_id = ? AND status IN ?", id, domain.ShelvedPackageStatuses).
+		Order("received_at").
+		Find(&packages).Error
+	return packages, err
+}
+
+func (r *storageLocationRepository) usage(query *gorm.DB) (map[uint]int, error) {
+	var rows []locationUsage
+	err := query.Model(&domain.Package{}).
+		Select("storage_location_id, COALESCE(SUM(quantity), 0) AS used").
+		Where("status IN ? AND storage_location_id IS NOT NULL", domain.ShelvedPackageStatuses).
+		Group("storage_location_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	usage := make(map[uint]int, len(rows))
+	for _, row := range rows {
+		usage[row.StorageLocationID] = row.Used
+	}
+	return usage, nil
+}
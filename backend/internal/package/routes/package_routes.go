@@ -4,27 +4,123 @@ import (
 	"portarius/internal/package/domain"
 	packageHandler "portarius/internal/package/handler"
 	"portarius/internal/package/repository"
+	packageService "portarius/internal/package/service"
+	residentDomain "portarius/internal/resident/domain"
+	residentRepository "portarius/internal/resident/repository"
+	fileStorage "portarius/internal/storage"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func RegisterPackageRoutes(router *gin.RouterGroup, db *gorm.DB) {
+func newAttachmentHandler(router *gin.RouterGroup, db *gorm.DB, store fileStorage.Storage, signer *fileStorage.URLSigner) *packageHandler.PackageAttachmentHandler {
 	var (
-		repo domain.IPackageRepository = repository.NewPackageRepository(db)
+		repo           domain.IPackageRepository           = repository.NewPackageRepository(db)
+		attachmentRepo domain.IPackageAttachmentRepository = repository.NewPackageAttachmentRepository(db)
 	)
 
-	handler := packageHandler.NewPackageHandler(repo)
+	attachments := packageService.NewAttachmentService(attachmentRepo, store, signer, router.BasePath())
+	return packageHandler.NewPackageAttachmentHandler(repo, attachments)
+}
+
+// RegisterPackagePublicRoutes serves the attachment files to signed links, which work without the
+// bearer token so they can be used directly in <img> tags
+func RegisterPackagePublicRoutes(router *gin.RouterGroup, db *gorm.DB, store fileStorage.Storage, signer *fileStorage.URLSigner) {
+	handler := newAttachmentHandler(router, db, store, signer)
+
+	attachments := router.Group("/package-attachments")
+	{
+		attachments.GET("/:id/file", handler.Download)
+		attachments.GET("/:id/thumbnail", handler.DownloadThumbnail)
+	}
+}
+
+func RegisterPackageRoutes(router *gin.RouterGroup, db *gorm.DB, store fileStorage.Storage, signer *fileStorage.URLSigner) {
+	var (
+		repo         domain.IPackageRepository          = repository.NewPackageRepository(db)
+		pickupRepo   domain.IAuthorizedPickupRepository = repository.NewAuthorizedPickupRepository(db)
+		storageRepo  domain.IStorageLocationRepository  = repository.NewStorageLocationRepository(db)
+		lossRepo     domain.IPackageLossRepository      = repository.NewPackageLossRepository(db)
+		returnRepo   domain.IPackageReturnRepository    = repository.NewPackageReturnRepository(db)
+		residentRepo residentDomain.IResidentRepository = residentRepository.NewResidentRepository(db)
+	)
+
+	storage := packageService.NewStorageService(storageRepo, residentRepo)
+	intake := packageService.NewIntakeService(repo, storage)
+	handler := packageHandler.NewPackageHandler(repo, pickupRepo, residentRepo, storage, intake)
+	pickupHandler := packageHandler.NewAuthorizedPickupHandler(pickupRepo, residentRepo)
+	storageHandler := packageHandler.NewStorageLocationHandler(storageRepo, storage)
+	attachmentHandler := newAttachmentHandler(router, db, store, signer)
+	lossHandler := packageHandler.NewPackageLossHandler(lossRepo, repo)
+	returnHandler := packageHandler.NewPackageReturnHandler(returnRepo, repo)
 
 	packages := router.Group("/packages")
 	{
 		packages.GET("/", handler.GetAll)
 		packages.GET("/:id", handler.GetByID)
 		packages.POST("/", handler.Create)
+		packages.POST("/batch", handler.CreateBatch)
 		packages.PUT("/:id", handler.Update)
 		packages.DELETE("/:id", handler.Delete)
 		packages.PUT("/:id/deliver", handler.MarkAsDelivered)
-		packages.PUT("/:id/lost", handler.MarkAsLost)
+		packages.PUT("/:id/lost", lossHandler.Report)
+		packages.GET("/:id/loss-reports", lossHandler.GetByPackage)
+		packages.PUT("/:id/return", returnHandler.Register)
+		packages.GET("/:id/returns", returnHandler.GetByPackage)
+		packages.GET("/:id/authorized-pickups", handler.GetAuthorizedPickups)
+		packages.PUT("/:id/location", handler.MoveStorage)
+		packages.GET("/:id/attachments", attachmentHandler.GetByPackage)
+		packages.POST("/:id/attachments", attachmentHandler.Upload)
+		packages.DELETE("/:id/attachments/:attachmentId", attachmentHandler.Delete)
 		packages.GET("/status", handler.ListPackageStatus)
+		packages.GET("/aging", handler.Aging)
+		packages.GET("/labels", handler.Labels)
+		packages.GET("/:id/label", handler.Label)
+		packages.GET("/carriers", handler.ListCarriers)
+		packages.GET("/lookup", handler.Lookup)
+	}
+
+	pickups := router.Group("/authorized-pickups")
+	{
+		pickups.GET("/", pickupHandler.GetAll)
+		pickups.POST("/", pickupHandler.Create)
+		pickups.GET("/relationships", pickupHandler.ListRelationships)
+		pickups.GET("/unit/:block/:apartment", pickupHandler.GetByUnit)
+		pickups.GET("/:id", pickupHandler.GetByID)
+		pickups.PUT("/:id", pickupHandler.Update)
+		pickups.DELETE("/:id", pickupHandler.Delete)
+	}
+
+	losses := router.Group("/loss-reports")
+	{
+		losses.GET("/", lossHandler.GetAll)
+		losses.GET("/stats", lossHandler.Stats)
+		losses.GET("/resolutions", lossHandler.ListResolutions)
+		losses.GET("/:id", lossHandler.GetByID)
+		losses.PUT("/:id/investigate", lossHandler.Investigate)
+		losses.POST("/:id/notes", lossHandler.AddNote)
+		losses.PUT("/:id/resolve", lossHandler.Resolve)
+	}
+
+	returns := router.Group("/package-returns")
+	{
+		returns.GET("/", returnHandler.GetAll)
+		returns.GET("/stats", returnHandler.Stats)
+		returns.GET("/reasons", returnHandler.ListReasons)
+		returns.GET("/:id", returnHandler.GetByID)
+		returns.PUT("/:id/carrier-pickup", returnHandler.CarrierPickup)
+		returns.PUT("/:id/cancel", returnHandler.Cancel)
+	}
+
+	locations := router.Group("/storage-locations")
+	{
+		locations.GET("/", storageHandler.GetAll)
+		locations.POST("/", storageHandler.Create)
+		locations.GET("/occupancy", storageHandler.GetOccupancy)
+		locations.GET("/suggest", storageHandler.Suggest)
+		locations.GET("/:id", storageHandler.GetByID)
+		locations.PUT("/:id", storageHandler.Update)
+		locations.DELETE("/:id", storageHandler.Delete)
+		locations.GET("/:id/packages", storageHandler.GetPackages)
 	}
 }
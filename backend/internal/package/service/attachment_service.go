@@ -0,0 +1,139 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	pkgDomain "portarius/internal/package/domain"
+	"portarius/internal/storage"
+	"time"
+)
+
+const (
+	AttachmentFile      = "file"
+	AttachmentThumbnail = "thumbnail"
+)
+
+type AttachmentService struct {
+	repo     pkgDomain.IPackageAttachmentRepository
+	storage  storage.Storage
+	signer   *storage.URLSigner
+	basePath string
+}
+
+// NewAttachmentService builds the service; basePath is the prefix of the public download routes,
+// such as "/api"
+func NewAttachmentService(repo pkgDomain.IPackageAttachmentRepository, store storage.Storage, signer *storage.URLSigner, basePath string) *AttachmentService {
+	return &AttachmentService{repo: repo, storage: store, signer: signer, basePath: basePath}
+}
+
+// Upload stores the file and its thumbnail and records the attachment. Stored files are removed
+// again when any later step fails, so no orphan is left in the storage.
+func (s *AttachmentService) Upload(ctx context.Context, pkg *pkgDomain.Package, kind pkgDomain.AttachmentKind, fileName string, content []byte, uploadedByID *uint) (*pkgDomain.PackageAttachment, error) {
+	contentType, err := pkgDomain.DetectAttachmentType(content)
+	if err != nil {
+		return nil, err
+	}
+
+	thumbnail, err := storage.Thumbnail(content, storage.ThumbnailSize)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := pkgDomain.NewPackageAttachment(pkg.ID, kind, fileName, contentType, int64(len(content)), uploadedByID, time.Now())
+
+	if err := s.storage.Put(ctx, attachment.StorageKey, bytes.NewReader(content), int64(len(content)), contentType); err != nil {
+		return nil, fmt.Errorf("erro ao salvar o arquivo: %v", err)
+	}
+
+	if err := s.storage.Put(ctx, attachment.ThumbnailKey, bytes.NewReader(thumbnail), int64(len(thumbnail)), "image/jpeg"); err != nil {
+		s.removeFiles(ctx, attachment.StorageKey)
+		return nil, fmt.Errorf("erro ao salvar a miniatura: %v", err)
+	}
+
+	if err := s.repo.Create(attachment); err != nil {
+		s.removeFiles(ctx, attachment.StorageKey, attachment.ThumbnailKey)
+		return nil, err
+	}
+
+	s.WithURLs(attachment)
+	return attachment, nil
+}
+
+func (s *AttachmentService) GetByPackage(packageID uint) ([]pkgDomain.PackageAttachment, error) {
+	attachments, err := s.repo.GetByPackage(packageID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range attachments {
+		s.WithURLs(&attachments[i])
+	}
+	return attachments, nil
+}
+
+// WithURLs fills the signed links to the file and the thumbnail of the attachment
+func (s *AttachmentService) WithURLs(attachment *pkgDomain.PackageAttachment) {
+	now := time.Now()
+	attachment.DownloadURL = s.signer.Sign(s.DownloadPath(attachment.ID, AttachmentFile), now)
+	if attachment.ThumbnailKey != "" {
+		attachment.ThumbnailURL = s.signer.Sign(s.DownloadPath(attachment.ID, AttachmentThumbnail), now)
+	}
+}
+
+// DownloadPath is the public route serving the file or the thumbnail of the attachment
+func (s *AttachmentService) DownloadPath(id uint, variant string) string {
+	return fmt.Sprintf("%s/package-attachments/%d/%s", s.basePath, id, variant)
+}
+
+// VerifyDownload checks the signature of a download link for the path it was requested on
+func (s *AttachmentService) VerifyDownload(path, expires, signature string) error {
+	return s.signer.Verify(path, expires, signature, time.Now())
+}
+
+// Open returns the attachment with the content of its file or thumbnail and the content type of it
+func (s *AttachmentService) Open(ctx context.Context, id uint, variant string) (*pkgDomain.PackageAttachment, io.ReadCloser, string, error) {
+	attachment, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, nil, "", storage.ErrNotFound
+	}
+
+	key, contentType := attachment.StorageKey, attachment.ContentType
+	if variant == AttachmentThumbnail {
+		key, contentType = attachment.ThumbnailKey, "image/jpeg"
+	}
+
+	content, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	return attachment, content, contentType, nil
+}
+
+func (s *AttachmentService) GetByID(id uint) (*pkgDomain.PackageAttachment, error) {
+	return s.repo.GetByID(id)
+}
+
+// Delete removes the attachment and then its files; a file that fails to be removed is only logged,
+// since the attachment is already gone
+func (s *AttachmentService) Delete(ctx context.Context, attachment *pkgDomain.PackageAttachment) error {
+	if err := s.repo.Delete(attachment.ID); err != nil {
+		return err
+	}
+
+	s.removeFiles(ctx, attachment.StorageKey, attachment.ThumbnailKey)
+	return nil
+}
+
+func (s *AttachmentService) removeFiles(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+		if err := s.storage.Delete(ctx, key); err != nil {
+			log.Printf("Erro ao remover o arquivo %s do armazenamento: %v", key, err)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"net/url"
+	exportDomain "portarius/internal/export/domain"
+	pkgDomain "portarius/internal/package/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// PackageExporter exports packages with the resident they belong to, filtered by status, resident and
+// reception date
+type PackageExporter struct{}
+
+func NewPackageExporter() *PackageExporter {
+	return &PackageExporter{}
+}
+
+func (e *PackageExporter) Entity() exportDomain.ExportEntity {
+	return exportDomain.ExportEntityPackages
+}
+
+func (e *PackageExporter) Columns() []exportDomain.Column {
+	return []exportDomain.Column{
+		{Key: "id", Header: "ID"},
+		{Key: "resident_id", Header: "ID do morador"},
+		{Key: "resident_name", Header: "Morador"},
+		{Key: "unit", Header: "Unidade"},
+		{Key: "quantity", Header: "Quantidade"},
+		{Key: "description", Header: "Descrição"},
+		{Key: "carrier", Header: "Transportadora"},
+		{Key: "tracking_code", Header: "Código de rastreio"},
+		{Key: "sender", Header: "Remetente"},
+		{Key: "storage_location", Header: "Local"},
+		{Key: "status", Header: "Status"},
+		{Key: "received_at", Header: "Recebida em"},
+		{Key: "delivered_at", Header: "Entregue em"},
+		{Key: "delivered_to_id", Header: "Entregue a"},
+		{Key: "authorized_pickup_id", Header: "ID da pessoa autorizada"},
+		{Key: "picked_up_by_name", Header: "Retirada por"},
+		{Key: "picked_up_by_document", Header: "Documento de quem retirou"},
+		{Key: "delivered_by_id", Header: "Entregue por"},
+		{Key: "pickup_override_reason", Header: "Motivo da liberação sem código"},
+	}
+}
+
+func (e *PackageExporter) Query(db *gorm.DB, filters url.Values) (*gorm.DB, error) {
+	query := db.Model(&pkgDomain.Package{}).Preload("Resident").Preload("StorageLocation")
+
+	if status := filters.Get("status"); status != "" {
+		query = query.Where("status = ?", strings.ToUpper(status))
+	}
+	if carrier := filters.Get("carrier"); carrier != "" {
+		query = query.Where("carrier = ?", strings.ToUpper(carrier))
+	}
+
+	residentID, err := exportDomain.FilterID(filters, "residentId")
+	if err != nil {
+		return nil, err
+	}
+	if residentID != nil {
+		query = query.Where("resident_id = ?", *residentID)
+	}
+
+	startDate, err := exportDomain.FilterDate(filters, "start_date")
+	if err != nil {
+		return nil, err
+	}
+	if startDate != nil {
+		query = query.Where("received_at >= ?", *startDate)
+	}
+
+	endDate, err := exportDomain.FilterDate(filters, "end_date")
+	if err != nil {
+		return nil, err
+	}
+	if endDate != nil {
+		query = query.Where("received_at < ?", endDate.AddDate(0, 0, 1))
+	}
+
+	return query, nil
+}
+
+func (e *PackageExporter) Export(query *gorm.DB, write func(values []any) error) error {
+	var packages []pkgDomain.Package
+	return query.FindInBatches(&packages, exportDomain.ExportBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, pkg := range packages {
+			var residentName, unit, storageCode string
+			if pkg.Resident != nil {
+				residentName = pkg.Resident.Name
+				unit = pkg.Resident.Block + pkg.Resident.Apartment
+			}
+			if pkg.StorageLocation != nil {
+				storageCode = pkg.StorageLocation.Code
+			}
+
+			if err := write([]any{
+				pkg.ID,
+				pkg.ResidentID,
+				residentName,
+				unit,
+				pkg.Quantity,
+				pkg.Description,
+				pkg.Carrier,
+				pkg.TrackingCode,
+				pkg.Sender,
+				storageCode,
+				pkg.Status,
+				pkg.ReceivedAt,
+				pkg.DeliveredAt,
+				pkg.DeliveredToID,
+				pkg.AuthorizedPickupID,
+				pkg.PickedUpByName,
+				pkg.PickedUpByDocument,
+				pkg.DeliveredByID,
+				pkg.PickupOverrideReason,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"fmt"
+	pkgDomain "portarius/internal/package/domain"
+	"time"
+)
+
+type IntakeService struct {
+	repo    pkgDomain.IPackageRepository
+	storage *StorageService
+}
+
+func NewIntakeService(repo pkgDomain.IPackageRepository, storage *StorageService) *IntakeService {
+	return &IntakeService{repo: repo, storage: storage}
+}
+
+// ReceiveBatch registers a delivery of many packages in a single transaction and returns them grouped
+// per resident, for one notification each. Nothing is saved when any package is rejected; the item
+// errors are returned together with ErrInvalidBatch.
+func (s *IntakeService) ReceiveBatch(packages []pkgDomain.Package, now time.Time) ([]pkgDomain.ResidentPackages, []pkgDomain.BatchItemError, error) {
+	if len(packages) > pkgDomain.MaxBatchSize {
+		return nil, nil, pkgDomain.ErrBatchTooLarge
+	}
+
+	errs := pkgDomain.ValidateBatch(packages)
+	if len(errs) > 0 {
+		return nil, errs, pkgDomain.ErrInvalidBatch
+	}
+
+	for i, pkg := range packages {
+		if pkg.TrackingCode == "" {
+			continue
+		}
+		if existing, err := s.repo.FindByTrackingCode(pkg.TrackingCode); err == nil {
+			errs = append(errs, pkgDomain.BatchItemError{Index: i, Error: fmt.Sprintf("encomenda com o código %s já registrada (ID %d)", pkg.TrackingCode, existing.ID)})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs, pkgDomain.ErrInvalidBatch
+	}
+
+	errs, err := s.storage.AssignAll(packages)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(errs) > 0 {
+		return nil, errs, pkgDomain.ErrInvalidBatch
+	}
+
+	groups := pkgDomain.GroupByResident(packages)
+	if err := pkgDomain.PrepareBatch(packages, groups, now); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.repo.CreateBatch(packages); err != nil {
+		return nil, nil, err
+	}
+
+	return groups, nil, nil
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	pkgDomain "portarius/internal/package/domain"
+	residentDomain "portarius/internal/resident/domain"
+)
+
+type StorageService struct {
+	repo         pkgDomain.IStorageLocationRepository
+	residentRepo residentDomain.IResidentRepository
+}
+
+func NewStorageService(repo pkgDomain.IStorageLocationRepository, residentRepo residentDomain.IResidentRepository) *StorageService {
+	return &StorageService{repo: repo, residentRepo: residentRepo}
+}
+
+// Suggest picks a free location for a package of the resident with the given quantity
+func (s *StorageService) Suggest(residentID *uint, quantity int) (*pkgDomain.StorageLocation, error) {
+	occupancies, err := s.repo.GetOccupancy()
+	if err != nil {
+		return nil, err
+	}
+
+	unitLocationIDs, err := s.unitLocationIDs(residentID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pkgDomain.SuggestStorageLocation(occupancies, quantity, unitLocationIDs)
+}
+
+// Assign stores the package at the location it names, checking there is room for it, or at the
+// suggested location when it names none. Without a free location the package is left unassigned,
+// so intake is never blocked by a full mailroom.
+func (s *StorageService) Assign(pkg *pkgDomain.Package) error {
+	if pkg.StorageLocationID != nil {
+		return s.MoveTo(pkg, *pkg.StorageLocationID)
+	}
+
+	location, err := s.Suggest(pkg.ResidentID, pkg.Quantity)
+	if errors.Is(err, pkgDomain.ErrNoStorageAvailable) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	pkg.StorageLocationID = &location.ID
+	pkg.StorageLocation = location
+	return nil
+}
+
+// MoveTo stores the package at the location if it has room for it
+func (s *StorageService) MoveTo(pkg *pkgDomain.Package, locationID uint) error {
+	occupancy, err := s.repo.GetLocationOccupancy(locationID)
+	if err != nil {
+		return fmt.Errorf("local de armazenamento não encontrado")
+	}
+
+	quantity := pkg.Quantity
+	if quantity <= 0 {
+		quantity = 1
+	}
+	if pkg.StorageLocationID != nil && *pkg.StorageLocationID == locationID && pkg.ID != 0 && pkg.Status.IsShelved() {
+		occupancy.Free += quantity
+	}
+
+	if !occupancy.Fits(quantity) {
+		return fmt.Errorf("%w: %s", pkgDomain.ErrStorageLocationFull, occupancy.Location.Code)
+	}
+
+	pkg.StorageLocationID = &occupancy.Location.ID
+	pkg.StorageLocation = &occupancy.Location
+	return nil
+}
+
+// AssignAll stores the packages of a batch like Assign, but counts the volumes the batch itself has
+// already placed, so a large delivery is spread over the locations instead of overfilling the
+// suggested one. Packages naming a full or unknown location are reported, the others are left
+// unassigned when nothing is free.
+func (s *StorageService) AssignAll(packages []pkgDomain.Package) ([]pkgDomain.BatchItemError, error) {
+	occupancies, err := s.repo.GetOccupancy()
+	if err != nil {
+		return nil, err
+	}
+
+	errs := []pkgDomain.BatchItemError{}
+	unitLocations := map[uint][]uint{}
+	for i := range packages {
+		pkg := &packages[i]
+		quantity := max(pkg.Quantity, 1)
+
+		index := -1
+		if pkg.StorageLocationID != nil {
+			index = findOccupancy(occupancies, *pkg.StorageLocationID)
+			if index < 0 {
+				errs = append(errs, pkgDomain.BatchItemError{Index: i, Error: "local de armazenamento não encontrado"})
+				continue
+			}
+			if !occupancies[index].Fits(quantity) {
+				errs = append(errs, pkgDomain.BatchItemError{Index: i, Error: fmt.Sprintf("%v: %s", pkgDomain.ErrStorageLocationFull, occupancies[index].Location.Code)})
+				continue
+			}
+		} else {
+			ids, ok := unitLocations[*pkg.ResidentID]
+			if !ok {
+				ids, err = s.unitLocationIDs(pkg.ResidentID)
+				if err != nil {
+					errs = append(errs, pkgDomain.BatchItemError{Index: i, Error: err.Error()})
+					continue
+				}
+				unitLocations[*pkg.ResidentID] = ids
+			}
+
+			location, err := pkgDomain.SuggestStorageLocation(occupancies, quantity, ids)
+			if errors.Is(err, pkgDomain.ErrNoStorageAvailable) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			index = findOccupancy(occupancies, location.ID)
+		}
+
+		occupancy := &occupancies[index]
+		*occupancy = pkgDomain.NewStorageOccupancy(occupancy.Location, occupancy.Used+quantity)
+
+		location := occupancy.Location
+		pkg.StorageLocationID = &location.ID
+		pkg.StorageLocation = &location
+		unitLocations[*pkg.ResidentID] = append(unitLocations[*pkg.ResidentID], location.ID)
+	}
+
+	return errs, nil
+}
+
+// unitLocationIDs returns the locations already holding packages of the resident's unit
+func (s *StorageService) unitLocationIDs(residentID *uint) ([]uint, error) {
+	if residentID == nil {
+		return nil, nil
+	}
+
+	resident, err := s.residentRepo.GetByID(*residentID)
+	if err != nil {
+		return nil, fmt.Errorf("morador não encontrado")
+	}
+
+	return s.repo.FindUnitLocationIDs(resident.Block, resident.Apartment)
+}
+
+func findOccupancy(occupancies []pkgDomain.StorageOccupancy, locationID uint) int {
+	for i, occupancy := range occupancies {
+		if occupancy.Location.ID == locationID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"fmt"
+	"os"
+	"portarius/internal/utils"
+	"strings"
+	"unicode"
+)
+
+const (
+	pixGUI                = "br.gov.bcb.pix"
+	maxMerchantNameLength = 25
+	maxMerchantCityLength = 15
+	maxTxIDLength         = 25
+	maxDescriptionLength  = 40
+	txIDPlaceholder       = "***"
+)
+
+// BRCode represents the data encoded in a static PIX "copia e cola" payload following the EMV BR Code
+// standard: the key, the amount and the txid are carried in the code itself.
+type BRCode struct {
+	Key          string
+	Description  string
+	MerchantName string
+	MerchantCity string
+	Amount       float64
+	TxID         string
+}
+
+func NewBRCodeFromEnv(amount float64, txid string) (*BRCode, error) {
+	key := os.Getenv("PIX_KEY")
+	if key == "" {
+		return nil, fmt.Errorf("chave PIX não configurada")
+	}
+
+	return &BRCode{
+		Key:          key,
+		MerchantName: os.Getenv("PIX_MERCHANT_NAME"),
+		MerchantCity: os.Getenv("PIX_MERCHANT_CITY"),
+		Amount:       amount,
+		TxID:         txid,
+	}, nil
+}
+
+func (b *BRCode) Payload() (string, error) {
+	if b.Key == "" {
+		return "", fmt.Errorf("chave PIX é obrigatória")
+	}
+
+	name := normalizeField(b.MerchantName, maxMerchantNameLength)
+	if name == "" {
+		return "", fmt.Errorf("nome do recebedor é obrigatório")
+	}
+
+	city := normalizeField(b.MerchantCity, maxMerchantCityLength)
+	if city == "" {
+		return "", fmt.Errorf("cidade do recebedor é obrigatória")
+	}
+
+	txid := NormalizeTxID(b.TxID)
+	if txid == "" {
+		txid = txIDPlaceholder
+	}
+
+	account := emvField("00", pixGUI) + emvField("01", b.Key)
+	if description := normalizeField(b.Description, maxDescriptionLength); description != "" {
+		account += emvField("02", description)
+	}
+
+	var payload strings.Builder
+	payload.WriteString(emvField("00", "01"))
+	payload.WriteString(emvField("26", account))
+	payload.WriteString(emvField("52", "0000"))
+	payload.WriteString(emvField("53", "986"))
+	if b.Amount > 0 {
+		payload.WriteString(emvField("54", fmt.Sprintf("%.2f", b.Amount)))
+	}
+	payload.WriteString(emvField("58", "BR"))
+	payload.WriteString(emvField("59", name))
+	payload.WriteString(emvField("60", city))
+	payload.WriteString(emvField("62", emvField("05", txid)))
+	payload.WriteString("6304")
+
+	result := payload.String()
+	return result + fmt.Sprintf("%04X", CRC16(result)), nil
+}
+
+// CRC16 computes the CRC16-CCITT (polynomial 0x1021, initial value 0xFFFF) required by the BR Code.
+func CRC16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+func NormalizeTxID(txid string) string {
+	var builder strings.Builder
+	for _, r := range txid {
+		if (r >= 'A' && r <= 'Z') || (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+		}
+	}
+
+	result := builder.String()
+	if len(result) > maxTxIDLength {
+		result = result[:maxTxIDLength]
+	}
+	return result
+}
+
+func emvField(id, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+func normalizeField(value string, maxLength int) string {
+	value = utils.RemoveAccents(strings.TrimSpace(value))
+
+	var builder strings.Builder
+	for _, r := range value {
+		if r < unicode.MaxASCII && unicode.IsPrint(r) {
+			builder.WriteRune(r)
+		}
+	}
+
+	result := builder.String()
+	if len(result) > maxLength {
+		result = strings.TrimSpace(result[:maxLength])
+	}
+	return result
+}
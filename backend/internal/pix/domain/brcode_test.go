@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"fmt"
+	pixDomain "portarius/internal/pix/domain"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCRC16(t *testing.T) {
+	assert.Equal(t, uint16(0x29B1), pixDomain.CRC16("123456789"))
+}
+
+func TestBRCode_Payload(t *testing.T) {
+	code := pixDomain.BRCode{
+		Key:          "12345678000195",
+		MerchantName: "Condomínio Residencial",
+		MerchantCity: "Cascavel",
+		Amount:       70,
+		TxID:         "RESERVA00000042",
+	}
+
+	payload, err := code.Payload()
+	assert.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(payload, "000201"))
+	assert.Contains(t, payload, "26360014br.gov.bcb.pix011412345678000195")
+	assert.Contains(t, payload, "540570.00")
+	assert.Contains(t, payload, "5922Condominio Residencial")
+	assert.Contains(t, payload, "6008Cascavel")
+	assert.Contains(t, payload, "62190515RESERVA00000042")
+
+	body, crc := payload[:len(payload)-4], payload[len(payload)-4:]
+	assert.True(t, strings.HasSuffix(body, "6304"))
+	assert.Equal(t, fmt.Sprintf("%04X", pixDomain.CRC16(body)), crc)
+}
+
+func TestBRCode_PayloadRequiresKey(t *testing.T) {
+	code := pixDomain.BRCode{MerchantName: "Condominio", MerchantCity: "Cascavel"}
+
+	_, err := code.Payload()
+	assert.Error(t, err)
+}
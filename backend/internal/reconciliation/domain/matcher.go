@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	boletoDomain "portarius/internal/boleto/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PaymentWindowAfterEvent is how long after the event a credit without txid can still be matched to a reservation.
+const PaymentWindowAfterEvent = 7 * 24 * time.Hour
+
+// MatchResult represents the outcome of matching a credit against the reservations waiting for payment
+type MatchResult struct {
+	Status      CreditStatus
+	Reservation *reservationDomain.Reservation
+	Candidates  []uint
+}
+
+func NewStatementCredit(importID uint, format StatementFormat, parsed ParsedCredit) StatementCredit {
+	return StatementCredit{
+		ImportID:    importID,
+		Fingerprint: Fingerprint(format, parsed),
+		Date:        parsed.Date,
+		Amount:      parsed.Amount,
+		Reference:   parsed.Reference,
+		Description: parsed.Description,
+		TxID:        reservationDomain.FindPixTxID(parsed.Reference + " " + parsed.Description),
+		Status:      CreditUnmatched,
+	}
+}
+
+func Fingerprint(format StatementFormat, parsed ParsedCredit) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s|%s|%s|%.2f|%s", format, parsed.Reference, parsed.Date.Format("2006-01-02"), parsed.Amount, parsed.Description)))
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchCredit matches a credit by txid. Since every reservation costs one of a few fees, a credit without
+// a known txid is never matched on amount and date alone: the reservations fitting them are suggested in
+// the review queue, as is the reservation of a txid paid with a different amount.
+func MatchCredit(credit *StatementCredit, pending []reservationDomain.Reservation) MatchResult {
+	if credit.TxID != "" {
+		for i := range pending {
+			if pending[i].GetPixTxID() != credit.TxID {
+				continue
+			}
+
+			if amountsEqual(pending[i].PaymentAmount, credit.Amount) {
+				return MatchResult{Status: CreditMatched, Reservation: &pending[i]}
+			}
+			return MatchResult{Status: CreditInReview, Candidates: []uint{pending[i].ID}}
+		}
+	}
+
+	candidates := []*reservationDomain.Reservation{}
+	for i := range pending {
+		if amountsEqual(pending[i].PaymentAmount, credit.Amount) && withinPaymentWindow(&pending[i], credit.Date) {
+			candidates = append(candidates, &pending[i])
+		}
+	}
+
+	if len(candidates) == 0 {
+		return MatchResult{Status: CreditUnmatched}
+	}
+
+	ids := make([]uint, 0, len(candidates))
+	for _, candidate := range candidates {
+		ids = append(ids, candidate.ID)
+	}
+	return MatchResult{Status: CreditInReview, Candidates: ids}
+}
+
+// FindBoleto returns the open boleto whose nosso número appears in a credit read from a CNAB return file.
+func FindBoleto(credit *StatementCredit, boletos []boletoDomain.Boleto) *boletoDomain.Boleto {
+	for i := range boletos {
+		if boletos[i].IsOpen() && boletos[i].MatchesReference(credit.Reference) {
+			return &boletos[i]
+		}
+	}
+	return nil
+}
+
+// MatchBoletoCredit matches a credit paying a boleto to the boleto's reservation. A payment below the
+// boleto amount, or for a reservation no longer waiting for payment, goes to the review queue.
+func MatchBoletoCredit(credit *StatementCredit, boleto *boletoDomain.Boleto, pending []reservationDomain.Reservation) MatchResult {
+	for i := range pending {
+		if pending[i].ID != boleto.ReservationID {
+			continue
+		}
+
+		if credit.Amount+0.005 < boleto.Amount {
+			return MatchResult{Status: CreditInReview, Candidates: []uint{boleto.ReservationID}}
+		}
+		return MatchResult{Status: CreditMatched, Reservation: &pending[i]}
+	}
+	return MatchResult{Status: CreditInReview, Candidates: []uint{boleto.ReservationID}}
+}
+
+func (c *StatementCredit) SetCandidates(ids []uint) {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.FormatUint(uint64(id), 10))
+	}
+	c.CandidateIDs = strings.Join(values, ",")
+}
+
+func withinPaymentWindow(reservation *reservationDomain.Reservation, date time.Time) bool {
+	created := reservation.CreatedAt
+	if !created.IsZero() {
+		created = time.Date(created.Year(), created.Month(), created.Day(), 0, 0, 0, 0, date.Location())
+		if date.Before(created) {
+			return false
+		}
+	}
+	return !date.After(reservation.StartTime.Add(PaymentWindowAfterEvent))
+}
+
+func amountsEqual(a, b float64) bool {
+	return math.Abs(a-b) < 0.005
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type StatementFormat string
+
+const (
+	FormatOFX     StatementFormat = "OFX"
+	FormatCNAB240 StatementFormat = "CNAB240"
+	FormatCNAB400 StatementFormat = "CNAB400"
+)
+
+type CreditStatus string
+
+const (
+	CreditMatched   CreditStatus = "CONCILIADO"
+	CreditInReview  CreditStatus = "EM_REVISAO"
+	CreditUnmatched CreditStatus = "NAO_CONCILIADO"
+	CreditDismissed CreditStatus = "DESCARTADO"
+)
+
+// StatementImport represents a bank statement or return file imported for reconciliation
+// swagger:model
+type StatementImport struct {
+	gorm.Model `swaggerignore:"true"`
+	FileName   string          `json:"file_name" gorm:"not null"`
+	Format     StatementFormat `json:"format" gorm:"type:varchar(10);not null"`
+	ImportedAt time.Time       `json:"imported_at"`
+	Credits    int             `json:"credits"`
+	Duplicates int             `json:"duplicates"`
+	Matched    int             `json:"matched"`
+	InReview   int             `json:"in_review"`
+	Unmatched  int             `json:"unmatched"`
+}
+
+// StatementCredit represents a credit read from an imported statement and its reconciliation state
+// swagger:model
+type StatementCredit struct {
+	gorm.Model    `swaggerignore:"true"`
+	ImportID      uint                           `json:"import_id" gorm:"not null;index"`
+	Fingerprint   string                         `json:"-" gorm:"type:varchar(64);not null;uniqueIndex"`
+	Date          time.Time                      `json:"date" gorm:"not null"`
+	Amount        float64                        `json:"amount" gorm:"type:decimal(10,2);not null"`
+	Reference     string                         `json:"reference"`
+	Description   string                         `json:"description" gorm:"type:text"`
+	TxID          string                         `json:"txid" gorm:"type:varchar(35);index"`
+	Status        CreditStatus                   `json:"status" gorm:"type:varchar(20);not null;default:'NAO_CONCILIADO'"`
+	ReservationID *uint                          `json:"reservation_id"`
+	Reservation   *reservationDomain.Reservation `json:"reservation,omitempty" gorm:"foreignKey:ReservationID" swaggerignore:"true"`
+	CandidateIDs  string                         `json:"candidate_ids"`
+	BoletoID      *uint                          `json:"boleto_id"`
+	ResolvedAt    *time.Time                     `json:"resolved_at" gorm:"type:timestamp"`
+}
+
+// ReconciliationReport represents the credits and reservations still waiting to be reconciled
+// swagger:model
+type ReconciliationReport struct {
+	UnmatchedCredits   []StatementCredit               `json:"unmatched_credits"`
+	InReviewCredits    []StatementCredit               `json:"in_review_credits"`
+	UnpaidReservations []reservationDomain.Reservation `json:"unpaid_reservations"`
+	UnmatchedAmount    float64                         `json:"unmatched_amount"`
+	UnpaidAmount       float64                         `json:"unpaid_amount"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+type IReconciliationRepository interface {
+	CreateImport(statementImport *StatementImport) error
+	UpdateImport(statementImport *StatementImport) error
+	GetImports(page, pageSize int) ([]StatementImport, error)
+	ExistsCredit(fingerprint string) (bool, error)
+	CreateCredit(credit *StatementCredit) error
+	UpdateCredit(credit *StatementCredit) error
+	GetCreditByID(id uint) (*StatementCredit, error)
+	GetCreditsByStatus(status CreditStatus, from, to time.Time) ([]StatementCredit, error)
+	GetCreditsByImport(importID uint) ([]StatementCredit, error)
+}
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsedCredit represents a credit read from a statement before it is reconciled
+type ParsedCredit struct {
+	Date        time.Time
+	Amount      float64
+	Reference   string
+	Description string
+}
+
+var (
+	ofxTransactionPattern = regexp.MustCompile(`(?is)<STMTTRN>(.*?)(?:</STMTTRN>|<STMTTRN>|</BANKTRANLIST>)`)
+	ofxTagPattern         = regexp.MustCompile(`(?i)<([A-Z0-9.]+)>([^<\r\n]*)`)
+)
+
+// Movement codes that mean the boleto or transfer was settled in CNAB return files.
+var (
+	cnab240SettlementCodes = map[string]bool{"06": true, "17": true}
+	cnab400SettlementCodes = map[string]bool{"06": true, "15": true, "17": true}
+)
+
+func DetectFormat(fileName string, data []byte) (StatementFormat, error) {
+	if strings.EqualFold(filepath.Ext(fileName), ".ofx") || bytes.Contains(bytes.ToUpper(data[:min(len(data), 2048)]), []byte("<OFX>")) {
+		return FormatOFX, nil
+	}
+
+	for _, line := range splitLines(data) {
+		switch len(line) {
+		case 240:
+			return FormatCNAB240, nil
+		case 400:
+			return FormatCNAB400, nil
+		}
+	}
+
+	return "", fmt.Errorf("formato de arquivo não reconhecido")
+}
+
+func ParseStatement(format StatementFormat, data []byte) ([]ParsedCredit, error) {
+	switch format {
+	case FormatOFX:
+		return parseOFX(data)
+	case FormatCNAB240:
+		return parseCNAB240(data)
+	case FormatCNAB400:
+		return parseCNAB400(data)
+	default:
+		return nil, fmt.Errorf("formato de arquivo inválido: %s", format)
+	}
+}
+
+func parseOFX(data []byte) ([]ParsedCredit, error) {
+	credits := []ParsedCredit{}
+
+	for _, transaction := range ofxTransactionPattern.FindAllStringSubmatch(string(data), -1) {
+		tags := map[string]string{}
+		for _, tag := range ofxTagPattern.FindAllStringSubmatch(transaction[1], -1) {
+			tags[strings.ToUpper(tag[1])] = strings.TrimSpace(tag[2])
+		}
+
+		amount, err := strconv.ParseFloat(strings.Replace(tags["TRNAMT"], ",", ".", 1), 64)
+		if err != nil {
+			return nil, fmt.Errorf("valor inválido na transação %s: %v", tags["FITID"], err)
+		}
+
+		if amount <= 0 {
+			continue
+		}
+
+		date, err := parseOFXDate(tags["DTPOSTED"])
+		if err != nil {
+			return nil, fmt.Errorf("data inválida na transação %s: %v", tags["FITID"], err)
+		}
+
+		description := strings.TrimSpace(strings.Join([]string{tags["NAME"], tags["MEMO"]}, " "))
+
+		credits = append(credits, ParsedCredit{
+			Date:        date,
+			Amount:      amount,
+			Reference:   tags["FITID"],
+			Description: description,
+		})
+	}
+
+	return credits, nil
+}
+
+func parseOFXDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("data incompleta: %q", value)
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+// parseCNAB240 reads the T and U segments of a FEBRABAN CNAB 240 return file.
+func parseCNAB240(data []byte) ([]ParsedCredit, error) {
+	credits := []ParsedCredit{}
+
+	var segmentT string
+
+	for number, line := range splitLines(data) {
+		if len(line) < 240 || line[7] != '3' {
+			continue
+		}
+
+		switch line[13] {
+		case 'T':
+			segmentT = line
+		case 'U':
+			if segmentT == "" {
+				return nil, fmt.Errorf("linha %d: segmento U sem segmento T", number+1)
+			}
+
+			movement := line[15:17]
+			if !cnab240SettlementCodes[movement] {
+				segmentT = ""
+				continue
+			}
+
+			amount, err := parseCNABAmount(line[77:92])
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: valor pago inválido: %v", number+1, err)
+			}
+
+			date, err := parseCNABDate(line[145:153], "02012006")
+			if err != nil {
+				date, err = parseCNABDate(line[137:145], "02012006")
+				if err != nil {
+					return nil, fmt.Errorf("linha %d: data de crédito inválida: %v", number+1, err)
+				}
+			}
+
+			ourNumber := strings.TrimSpace(segmentT[37:57])
+			yourNumber := strings.TrimSpace(segmentT[58:73])
+
+			credits = append(credits, ParsedCredit{
+				Date:        date,
+				Amount:      amount,
+				Reference:   ourNumber,
+				Description: strings.TrimSpace(fmt.Sprintf("Nosso número %s Seu número %s", ourNumber, yourNumber)),
+			})
+
+			segmentT = ""
+		}
+	}
+
+	return credits, nil
+}
+
+// parseCNAB400 reads the detail records of a CNAB 400 return file in the Bradesco layout.
+func parseCNAB400(data []byte) ([]ParsedCredit, error) {
+	credits := []ParsedCredit{}
+
+	for number, line := range splitLines(data) {
+		if len(line) < 400 || line[0] != '1' {
+			continue
+		}
+
+		if !cnab400SettlementCodes[line[108:110]] {
+			continue
+		}
+
+		amount, err := parseCNABAmount(line[253:266])
+		if err != nil {
+			return nil, fmt.Errorf("linha %d: valor pago inválido: %v", number+1, err)
+		}
+
+		date, err := parseCNABDate(line[295:301], "020106")
+		if err != nil {
+			date, err = parseCNABDate(line[110:116], "020106")
+			if err != nil {
+				return nil, fmt.Errorf("linha %d: data de crédito inválida: %v", number+1, err)
+			}
+		}
+
+		ourNumber := strings.TrimSpace(line[70:82])
+		yourNumber := strings.TrimSpace(line[116:126])
+		companyUse := strings.TrimSpace(line[37:62])
+
+		credits = append(credits, ParsedCredit{
+			Date:        date,
+			Amount:      amount,
+			Reference:   ourNumber,
+			Description: strings.TrimSpace(fmt.Sprintf("Nosso número %s Seu número %s %s", ourNumber, yourNumber, companyUse)),
+		})
+	}
+
+	return credits, nil
+}
+
+func parseCNABAmount(value string) (float64, error) {
+	cents, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return float64(cents) / 100, nil
+}
+
+func parseCNABDate(value, layout string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.Trim(value, "0") == "" {
+		return time.Time{}, fmt.Errorf("data vazia")
+	}
+	return time.Parse(layout, value)
+}
+
+func splitLines(data []byte) []string {
+	lines := []string{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 1024), 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, strings.TrimRight(scanner.Text(), "\r"))
+	}
+	return lines
+}
@@ -0,0 +1,167 @@
+package domain_test
+
+import (
+	"portarius/internal/reconciliation/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+const sampleOFX = `OFXHEADER:100
+DATA:OFXSGML
+<OFX>
+<BANKMSGSRSV1><STMTTRNRS><STMTRS><BANKTRANLIST>
+<STMTTRN>
+<TRNTYPE>CREDIT
+<DTPOSTED>20250314120000[-3:BRT]
+<TRNAMT>70,00
+<FITID>0001
+<MEMO>PIX RECEBIDO RESERVA00000012
+</STMTTRN>
+<STMTTRN>
+<TRNTYPE>DEBIT
+<DTPOSTED>20250314
+<TRNAMT>-15.00
+<FITID>0002
+<MEMO>TARIFA
+</STMTTRN>
+</BANKTRANLIST></STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>`
+
+func cnabLine(size int, fields map[int]string) string {
+	line := []byte(strings.Repeat(" ", size))
+	for position, value := range fields {
+		copy(line[position:], value)
+	}
+	return string(line)
+}
+
+func TestParseOFX(t *testing.T) {
+	format, err := domain.DetectFormat("extrato.ofx", []byte(sampleOFX))
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FormatOFX, format)
+
+	credits, err := domain.ParseStatement(format, []byte(sampleOFX))
+	assert.NoError(t, err)
+	assert.Len(t, credits, 1)
+	assert.Equal(t, 70.0, credits[0].Amount)
+	assert.Equal(t, "0001", credits[0].Reference)
+	assert.Equal(t, time.Date(2025, 3, 14, 0, 0, 0, 0, time.UTC), credits[0].Date)
+	assert.Contains(t, credits[0].Description, "RESERVA00000012")
+}
+
+func TestParseCNAB240(t *testing.T) {
+	segmentT := cnabLine(240, map[int]string{7: "3", 13: "T", 15: "06", 37: "00000000000000000123", 58: "RESERVA00000012"})
+	segmentU := cnabLine(240, map[int]string{7: "3", 13: "U", 15: "06", 77: "000000000004500", 137: "14032025", 145: "15032025"})
+	ignored := cnabLine(240, map[int]string{7: "3", 13: "T", 15: "02"})
+	ignoredU := cnabLine(240, map[int]string{7: "3", 13: "U", 15: "02", 77: "000000000007000"})
+	data := []byte(strings.Join([]string{segmentT, segmentU, ignored, ignoredU}, "\r\n"))
+
+	format, err := domain.DetectFormat("retorno.ret", data)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FormatCNAB240, format)
+
+	credits, err := domain.ParseStatement(format, data)
+	assert.NoError(t, err)
+	assert.Len(t, credits, 1)
+	assert.Equal(t, 45.0, credits[0].Amount)
+	assert.Equal(t, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), credits[0].Date)
+	assert.Equal(t, "00000000000000000123", credits[0].Reference)
+}
+
+func TestParseCNAB400(t *testing.T) {
+	detail := cnabLine(400, map[int]string{0: "1", 70: "000000000456", 108: "06", 110: "140325", 116: "RES0000012", 253: "0000000007000", 295: "150325"})
+	data := []byte(strings.Join([]string{cnabLine(400, map[int]string{0: "0"}), detail, cnabLine(400, map[int]string{0: "9"})}, "\n"))
+
+	format, err := domain.DetectFormat("retorno.ret", data)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.FormatCNAB400, format)
+
+	credits, err := domain.ParseStatement(format, data)
+	assert.NoError(t, err)
+	assert.Len(t, credits, 1)
+	assert.Equal(t, 70.0, credits[0].Amount)
+	assert.Equal(t, "000000000456", credits[0].Reference)
+	assert.Equal(t, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC), credits[0].Date)
+}
+
+func TestMatchCredit(t *testing.T) {
+	created := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	reservation := func(id uint, amount float64, start time.Time) reservationDomain.Reservation {
+		return reservationDomain.Reservation{
+			Model:         gorm.Model{ID: id, CreatedAt: created},
+			PaymentAmount: amount,
+			StartTime:     start,
+		}
+	}
+
+	pending := []reservationDomain.Reservation{
+		reservation(12, 70, time.Date(2025, 3, 15, 10, 0, 0, 0, time.UTC)),
+		reservation(13, 45, time.Date(2025, 3, 18, 10, 0, 0, 0, time.UTC)),
+		reservation(14, 45, time.Date(2025, 3, 19, 10, 0, 0, 0, time.UTC)),
+	}
+
+	tests := []struct {
+		name       string
+		parsed     domain.ParsedCredit
+		status     domain.CreditStatus
+		matchedID  uint
+		candidates []uint
+	}{
+		{
+			name:      "txid and amount match",
+			parsed:    domain.ParsedCredit{Date: created, Amount: 70, Description: "PIX RESERVA00000012"},
+			status:    domain.CreditMatched,
+			matchedID: 12,
+		},
+		{
+			name:       "txid with different amount goes to review",
+			parsed:     domain.ParsedCredit{Date: created, Amount: 45, Description: "PIX RESERVA00000012"},
+			status:     domain.CreditInReview,
+			candidates: []uint{12},
+		},
+		{
+			name:       "single reservation with amount in window goes to review",
+			parsed:     domain.ParsedCredit{Date: created, Amount: 70, Description: "PIX RECEBIDO"},
+			status:     domain.CreditInReview,
+			candidates: []uint{12},
+		},
+		{
+			name:       "several reservations with the same amount",
+			parsed:     domain.ParsedCredit{Date: created, Amount: 45, Description: "PIX RECEBIDO"},
+			status:     domain.CreditInReview,
+			candidates: []uint{13, 14},
+		},
+		{
+			name:   "credit before the reservation was created",
+			parsed: domain.ParsedCredit{Date: created.AddDate(0, 0, -2), Amount: 70},
+			status: domain.CreditUnmatched,
+		},
+		{
+			name:   "no reservation with the amount",
+			parsed: domain.ParsedCredit{Date: created, Amount: 99.9},
+			status: domain.CreditUnmatched,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			credit := domain.NewStatementCredit(1, domain.FormatOFX, tt.parsed)
+			result := domain.MatchCredit(&credit, pending)
+
+			assert.Equal(t, tt.status, result.Status)
+			if tt.matchedID != 0 {
+				assert.Equal(t, tt.matchedID, result.Reservation.ID)
+			} else {
+				assert.Nil(t, result.Reservation)
+			}
+			if tt.candidates != nil {
+				assert.Equal(t, tt.candidates, result.Candidates)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"portarius/internal/reconciliation/domain"
+	reconciliationService "portarius/internal/reconciliation/service"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const maxStatementSize = 10 << 20
+
+type ReconciliationHandler struct {
+	repo    domain.IReconciliationRepository
+	service *reconciliationService.ReconciliationService
+}
+
+func NewReconciliationHandler(repo domain.IReconciliationRepository, service *reconciliationService.ReconciliationService) *ReconciliationHandler {
+	return &ReconciliationHandler{repo: repo, service: service}
+}
+
+// ResolveRequest represents the reservation chosen to reconcile a credit
+// swagger:model
+type ResolveRequest struct {
+	ReservationID uint `json:"reservation_id" binding:"required"`
+}
+
+// Import godoc
+// @Summary Import a bank statement
+// @Description Imports an OFX statement or a CNAB 240/400 return file and reconciles its credits against reservations waiting for payment. Only credits carrying the PIX txid of a reservation, or paying a boleto, are reconciled automatically; the others go to the review queue with the reservations they may pay. Credits already imported are skipped.
+// @Tags Reconciliation
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param file formData file true "OFX or CNAB file"
+// @Param format formData string false "File format (OFX, CNAB240, CNAB400). Detected from the file when omitted"
+// @Success 201 {object} reconciliationService.ImportResult
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /reconciliation/import [post]
+func (c *ReconciliationHandler) Import(ctx *gin.Context) {
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Nenhum arquivo enviado"})
+		return
+	}
+
+	if fileHeader.Size > maxStatementSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo muito grande"})
+		return
+	}
+
+	format := domain.StatementFormat(strings.ToUpper(ctx.PostForm("format")))
+	switch format {
+	case "", domain.FormatOFX, domain.FormatCNAB240, domain.FormatCNAB400:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Formato de arquivo inválido"})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := c.service.Import(fileHeader.Filename, format, data)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, reconciliationService.ErrInvalidStatement) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, result)
+}
+
+// GetImports godoc
+// @Summary List statement imports
+// @Description Get paginated list of imported statements with their reconciliation totals
+// @Tags Reconciliation
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param pageSize query int false "Items per page" minimum(1) maximum(100) default(10)
+// @Success 200 {array} domain.StatementImport
+// @Failure 401
+// @Failure 500
+// @Router /reconciliation/imports [get]
+func (c *ReconciliationHandler) GetImports(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.Query("page"))
+	pageSize, _ := strconv.Atoi(ctx.Query("pageSize"))
+	imports, err := c.repo.GetImports(page, pageSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, imports)
+}
+
+// GetImportCredits godoc
+// @Summary List credits of an import
+// @Description Retrieve the credits read from an imported statement and their reconciliation status
+// @Tags Reconciliation
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Import ID"
+// @Success 200 {array} domain.StatementCredit
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /reconciliation/imports/{id}/credits [get]
+func (c *ReconciliationHandler) GetImportCredits(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	credits, err := c.repo.GetCreditsByImport(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, credits)
+}
+
+// GetReviewQueue godoc
+// @Summary List credits waiting for review
+// @Description Retrieve credits without a txid that fit the amount and date of reservations waiting for payment, and credits whose amount differs from the fee of the reservation of their txid
+// @Tags Reconciliation
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.StatementCredit
+// @Failure 401
+// @Failure 500
+// @Router /reconciliation/review [get]
+func (c *ReconciliationHandler) GetReviewQueue(ctx *gin.Context) {
+	credits, err := c.service.GetReviewQueue()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, credits)
+}
+
+// Resolve godoc
+// @Summary Reconcile a credit manually
+// @Description Links a credit in review, or not matched, to a reservation and marks the reservation as paid
+// @Tags Reconciliation
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Credit ID"
+// @Param body body ResolveRequest true "Reservation to reconcile"
+// @Success 200 {object} domain.StatementCredit
+// @Failure 400
+// @Failure 401
+// @Router /reconciliation/credits/{id}/resolve [post]
+func (c *ReconciliationHandler) Resolve(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	var input ResolveRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	credit, err := c.service.Resolve(uint(id), input.ReservationID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, credit)
+}
+
+// Dismiss godoc
+// @Summary Dismiss a credit
+// @Description Marks a credit as unrelated to reservations, removing it from the review queue and the report
+// @Tags Reconciliation
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Credit ID"
+// @Success 200 {object} domain.StatementCredit
+// @Failure 400
+// @Failure 401
+// @Router /reconciliation/credits/{id}/dismiss [post]
+func (c *ReconciliationHandler) Dismiss(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	credit, err := c.service.Dismiss(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, credit)
+}
+
+// GetReport godoc
+// @Summary Reconciliation report
+// @Description Lists credits not reconciled and reservations still waiting for payment in the period
+// @Tags Reconciliation
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "Start date (YYYY-MM-DD)"
+// @Param to query string false "End date (YYYY-MM-DD), inclusive"
+// @Success 200 {object} domain.ReconciliationReport
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /reconciliation/report [get]
+func (c *ReconciliationHandler) GetReport(ctx *gin.Context) {
+	var from, to time.Time
+	var err error
+
+	if fromDate := ctx.Query("from"); fromDate != "" {
+		from, err = time.Parse("2006-01-02", fromDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data inicial inválida"})
+			return
+		}
+	}
+
+	if toDate := ctx.Query("to"); toDate != "" {
+		to, err = time.Parse("2006-01-02", toDate)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data final inválida"})
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+
+	report, err := c.service.Report(from, to)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, report)
+}
+
+// ListCreditStatuses godoc
+// @Summary List credit statuses
+// @Description Returns the list of possible reconciliation statuses of a credit
+// @Tags Reconciliation
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.CreditStatus
+// @Router /reconciliation/creditStatus [get]
+func (c *ReconciliationHandler) ListCreditStatuses(ctx *gin.Context) {
+	statuses := []domain.CreditStatus{
+		domain.CreditMatched,
+		domain.CreditInReview,
+		domain.CreditUnmatched,
+		domain.CreditDismissed,
+	}
+	ctx.JSON(http.StatusOK, statuses)
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"portarius/internal/infra"
+	"portarius/internal/reconciliation/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type reconciliationRepository struct {
+	db *gorm.DB
+}
+
+func NewReconciliationRepository(db *gorm.DB) domain.IReconciliationRepository {
+	return &reconciliationRepository{db: db}
+}
+
+func (r *reconciliationRepository) CreateImport(statementImport *domain.StatementImport) error {
+	return r.db.Create(statementImport).Error
+}
+
+func (r *reconciliationRepository) UpdateImport(statementImport *domain.StatementImport) error {
+	return r.db.Save(statementImport).Error
+}
+
+func (r *reconciliationRepository) GetImports(page, pageSize int) ([]domain.StatementImport, error) {
+	var imports []domain.StatementImport
+	err := r.db.Scopes(infra.Paginate(page, pageSize)).Order("imported_at DESC").Find(&imports).Error
+	return imports, err
+}
+
+func (r *reconciliationRepository) ExistsCredit(fingerprint string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.StatementCredit{}).Where("fingerprint = ?", fingerprint).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *reconciliationRepository) CreateCredit(credit *domain.StatementCredit) error {
+	return r.db.Create(credit).Error
+}
+
+func (r *reconciliationRepository) UpdateCredit(credit *domain.StatementCredit) error {
+	return r.db.Omit("Reservation").Save(credit).Error
+}
+
+func (r *reconciliationRepository) GetCreditByID(id uint) (*domain.StatementCredit, error) {
+	var credit domain.StatementCredit
+	err := r.db.Preload("Reservation").First(&credit, id).Error
+	return &credit, err
+}
+
+func (r *reconciliationRepository) GetCreditsByStatus(status domain.CreditStatus, from, to time.Time) ([]domain.StatementCredit, error) {
+	var credits []domain.StatementCredit
+	query := r.db.Where("status = ?", status)
+	if !from.IsZero() {
+		query = query.Where("date >= ?", from)
+	}
+	if !to.IsZero() {
+		query = query.Where("date < ?", to)
+	}
+	err := query.Order("date ASC").Find(&credits).Error
+	return credits, err
+}
+
+func (r *reconciliationRepository) GetCreditsByImport(importID uint) ([]domain.StatementCredit, error) {
+	var credits []domain.StatementCredit
+	err := r.db.Preload("Reservation").Where("import_id = ?", importID).Order("date ASC").Find(&credits).Error
+	return credits, err
+}
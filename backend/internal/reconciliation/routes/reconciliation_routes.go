@@ -0,0 +1,38 @@
+package routes
+
+import (
+	boletoDomain "portarius/internal/boleto/domain"
+	boletoRepository "portarius/internal/boleto/repository"
+	"portarius/internal/reconciliation/domain"
+	reconciliationHandler "portarius/internal/reconciliation/handler"
+	"portarius/internal/reconciliation/repository"
+	reconciliationService "portarius/internal/reconciliation/service"
+	reservationDomain "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterReconciliationRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	var (
+		repo            domain.IReconciliationRepository         = repository.NewReconciliationRepository(db)
+		reservationRepo reservationDomain.IReservationRepository = reservationRepository.NewReservationRepository(db)
+		boletoRepo      boletoDomain.IBoletoRepository           = boletoRepository.NewBoletoRepository(db)
+	)
+
+	service := reconciliationService.NewReconciliationService(db, repo, reservationRepo, boletoRepo)
+	handler := reconciliationHandler.NewReconciliationHandler(repo, service)
+
+	reconciliation := router.Group("/reconciliation")
+	{
+		reconciliation.POST("/import", handler.Import)
+		reconciliation.GET("/imports", handler.GetImports)
+		reconciliation.GET("/imports/:id/credits", handler.GetImportCredits)
+		reconciliation.GET("/review", handler.GetReviewQueue)
+		reconciliation.POST("/credits/:id/resolve", handler.Resolve)
+		reconciliation.POST("/credits/:id/dismiss", handler.Dismiss)
+		reconciliation.GET("/report", handler.GetReport)
+		reconciliation.GET("/creditStatus", handler.ListCreditStatuses)
+	}
+}
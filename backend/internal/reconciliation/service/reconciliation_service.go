@@ -0,0 +1,310 @@
+package reconciliation
+
+import (
+	"errors"
+	"fmt"
+	boletoDomain "portarius/internal/boleto/domain"
+	boletoRepository "portarius/internal/boleto/repository"
+	"portarius/internal/eventbus"
+	"portarius/internal/reconciliation/domain"
+	reconciliationRepository "portarius/internal/reconciliation/repository"
+	reminderDomain "portarius/internal/reminder/domain"
+	reservationDomain "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ImportResult represents the summary of an imported statement and the credits it produced
+type ImportResult struct {
+	Import  *domain.StatementImport  `json:"import"`
+	Credits []domain.StatementCredit `json:"credits"`
+}
+
+// ErrInvalidStatement wraps the errors of a file that cannot be read as a statement
+var ErrInvalidStatement = errors.New("arquivo de extrato inválido")
+
+type paidReservation struct {
+	reservation *reservationDomain.Reservation
+	wasPending  bool
+}
+
+type ReconciliationService struct {
+	db              *gorm.DB
+	repo            domain.IReconciliationRepository
+	reservationRepo reservationDomain.IReservationRepository
+	boletoRepo      boletoDomain.IBoletoRepository
+}
+
+func NewReconciliationService(db *gorm.DB, repo domain.IReconciliationRepository, reservationRepo reservationDomain.IReservationRepository, boletoRepo boletoDomain.IBoletoRepository) *ReconciliationService {
+	return &ReconciliationService{db: db, repo: repo, reservationRepo: reservationRepo, boletoRepo: boletoRepo}
+}
+
+func (s *ReconciliationService) Import(fileName string, format domain.StatementFormat, data []byte) (*ImportResult, error) {
+	if format == "" {
+		detected, err := domain.DetectFormat(fileName, data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+		}
+		format = detected
+	}
+
+	parsed, err := domain.ParseStatement(format, data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidStatement, err)
+	}
+
+	pending, err := s.reservationRepo.FindPendingPayments()
+	if err != nil {
+		return nil, fmt.Errorf("erro ao buscar reservas pendentes: %v", err)
+	}
+
+	boletos := []boletoDomain.Boleto{}
+	if format != domain.FormatOFX {
+		boletos, err = s.boletoRepo.GetByStatus(boletoDomain.StatusIssued, boletoDomain.StatusOverdue)
+		if err != nil {
+			return nil, fmt.Errorf("erro ao buscar boletos em aberto: %v", err)
+		}
+	}
+
+	statementImport := &domain.StatementImport{
+		FileName:   fileName,
+		Format:     format,
+		ImportedAt: time.Now(),
+	}
+	credits := []domain.StatementCredit{}
+	paid := []paidReservation{}
+
+	// the import record, the boletos, the reservations and the credits are saved together, so a
+	// failure halfway does not leave reservations paid by credits that were never recorded
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		repo := reconciliationRepository.NewReconciliationRepository(tx)
+		reservationRepo := reservationRepository.NewReservationRepository(tx)
+		boletoRepo := boletoRepository.NewBoletoRepository(tx)
+
+		if err := repo.CreateImport(statementImport); err != nil {
+			return err
+		}
+
+		for _, item := range parsed {
+			credit := domain.NewStatementCredit(statementImport.ID, format, item)
+
+			exists, err := repo.ExistsCredit(credit.Fingerprint)
+			if err != nil {
+				return err
+			}
+			if exists {
+				statementImport.Duplicates++
+				continue
+			}
+
+			var result domain.MatchResult
+			if boleto := domain.FindBoleto(&credit, boletos); boleto != nil {
+				result = domain.MatchBoletoCredit(&credit, boleto, pending)
+				credit.BoletoID = &boleto.ID
+				if result.Status == domain.CreditMatched {
+					boleto.MarkAsPaid(credit.Date, credit.Amount)
+					if err := boletoRepo.Update(boleto); err != nil {
+						return err
+					}
+				}
+			} else {
+				result = domain.MatchCredit(&credit, pending)
+			}
+
+			credit.Status = result.Status
+			credit.SetCandidates(result.Candidates)
+
+			if result.Reservation != nil {
+				wasPending, err := applyPayment(reservationRepo, result.Reservation, &credit)
+				if err != nil {
+					return err
+				}
+				paid = append(paid, paidReservation{reservation: result.Reservation, wasPending: wasPending})
+				pending = removeReservation(pending, result.Reservation.ID)
+			}
+
+			if err := repo.CreateCredit(&credit); err != nil {
+				return err
+			}
+
+			statementImport.Credits++
+			switch credit.Status {
+			case domain.CreditMatched:
+				statementImport.Matched++
+			case domain.CreditInReview:
+				statementImport.InReview++
+			default:
+				statementImport.Unmatched++
+			}
+
+			credits = append(credits, credit)
+		}
+
+		return repo.UpdateImport(statementImport)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, payment := range paid {
+		publishPayment(payment.reservation, payment.wasPending)
+	}
+
+	return &ImportResult{Import: statementImport, Credits: credits}, nil
+}
+
+// Resolve links a credit waiting for review, or one that was not matched, to a reservation chosen by the operator.
+func (s *ReconciliationService) Resolve(creditID, reservationID uint) (*domain.StatementCredit, error) {
+	credit, err := s.repo.GetCreditByID(creditID)
+	if err != nil {
+		return nil, fmt.Errorf("crédito não encontrado")
+	}
+
+	if credit.Status == domain.CreditMatched || credit.Status == domain.CreditDismissed {
+		return nil, fmt.Errorf("crédito já foi conciliado ou descartado")
+	}
+
+	reservation, err := s.reservationRepo.GetByID(reservationID)
+	if err != nil {
+		return nil, fmt.Errorf("reserva não encontrada")
+	}
+
+	if err := reservation.CanConfirmPayment(); err != nil {
+		return nil, err
+	}
+	if reservation.PaymentStatus != reservationDomain.PaymentPending {
+		return nil, fmt.Errorf("a reserva não está aguardando pagamento")
+	}
+
+	var wasPending bool
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		wasPending, err = applyPayment(reservationRepository.NewReservationRepository(tx), reservation, credit)
+		if err != nil {
+			return err
+		}
+
+		credit.Status = domain.CreditMatched
+		return reconciliationRepository.NewReconciliationRepository(tx).UpdateCredit(credit)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishPayment(reservation, wasPending)
+	credit.Reservation = reservation
+	return credit, nil
+}
+
+// Dismiss marks a credit as unrelated to reservations so it leaves the review queue and the report.
+func (s *ReconciliationService) Dismiss(creditID uint) (*domain.StatementCredit, error) {
+	credit, err := s.repo.GetCreditByID(creditID)
+	if err != nil {
+		return nil, fmt.Errorf("crédito não encontrado")
+	}
+
+	if credit.Status == domain.CreditMatched {
+		return nil, fmt.Errorf("crédito já foi conciliado")
+	}
+
+	now := time.Now()
+	credit.Status = domain.CreditDismissed
+	credit.ResolvedAt = &now
+
+	if err := s.repo.UpdateCredit(credit); err != nil {
+		return nil, err
+	}
+	return credit, nil
+}
+
+func (s *ReconciliationService) GetReviewQueue() ([]domain.StatementCredit, error) {
+	return s.repo.GetCreditsByStatus(domain.CreditInReview, time.Time{}, time.Time{})
+}
+
+func (s *ReconciliationService) Report(from, to time.Time) (*domain.ReconciliationReport, error) {
+	unmatched, err := s.repo.GetCreditsByStatus(domain.CreditUnmatched, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	inReview, err := s.repo.GetCreditsByStatus(domain.CreditInReview, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	pending, err := s.reservationRepo.FindPendingPayments()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &domain.ReconciliationReport{
+		UnmatchedCredits:   unmatched,
+		InReviewCredits:    inReview,
+		UnpaidReservations: []reservationDomain.Reservation{},
+	}
+
+	for _, credit := range unmatched {
+		report.UnmatchedAmount += credit.Amount
+	}
+
+	for _, reservation := range pending {
+		if !from.IsZero() && reservation.StartTime.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !reservation.StartTime.Before(to) {
+			continue
+		}
+		report.UnpaidReservations = append(report.UnpaidReservations, reservation)
+		report.UnpaidAmount += reservation.PaymentAmount
+	}
+
+	return report, nil
+}
+
+// applyPayment marks the reservation as paid by the credit, confirming it when it was still pending
+func applyPayment(reservationRepo reservationDomain.IReservationRepository, reservation *reservationDomain.Reservation, credit *domain.StatementCredit) (bool, error) {
+	wasPending := reservation.Status == reservationDomain.StatusPending
+
+	paymentDate := credit.Date
+	reservation.PaymentStatus = reservationDomain.PaymentPaid
+	reservation.PaymentDate = &paymentDate
+	if wasPending {
+		reservation.Status = reservationDomain.StatusConfirmed
+	}
+
+	if err := reservationRepo.Update(reservation); err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	credit.ReservationID = &reservation.ID
+	credit.ResolvedAt = &now
+	return wasPending, nil
+}
+
+// publishPayment notifies the resident once the payment is committed
+func publishPayment(reservation *reservationDomain.Reservation, wasPending bool) {
+	channel := string(reminderDomain.ReminderChannelWhatsApp)
+	eventbus.Publish("ReservationPaid", &eventbus.ReservationPaidEvent{
+		ReservationID: &reservation.ID,
+		Channel:       channel,
+	})
+
+	if wasPending {
+		eventbus.Publish("ReservationConfirmed", &eventbus.ReservationConfirmedEvent{
+			ReservationID: &reservation.ID,
+			Channel:       channel,
+		})
+	}
+}
+
+func removeReservation(reservations []reservationDomain.Reservation, id uint) []reservationDomain.Reservation {
+	remaining := make([]reservationDomain.Reservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		if reservation.ID != id {
+			remaining = append(remaining, reservation)
+		}
+	}
+	return remaining
+}
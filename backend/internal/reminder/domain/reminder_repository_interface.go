@@ -16,5 +16,6 @@ type IReminderRepository interface {
 	GetByPendingStatus() ([]Reminder, error)
 	GetPendingRemindersFromReservations() ([]Reminder, error)
 	GetPendingRemindersFromPackages() ([]Reminder, error)
+	CancelPendingByPackageID(packageID uint) error
 	GetPendingRemindersFromReservationsForToday(now time.Time) ([]Reminder, error)
 }
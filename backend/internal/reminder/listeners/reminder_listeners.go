@@ -1,13 +1,17 @@
 package listeners
 
 import (
+	"fmt"
+	"os"
 	"portarius/internal/eventbus"
 	holydayHandler "portarius/internal/holyday/handler"
 	packageDomain "portarius/internal/package/domain"
+	pixDomain "portarius/internal/pix/domain"
 	reminderDomain "portarius/internal/reminder/domain"
 	reservationDomain "portarius/internal/reservation/domain"
 	residentDomain "portarius/internal/resident/domain"
 	whatsAppDomain "portarius/internal/whatsapp/domain"
+	"strings"
 	"time"
 )
 
@@ -25,10 +29,17 @@ func RegisterReminderListeners(reminderRepository reminderDomain.IReminderReposi
 	whatsappHandler = handler
 
 	eventbus.Subscribe("PackageCreated", onPackageCreated)
+	eventbus.Subscribe("PackagesBatchCreated", onPackagesBatchCreated)
+	eventbus.Subscribe("PackageReturnRegistered", onPackageReturnRegistered)
 	eventbus.Subscribe("ReservationCreated", onReservationCreated)
 	eventbus.Subscribe("SendPackageReminder", onSendPackageReminder)
 	eventbus.Subscribe("SendReservationReminder", onSendReservationReminder)
 	eventbus.Subscribe("UpdateStatusReminder", onUpdateStatusReminder)
+	eventbus.Subscribe("ReservationConfirmed", onReservationConfirmed)
+	eventbus.Subscribe("ReservationInspected", onReservationInspected)
+	eventbus.Subscribe("ReservationKeysOverdue", onReservationKeysOverdue)
+	eventbus.Subscribe("ReservationDocumentReady", onReservationDocumentReady)
+	eventbus.Subscribe("UnlistedGuestArrived", onUnlistedGuestArrived)
 }
 
 func onPackageCreated(e eventbus.Event) {
@@ -51,6 +62,37 @@ func onPackageCreated(e eventbus.Event) {
 	_ = reminderRepo.Create(&reminder)
 }
 
+func onPackagesBatchCreated(e eventbus.Event) {
+	event := e.(*eventbus.PackagesBatchCreatedEvent)
+	if len(event.PackageIDs) == 0 {
+		return
+	}
+
+	resident, err := residentRepo.GetByID(*event.ResidentID)
+	if err != nil {
+		return
+	}
+
+	pck, err := packageRepo.GetByID(event.PackageIDs[0])
+	if err != nil {
+		return
+	}
+
+	reminder := reminderDomain.Reminder{
+		PackageID:   &event.PackageIDs[0],
+		Recipient:   resident.Phone,
+		Channel:     reminderDomain.ReminderChannel(event.Channel),
+		Status:      reminderDomain.ReminderStatusPending,
+		ScheduledAt: time.Now(),
+	}
+
+	if err := reminderRepo.Create(&reminder); err != nil {
+		return
+	}
+
+	whatsappHandler.SendPackageBatchNotification(reminder.ID, reminder.Recipient, resident.Name, len(event.PackageIDs), pck.PickupCode)
+}
+
 func onReservationCreated(e eventbus.Event) {
 	event := e.(*eventbus.ReservationCreatedEvent)
 
@@ -82,7 +124,18 @@ func onSendPackageReminder(e eventbus.Event) {
 		return
 	}
 
-	whatsappHandler.SendPackageNotification(*event.ReminderID, event.Phone, pck.Resident.Name)
+	if pck.Status != packageDomain.PackagePending {
+		_ = reminderRepo.CancelPendingByPackageID(pck.ID)
+		return
+	}
+
+	whatsappHandler.SendPackageNotification(*event.ReminderID, event.Phone, pck.Resident.Name, pck.PickupCode)
+}
+
+func onPackageReturnRegistered(e eventbus.Event) {
+	event := e.(*eventbus.PackageReturnRegisteredEvent)
+
+	_ = reminderRepo.CancelPendingByPackageID(*event.PackageID)
 }
 
 func onSendReservationReminder(e eventbus.Event) {
@@ -110,3 +163,165 @@ func onUpdateStatusReminder(e eventbus.Event) {
 	}
 
 }
+
+func onReservationConfirmed(e eventbus.Event) {
+	event := e.(*eventbus.ReservationConfirmedEvent)
+
+	reservation, err := reservationRepo.GetByID(*event.ReservationID)
+	if err != nil || reservation.Resident == nil {
+		return
+	}
+
+	if reservation.PaymentMethod != reservationDomain.PaymentMethodPix || reservation.PaymentStatus != reservationDomain.PaymentPending {
+		return
+	}
+
+	code, err := pixDomain.NewBRCodeFromEnv(reservation.PaymentAmount, reservation.GetPixTxID())
+	if err != nil {
+		return
+	}
+
+	payload, err := code.Payload()
+	if err != nil {
+		return
+	}
+
+	reminder := reminderDomain.Reminder{
+		ReservationID: event.ReservationID,
+		Recipient:     reservation.Resident.Phone,
+		Channel:       reminderDomain.ReminderChannel(event.Channel),
+		Status:        reminderDomain.ReminderStatusPending,
+		ScheduledAt:   time.Now(),
+	}
+
+	if err := reminderRepo.Create(&reminder); err != nil {
+		return
+	}
+
+	date := reservation.StartTime.Format("02/01/2006")
+	amount := fmt.Sprintf("R$ %.2f", reservation.PaymentAmount)
+
+	whatsappHandler.SendReservationPixPayment(reminder.ID, reminder.Recipient, reservation.Resident.Name, reservation.GetLastCharFromSalon(), date, amount, payload)
+}
+
+func onReservationInspected(e eventbus.Event) {
+	event := e.(*eventbus.ReservationInspectedEvent)
+
+	reservation, err := reservationRepo.GetByID(*event.ReservationID)
+	if err != nil || reservation.Resident == nil {
+		return
+	}
+
+	reminder := reminderDomain.Reminder{
+		ReservationID: event.ReservationID,
+		Recipient:     reservation.Resident.Phone,
+		Channel:       reminderDomain.ReminderChannel(event.Channel),
+		Status:        reminderDomain.ReminderStatusPending,
+		ScheduledAt:   time.Now(),
+	}
+
+	if err := reminderRepo.Create(&reminder); err != nil {
+		return
+	}
+
+	charges := fmt.Sprintf("R$ %.2f", event.DamageAmount)
+
+	whatsappHandler.SendReservationInspectionReport(reminder.ID, reminder.Recipient, reservation.Resident.Name, reservation.GetLastCharFromSalon(), event.Findings, charges)
+}
+
+func onReservationKeysOverdue(e eventbus.Event) {
+	event := e.(*eventbus.ReservationKeysOverdueEvent)
+
+	reservation, err := reservationRepo.GetByID(*event.ReservationID)
+	if err != nil || reservation.Resident == nil {
+		return
+	}
+
+	hall := reservation.GetLastCharFromSalon()
+
+	reminder := reminderDomain.Reminder{
+		ReservationID: event.ReservationID,
+		Recipient:     reservation.Resident.Phone,
+		Channel:       reminderDomain.ReminderChannel(event.Channel),
+		Status:        reminderDomain.ReminderStatusPending,
+		ScheduledAt:   time.Now(),
+	}
+
+	if err := reminderRepo.Create(&reminder); err == nil {
+		whatsappHandler.SendReservationKeysOverdue(reminder.ID, reminder.Recipient, reservation.Resident.Name, hall, event.HoursOverdue)
+	}
+
+	staffPhone := os.Getenv("STAFF_ALERT_PHONE")
+	if staffPhone == "" {
+		return
+	}
+
+	staffReminder := reminderDomain.Reminder{
+		ReservationID: event.ReservationID,
+		Recipient:     staffPhone,
+		Channel:       reminderDomain.ReminderChannel(event.Channel),
+		Status:        reminderDomain.ReminderStatusPending,
+		ScheduledAt:   time.Now(),
+	}
+
+	if err := reminderRepo.Create(&staffReminder); err != nil {
+		return
+	}
+
+	unit := reservation.Resident.Block + reservation.Resident.Apartment
+
+	whatsappHandler.SendStaffKeysOverdueAlert(staffReminder.ID, staffPhone, reservation.Resident.Name, unit, hall, event.HoursOverdue, event.AlertLevel)
+}
+
+func onReservationDocumentReady(e eventbus.Event) {
+	event := e.(*eventbus.ReservationDocumentReadyEvent)
+
+	reservation, err := reservationRepo.GetByID(*event.ReservationID)
+	if err != nil || reservation.Resident == nil {
+		return
+	}
+
+	reminder := reminderDomain.Reminder{
+		ReservationID: event.ReservationID,
+		Recipient:     reservation.Resident.Phone,
+		Channel:       reminderDomain.ReminderChannel(event.Channel),
+		Status:        reminderDomain.ReminderStatusPending,
+		ScheduledAt:   time.Now(),
+	}
+
+	if err := reminderRepo.Create(&reminder); err != nil {
+		return
+	}
+
+	whatsappHandler.SendReservationDocument(reminder.ID, reminder.Recipient, reservation.Resident.Name, reservation.GetLastCharFromSalon(), event.DocumentLabel, event.FileName, event.Content)
+}
+
+func onUnlistedGuestArrived(e eventbus.Event) {
+	event := e.(*eventbus.UnlistedGuestArrivedEvent)
+
+	reservation, err := reservationRepo.GetByID(*event.ReservationID)
+	if err != nil || reservation.Resident == nil {
+		return
+	}
+
+	reminder := reminderDomain.Reminder{
+		ReservationID: event.ReservationID,
+		Recipient:     reservation.Resident.Phone,
+		Channel:       reminderDomain.ReminderChannel(event.Channel),
+		Status:        reminderDomain.ReminderStatusPending,
+		ScheduledAt:   time.Now(),
+	}
+
+	if err := reminderRepo.Create(&reminder); err != nil {
+		return
+	}
+
+	guest := []string{}
+	for _, value := range []string{event.GuestName, event.Document, event.VehiclePlate} {
+		if value != "" {
+			guest = append(guest, value)
+		}
+	}
+
+	whatsappHandler.SendReservationUnlistedGuest(reminder.ID, reminder.Recipient, reservation.Resident.Name, reservation.GetLastCharFromSalon(), strings.Join(guest, " - "))
+}
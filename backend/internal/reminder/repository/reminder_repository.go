@@ -93,6 +93,15 @@ func (r *reminderRepository) GetPendingRemindersFromPackages() ([]domain.Reminde
 	return reminders, err
 }
 
+// CancelPendingByPackageID stops the reminders of a package that will not be picked up anymore
+func (r *reminderRepository) CancelPendingByPackageID(packageID uint) error {
+	return r.db.Model(&domain.Reminder{}).
+		Where("package_id = ? AND status IN ?", packageID, []domain.ReminderStatus{
+			domain.ReminderStatusPending,
+			domain.ReminderStatusFailed}).
+		Update("status", domain.ReminderStatusCancelled).Error
+}
+
 func (r *reminderRepository) GetPendingRemindersFromReservationsForToday(now time.Time) ([]domain.Reminder, error) {
 	var reminders []domain.Reminder
 
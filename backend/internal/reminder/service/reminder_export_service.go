@@ -0,0 +1,90 @@
+package service
+
+import (
+	"net/url"
+	exportDomain "portarius/internal/export/domain"
+	"portarius/internal/reminder/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ReminderExporter exports reminders filtered by status, channel, recipient, reservation and package
+type ReminderExporter struct{}
+
+func NewReminderExporter() *ReminderExporter {
+	return &ReminderExporter{}
+}
+
+func (e *ReminderExporter) Entity() exportDomain.ExportEntity {
+	return exportDomain.ExportEntityReminders
+}
+
+func (e *ReminderExporter) Columns() []exportDomain.Column {
+	return []exportDomain.Column{
+		{Key: "id", Header: "ID"},
+		{Key: "recipient", Header: "Destinatário"},
+		{Key: "channel", Header: "Canal"},
+		{Key: "status", Header: "Status"},
+		{Key: "scheduled_at", Header: "Agendado para"},
+		{Key: "sent_at", Header: "Enviado em"},
+		{Key: "reservation_id", Header: "ID da reserva"},
+		{Key: "package_id", Header: "ID da encomenda"},
+	}
+}
+
+func (e *ReminderExporter) Query(db *gorm.DB, filters url.Values) (*gorm.DB, error) {
+	query := db.Model(&domain.Reminder{})
+
+	if status := filters.Get("status"); status != "" {
+		query = query.Where("status = ?", strings.ToUpper(status))
+	}
+	if channel := filters.Get("channel"); channel != "" {
+		query = query.Where("channel = ?", strings.ToUpper(channel))
+	}
+	if recipient := filters.Get("recipient"); recipient != "" {
+		query = query.Where("recipient = ?", recipient)
+	}
+	if filters.Get("pending") == "true" {
+		query = query.Where("status IN ?", []domain.ReminderStatus{domain.ReminderStatusPending, domain.ReminderStatusFailed})
+	}
+
+	reservationID, err := exportDomain.FilterID(filters, "reservationId")
+	if err != nil {
+		return nil, err
+	}
+	if reservationID != nil {
+		query = query.Where("reservation_id = ?", *reservationID)
+	}
+
+	packageID, err := exportDomain.FilterID(filters, "packageId")
+	if err != nil {
+		return nil, err
+	}
+	if packageID != nil {
+		query = query.Where("package_id = ?", *packageID)
+	}
+
+	return query, nil
+}
+
+func (e *ReminderExporter) Export(query *gorm.DB, write func(values []any) error) error {
+	var reminders []domain.Reminder
+	return query.FindInBatches(&reminders, exportDomain.ExportBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, reminder := range reminders {
+			if err := write([]any{
+				reminder.ID,
+				reminder.Recipient,
+				reminder.Channel,
+				reminder.Status,
+				reminder.ScheduledAt,
+				reminder.SentAt,
+				reminder.ReservationID,
+				reminder.PackageID,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
@@ -0,0 +1,393 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type RecurrenceFrequency string
+
+const (
+	FrequencyDaily   RecurrenceFrequency = "DAILY"
+	FrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	FrequencyMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// maxRecurrencePeriods bounds the expansion of a rule so a malformed rule can never loop forever.
+// It is large enough to expand a daily rule over a whole calendar cycle.
+const maxRecurrencePeriods = 50000
+
+// calendarCycleMonths is how long the calendar takes to repeat its dates on the same weekdays:
+// 28 years, which hold 10227 days, a whole number of weeks, between 1901 and 2099.
+const calendarCycleMonths = 28 * 12
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// RecurrenceDay represents a BYDAY entry. Ordinal is only used by monthly rules: 1MO is the first
+// Monday of the month, -1FR the last Friday and 0 every matching weekday.
+type RecurrenceDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule represents the subset of the iCalendar RRULE used by recurring reservations:
+// FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL.
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1}
+
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, fmt.Errorf("regra de recorrência vazia")
+	}
+
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("parte inválida na regra de recorrência: %s", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(strings.ToUpper(val))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("INTERVAL inválido: %s", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("COUNT inválido: %s", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, fmt.Errorf("UNTIL inválido: %s", val)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(strings.ToUpper(val), ",") {
+				day, err := parseRecurrenceDay(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, item := range strings.Split(val, ",") {
+				day, err := strconv.Atoi(item)
+				if err != nil || day == 0 || day < -31 || day > 31 {
+					return nil, fmt.Errorf("BYMONTHDAY inválido: %s", item)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		default:
+			return nil, fmt.Errorf("parâmetro não suportado na regra de recorrência: %s", key)
+		}
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *RecurrenceRule) validate() error {
+	switch r.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return fmt.Errorf("frequência não suportada: %s", r.Frequency)
+	}
+
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("COUNT e UNTIL não podem ser usados juntos")
+	}
+
+	if r.Frequency != FrequencyMonthly {
+		if len(r.ByMonthDay) > 0 {
+			return fmt.Errorf("BYMONTHDAY só pode ser usado com FREQ=MONTHLY")
+		}
+		for _, day := range r.ByDay {
+			if day.Ordinal != 0 {
+				return fmt.Errorf("BYDAY com posição só pode ser usado com FREQ=MONTHLY")
+			}
+		}
+	}
+
+	if len(r.ByDay) > 0 && len(r.ByMonthDay) > 0 {
+		return fmt.Errorf("BYDAY e BYMONTHDAY não podem ser usados juntos")
+	}
+
+	return nil
+}
+
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency)}
+
+	if r.Interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			code := weekdayCode(day.Weekday)
+			if day.Ordinal != 0 {
+				code = strconv.Itoa(day.Ordinal) + code
+			}
+			codes = append(codes, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, 0, len(r.ByMonthDay))
+		for _, day := range r.ByMonthDay {
+			days = append(days, strconv.Itoa(day))
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, fmt.Sprintf("COUNT=%d", r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences returns the start of every occurrence of the rule, beginning at dtstart, that falls between from and to (inclusive).
+func (r *RecurrenceRule) Occurrences(dtstart, from, to time.Time) []time.Time {
+	occurrences := []time.Time{}
+	emitted := 0
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		candidates, periodStart := r.periodCandidates(dtstart, period*r.Interval)
+		if periodStart.After(to) {
+			break
+		}
+
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && candidate.After(*r.Until) {
+				return occurrences
+			}
+			if r.Count > 0 && emitted >= r.Count {
+				return occurrences
+			}
+			emitted++
+
+			if candidate.After(to) {
+				return occurrences
+			}
+			if !candidate.Before(from) {
+				occurrences = append(occurrences, candidate)
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// CountBefore returns how many occurrences start before the given time, used to split rules limited by COUNT.
+func (r *RecurrenceRule) CountBefore(dtstart, before time.Time) int {
+	if !before.After(dtstart) {
+		return 0
+	}
+	return len(r.Occurrences(dtstart, dtstart, before.Add(-time.Second)))
+}
+
+// IsFinite reports whether the rule ends, by COUNT or UNTIL.
+func (r *RecurrenceRule) IsFinite() bool {
+	return r.Count > 0 || r.Until != nil
+}
+
+// CycleDays returns after how many days the occurrences of the rule repeat on the same days and times:
+// the interval of daily and weekly rules and, for monthly rules, the calendar cycle.
+func (r *RecurrenceRule) CycleDays(dtstart time.Time) int {
+	switch r.Frequency {
+	case FrequencyDaily:
+		return r.Interval
+	case FrequencyWeekly:
+		return 7 * r.Interval
+	default:
+		months := lcm(r.Interval, calendarCycleMonths)
+		return int((dtstart.AddDate(0, months, 0).Unix() - dtstart.Unix()) / (24 * 60 * 60))
+	}
+}
+
+func (r *RecurrenceRule) periodCandidates(dtstart time.Time, offset int) ([]time.Time, time.Time) {
+	hour, minute, second := dtstart.Clock()
+	loc := dtstart.Location()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, second, 0, loc)
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		day := at(dtstart.Year(), dtstart.Month(), dtstart.Day()+offset)
+		return []time.Time{day}, day
+
+	case FrequencyWeekly:
+		weekStart := at(dtstart.Year(), dtstart.Month(), dtstart.Day()-mondayOffset(dtstart.Weekday())+offset*7)
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []RecurrenceDay{{Weekday: dtstart.Weekday()}}
+		}
+
+		candidates := make([]time.Time, 0, len(days))
+		for _, day := range days {
+			candidates = append(candidates, at(weekStart.Year(), weekStart.Month(), weekStart.Day()+mondayOffset(day.Weekday)))
+		}
+		return sortUnique(candidates), weekStart
+
+	default:
+		monthStart := at(dtstart.Year(), dtstart.Month()+time.Month(offset), 1)
+		year, month := monthStart.Year(), monthStart.Month()
+		lastDay := at(year, month+1, 0).Day()
+
+		candidates := []time.Time{}
+
+		switch {
+		case len(r.ByDay) > 0:
+			for _, day := range r.ByDay {
+				matches := monthWeekdays(year, month, lastDay, day.Weekday)
+				if day.Ordinal == 0 {
+					for _, date := range matches {
+						candidates = append(candidates, at(year, month, date))
+					}
+					continue
+				}
+
+				index := day.Ordinal - 1
+				if day.Ordinal < 0 {
+					index = len(matches) + day.Ordinal
+				}
+				if index >= 0 && index < len(matches) {
+					candidates = append(candidates, at(year, month, matches[index]))
+				}
+			}
+		case len(r.ByMonthDay) > 0:
+			for _, day := range r.ByMonthDay {
+				if day < 0 {
+					day = lastDay + day + 1
+				}
+				if day >= 1 && day <= lastDay {
+					candidates = append(candidates, at(year, month, day))
+				}
+			}
+		default:
+			if dtstart.Day() <= lastDay {
+				candidates = append(candidates, at(year, month, dtstart.Day()))
+			}
+		}
+
+		return sortUnique(candidates), monthStart
+	}
+}
+
+func monthWeekdays(year int, month time.Month, lastDay int, weekday time.Weekday) []int {
+	days := []int{}
+	for day := 1; day <= lastDay; day++ {
+		if time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() == weekday {
+			days = append(days, day)
+		}
+	}
+	return days
+}
+
+func parseRecurrenceDay(code string) (RecurrenceDay, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 2 {
+		return RecurrenceDay{}, fmt.Errorf("BYDAY inválido: %s", code)
+	}
+
+	weekday, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return RecurrenceDay{}, fmt.Errorf("BYDAY inválido: %s", code)
+	}
+
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := code[:len(code)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceDay{}, fmt.Errorf("BYDAY inválido: %s", code)
+		}
+		day.Ordinal = ordinal
+	}
+	return day, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if until, err := time.Parse(layout, value); err == nil {
+			if layout == "20060102" {
+				until = until.Add(24*time.Hour - time.Second)
+			}
+			return until, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data inválida")
+}
+
+func weekdayCode(weekday time.Weekday) string {
+	for code, day := range weekdayCodes {
+		if day == weekday {
+			return code
+		}
+	}
+	return ""
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+func lcm(a, b int) int {
+	return a / gcd(a, b) * b
+}
+
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+func sortUnique(times []time.Time) []time.Time {
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	unique := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			unique = append(unique, t)
+		}
+	}
+	return unique
+}
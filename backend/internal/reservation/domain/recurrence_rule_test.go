@@ -0,0 +1,283 @@
+package domain_test
+
+import (
+	"portarius/internal/reservation/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func date(year int, month time.Month, day, hour int) time.Time {
+	return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "weekly", value: "FREQ=WEEKLY;BYDAY=MO", expected: "FREQ=WEEKLY;BYDAY=MO"},
+		{name: "with prefix and lowercase", value: "RRULE:freq=monthly;byday=1mo", expected: "FREQ=MONTHLY;BYDAY=1MO"},
+		{name: "interval and count", value: "FREQ=DAILY;INTERVAL=2;COUNT=5", expected: "FREQ=DAILY;INTERVAL=2;COUNT=5"},
+		{name: "until date", value: "FREQ=WEEKLY;UNTIL=20251231", expected: "FREQ=WEEKLY;UNTIL=20251231T235959Z"},
+		{name: "empty", value: "", wantErr: true},
+		{name: "yearly", value: "FREQ=YEARLY", wantErr: true},
+		{name: "count and until", value: "FREQ=DAILY;COUNT=2;UNTIL=20251231", wantErr: true},
+		{name: "ordinal on weekly", value: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "invalid weekday", value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "unsupported part", value: "FREQ=WEEKLY;BYHOUR=8", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := domain.ParseRecurrenceRule(tt.value)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.String())
+		})
+	}
+}
+
+func TestRecurrenceRule_Occurrences(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     string
+		dtstart  time.Time
+		from     time.Time
+		to       time.Time
+		expected []time.Time
+	}{
+		{
+			name:     "every monday morning",
+			rule:     "FREQ=WEEKLY;BYDAY=MO",
+			dtstart:  date(2025, time.November, 3, 8),
+			from:     date(2025, time.November, 1, 0),
+			to:       date(2025, time.November, 20, 0),
+			expected: []time.Time{date(2025, time.November, 3, 8), date(2025, time.November, 10, 8), date(2025, time.November, 17, 8)},
+		},
+		{
+			name:     "every other week on tuesday and thursday",
+			rule:     "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			dtstart:  date(2025, time.November, 4, 19),
+			from:     date(2025, time.November, 1, 0),
+			to:       date(2025, time.November, 30, 0),
+			expected: []time.Time{date(2025, time.November, 4, 19), date(2025, time.November, 6, 19), date(2025, time.November, 18, 19), date(2025, time.November, 20, 19)},
+		},
+		{
+			name:     "first monday of the month",
+			rule:     "FREQ=MONTHLY;BYDAY=1MO",
+			dtstart:  date(2025, time.November, 3, 19),
+			from:     date(2025, time.November, 1, 0),
+			to:       date(2026, time.January, 31, 0),
+			expected: []time.Time{date(2025, time.November, 3, 19), date(2025, time.December, 1, 19), date(2026, time.January, 5, 19)},
+		},
+		{
+			name:     "last friday of the month",
+			rule:     "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart:  date(2025, time.November, 28, 18),
+			from:     date(2025, time.November, 1, 0),
+			to:       date(2026, time.January, 31, 0),
+			expected: []time.Time{date(2025, time.November, 28, 18), date(2025, time.December, 26, 18), date(2026, time.January, 30, 18)},
+		},
+		{
+			name:     "day 31 skips short months",
+			rule:     "FREQ=MONTHLY",
+			dtstart:  date(2025, time.October, 31, 9),
+			from:     date(2025, time.October, 1, 0),
+			to:       date(2026, time.January, 31, 23),
+			expected: []time.Time{date(2025, time.October, 31, 9), date(2025, time.December, 31, 9), date(2026, time.January, 31, 9)},
+		},
+		{
+			name:     "count is applied from the start",
+			rule:     "FREQ=DAILY;COUNT=3",
+			dtstart:  date(2025, time.November, 1, 8),
+			from:     date(2025, time.November, 2, 0),
+			to:       date(2025, time.November, 30, 0),
+			expected: []time.Time{date(2025, time.November, 2, 8), date(2025, time.November, 3, 8)},
+		},
+		{
+			name:     "until",
+			rule:     "FREQ=WEEKLY;UNTIL=20251117",
+			dtstart:  date(2025, time.November, 3, 8),
+			from:     date(2025, time.November, 1, 0),
+			to:       date(2025, time.December, 31, 0),
+			expected: []time.Time{date(2025, time.November, 3, 8), date(2025, time.November, 10, 8), date(2025, time.November, 17, 8)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := domain.ParseRecurrenceRule(tt.rule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.Occurrences(tt.dtstart, tt.from, tt.to))
+		})
+	}
+}
+
+func TestReservationSeries_Occurrences(t *testing.T) {
+	moved := date(2025, time.November, 11, 14)
+	movedEnd := date(2025, time.November, 11, 16)
+
+	series := domain.ReservationSeries{
+		Space:     domain.Salon1,
+		Kind:      domain.SeriesKindMaintenance,
+		Title:     "Limpeza da academia",
+		RRule:     "FREQ=WEEKLY;BYDAY=MO",
+		StartTime: date(2025, time.November, 3, 8),
+		EndTime:   date(2025, time.November, 3, 10),
+		Exceptions: []domain.ReservationSeriesException{
+			{OccurrenceStart: date(2025, time.November, 10, 8), StartTime: &moved, EndTime: &movedEnd},
+			{OccurrenceStart: date(2025, time.November, 17, 8), Cancelled: true},
+		},
+	}
+
+	occurrences := series.Occurrences(date(2025, time.November, 1, 0), date(2025, time.November, 25, 0))
+	require.Len(t, occurrences, 3)
+	assert.Equal(t, date(2025, time.November, 3, 8), occurrences[0].StartTime)
+	assert.Equal(t, moved, occurrences[1].StartTime)
+	assert.True(t, occurrences[1].Modified)
+	assert.Equal(t, date(2025, time.November, 10, 8), occurrences[1].OccurrenceStart)
+	assert.Equal(t, date(2025, time.November, 24, 8), occurrences[2].StartTime)
+
+	assert.NotNil(t, series.Overlaps(date(2025, time.November, 24, 9), date(2025, time.November, 24, 12)))
+	assert.Nil(t, series.Overlaps(date(2025, time.November, 24, 10), date(2025, time.November, 24, 12)))
+	assert.Nil(t, series.Overlaps(date(2025, time.November, 17, 8), date(2025, time.November, 17, 9)))
+}
+
+func TestReservationSeries_Split(t *testing.T) {
+	series := domain.ReservationSeries{
+		Space:     domain.Salon2,
+		Title:     "Reunião da associação",
+		RRule:     "FREQ=MONTHLY;BYDAY=1MO;COUNT=6",
+		StartTime: date(2025, time.November, 3, 19),
+		EndTime:   date(2025, time.November, 3, 22),
+	}
+
+	splitAt := date(2026, time.January, 5, 19)
+	newStart := date(2026, time.January, 5, 20)
+
+	following, err := series.SplitAt(splitAt, domain.SeriesChange{StartTime: &newStart})
+	require.NoError(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;BYDAY=1MO;COUNT=4", following.RRule)
+	assert.Equal(t, date(2026, time.January, 5, 23), following.EndTime)
+
+	remaining, err := series.EndBefore(splitAt)
+	require.NoError(t, err)
+	assert.True(t, remaining)
+	assert.Equal(t, "FREQ=MONTHLY;BYDAY=1MO;COUNT=2", series.RRule)
+
+	remaining, err = series.EndBefore(series.StartTime)
+	require.NoError(t, err)
+	assert.False(t, remaining)
+}
+
+func TestRecurrenceRule_CycleDays(t *testing.T) {
+	start := date(2025, time.January, 6, 10)
+
+	tests := []struct {
+		rrule    string
+		expected int
+	}{
+		{"FREQ=DAILY", 1},
+		{"FREQ=DAILY;INTERVAL=3", 3},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", 14},
+		{"FREQ=MONTHLY;BYMONTHDAY=13", 10227},
+		{"FREQ=MONTHLY;INTERVAL=2;BYDAY=1MO", 10227},
+		{"FREQ=MONTHLY;INTERVAL=12", 10227},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rrule, func(t *testing.T) {
+			rule, err := domain.ParseRecurrenceRule(tt.rrule)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rule.CycleDays(start))
+		})
+	}
+}
+
+func TestReservationSeries_End(t *testing.T) {
+	series := domain.ReservationSeries{
+		RRule:     "FREQ=WEEKLY;BYDAY=MO;COUNT=60",
+		StartTime: date(2025, time.January, 6, 10),
+		EndTime:   date(2025, time.January, 6, 12),
+	}
+
+	end, ok := series.End()
+	require.True(t, ok)
+	assert.Equal(t, date(2026, time.February, 23, 12), end)
+
+	series.RRule = "FREQ=DAILY;UNTIL=20250110"
+	end, ok = series.End()
+	require.True(t, ok)
+	assert.Equal(t, date(2025, time.January, 10, 12), end)
+
+	series.RRule = "FREQ=DAILY"
+	_, ok = series.End()
+	assert.False(t, ok)
+}
+
+func TestReservationSeries_ConflictWith(t *testing.T) {
+	from := date(2025, time.January, 1, 0)
+
+	// every 500 days the cleaning falls on another weekday and only meets the Thursday meetings after a year
+	cleaning := domain.ReservationSeries{
+		Title:     "Limpeza geral",
+		RRule:     "FREQ=DAILY;INTERVAL=500",
+		StartTime: date(2025, time.January, 6, 10),
+		EndTime:   date(2025, time.January, 6, 12),
+	}
+	meetings := domain.ReservationSeries{
+		Title:     "Reunião",
+		RRule:     "FREQ=WEEKLY;BYDAY=TH",
+		StartTime: date(2025, time.January, 9, 11),
+		EndTime:   date(2025, time.January, 9, 13),
+	}
+
+	conflict := cleaning.ConflictWith(&meetings, from)
+	require.NotNil(t, conflict)
+	assert.Equal(t, date(2026, time.May, 21, 10), conflict.StartTime)
+
+	conflict = meetings.ConflictWith(&cleaning, from)
+	require.NotNil(t, conflict)
+	assert.Equal(t, date(2026, time.May, 21, 11), conflict.StartTime)
+
+	// the meetings end before the second cleaning
+	meetings.RRule = "FREQ=WEEKLY;BYDAY=TH;UNTIL=20260501"
+	assert.Nil(t, cleaning.ConflictWith(&meetings, from))
+
+	// the meetings start after the cleaning is over
+	meetings.RRule = "FREQ=WEEKLY;BYDAY=TH"
+	meetings.StartTime = date(2025, time.January, 9, 14)
+	meetings.EndTime = date(2025, time.January, 9, 16)
+	assert.Nil(t, cleaning.ConflictWith(&meetings, from))
+
+	// the first Friday the 13th of 2025 is in June
+	monthly := domain.ReservationSeries{
+		RRule:     "FREQ=MONTHLY;BYMONTHDAY=13",
+		StartTime: date(2025, time.January, 13, 19),
+		EndTime:   date(2025, time.January, 13, 22),
+	}
+	fridays := domain.ReservationSeries{
+		RRule:     "FREQ=WEEKLY;BYDAY=FR",
+		StartTime: date(2025, time.January, 3, 20),
+		EndTime:   date(2025, time.January, 3, 23),
+	}
+
+	conflict = monthly.ConflictWith(&fridays, from)
+	require.NotNil(t, conflict)
+	assert.Equal(t, date(2025, time.June, 13, 19), conflict.StartTime)
+
+	fridays.Exceptions = []domain.ReservationSeriesException{
+		{OccurrenceStart: date(2025, time.June, 13, 20), Cancelled: true},
+	}
+	conflict = monthly.ConflictWith(&fridays, from)
+	require.NotNil(t, conflict)
+	assert.Equal(t, date(2026, time.February, 13, 19), conflict.StartTime)
+}
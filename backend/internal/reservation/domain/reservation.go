@@ -34,9 +34,10 @@ const (
 type PaymentStatus string
 
 const (
-	PaymentPending  PaymentStatus = "PAGAMENTO_PENDENTE"
-	PaymentPaid     PaymentStatus = "PAGO"
-	PaymentRefunded PaymentStatus = "REEMBOLSADO"
+	PaymentPending           PaymentStatus = "PAGAMENTO_PENDENTE"
+	PaymentPaid              PaymentStatus = "PAGO"
+	PaymentRefunded          PaymentStatus = "REEMBOLSADO"
+	PaymentPartiallyRefunded PaymentStatus = "REEMBOLSO_PARCIAL"
 )
 
 const (
@@ -60,10 +61,16 @@ type Reservation struct {
 	KeysTakenAt    *time.Time `json:"keys_taken_at" gorm:"type:timestamp"`
 	KeysReturnedAt *time.Time `json:"keys_returned_at" gorm:"type:timestamp"`
 
+	KeysOverdueAlertLevel  int        `json:"keys_overdue_alert_level" gorm:"default:0"`
+	KeysOverdueLastAlertAt *time.Time `json:"keys_overdue_last_alert_at" gorm:"type:timestamp"`
+
 	PaymentAmount float64    `json:"payment_amount" gorm:"type:decimal(10,2);default:0"`
 	PaymentDate   *time.Time `json:"payment_date" gorm:"type:timestamp"`
 
 	PaymentMethod PaymentMethod `json:"payment_method" gorm:"type:varchar(20);not null;"`
 
-	CancellationReason string `json:"cancellation_reason" gorm:"type:text"`
+	CancellationReason string     `json:"cancellation_reason" gorm:"type:text"`
+	CancelledAt        *time.Time `json:"cancelled_at" gorm:"type:timestamp"`
+	RefundAmount       float64    `json:"refund_amount" gorm:"type:decimal(10,2);default:0"`
+	RefundedAt         *time.Time `json:"refunded_at" gorm:"type:timestamp"`
 }
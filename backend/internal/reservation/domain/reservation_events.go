@@ -8,16 +8,18 @@ import (
 )
 
 func (r *Reservation) BeforeSave(tx *gorm.DB) (err error) {
+	r.PaymentAmount = GetPaymentAmountForDate(r.StartTime)
+	return
+}
 
-	weekday := r.StartTime.Weekday()
+func GetPaymentAmountForDate(date time.Time) float64 {
+	weekday := date.Weekday()
 	switch {
-	case weekday == time.Friday || weekday == time.Saturday || weekday == time.Sunday || holydayHandler.IsHolyday(r.StartTime):
-		r.PaymentAmount = HolydayPaymentAmount
+	case weekday == time.Friday || weekday == time.Saturday || weekday == time.Sunday || holydayHandler.IsHolyday(date):
+		return HolydayPaymentAmount
 	default:
-		r.PaymentAmount = CommonPaymentAmount
+		return CommonPaymentAmount
 	}
-
-	return
 }
 
 func GetReminderScheduleDate(reservationDate time.Time, isHolidayFunc func(time.Time) bool) time.Time {
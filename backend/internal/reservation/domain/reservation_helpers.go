@@ -1,6 +1,20 @@
 package domain
 
-import "strings"
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var pixTxIDPattern = regexp.MustCompile(`(?i)RESERVA\d{8}`)
+
+var (
+	ErrPaymentAlreadyConfirmed = errors.New("pagamento já foi confirmado anteriormente")
+	ErrPaymentRefunded         = errors.New("o pagamento da reserva foi reembolsado")
+	ErrReservationCancelled    = errors.New("a reserva foi cancelada")
+)
 
 func (r *Reservation) GetLastCharFromSalon() string {
 	parts := strings.Split(string(r.Space), "_")
@@ -12,3 +26,41 @@ func (r *Reservation) GetLastCharFromSalon() string {
 	}
 	return ""
 }
+
+// StartOfDay returns the calendar day of t as midnight UTC. Spaces are booked and blocked by whole
+// days, and every check that compares days goes through it so they agree on where a day starts.
+func StartOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func IsValidSpaceType(space string) bool {
+	switch SpaceType(space) {
+	case Salon1, Salon2:
+		return true
+	}
+	return false
+}
+
+// GetPixTxID returns the txid used to identify the payment of the reservation in PIX codes and bank statements.
+func (r *Reservation) GetPixTxID() string {
+	return fmt.Sprintf("RESERVA%08d", r.ID)
+}
+
+// FindPixTxID looks for a reservation txid in free text such as a bank statement memo.
+func FindPixTxID(text string) string {
+	return strings.ToUpper(pixTxIDPattern.FindString(text))
+}
+
+// CanConfirmPayment tells whether a payment may still be recorded for the reservation: a cancelled
+// reservation, or one whose payment was refunded, cannot be paid, and confirmed, again.
+func (r *Reservation) CanConfirmPayment() error {
+	switch {
+	case r.Status == StatusCancelled:
+		return ErrReservationCancelled
+	case r.PaymentStatus == PaymentRefunded || r.PaymentStatus == PaymentPartiallyRefunded:
+		return ErrPaymentRefunded
+	case r.PaymentStatus == PaymentPaid:
+		return ErrPaymentAlreadyConfirmed
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"portarius/internal/reservation/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservation_CanConfirmPayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		reservation domain.Reservation
+		expected    error
+	}{
+		{"pending", domain.Reservation{Status: domain.StatusPending, PaymentStatus: domain.PaymentPending}, nil},
+		{"confirmed waiting payment", domain.Reservation{Status: domain.StatusConfirmed, PaymentStatus: domain.PaymentPending}, nil},
+		{"already paid", domain.Reservation{Status: domain.StatusConfirmed, PaymentStatus: domain.PaymentPaid}, domain.ErrPaymentAlreadyConfirmed},
+		{"cancelled", domain.Reservation{Status: domain.StatusCancelled, PaymentStatus: domain.PaymentPending}, domain.ErrReservationCancelled},
+		{"refunded", domain.Reservation{Status: domain.StatusConfirmed, PaymentStatus: domain.PaymentRefunded}, domain.ErrPaymentRefunded},
+		{"partially refunded", domain.Reservation{Status: domain.StatusConfirmed, PaymentStatus: domain.PaymentPartiallyRefunded}, domain.ErrPaymentRefunded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.reservation.CanConfirmPayment()
+			if test.expected == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, test.expected)
+			}
+		})
+	}
+}
+
+func TestStartOfDay(t *testing.T) {
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+	expected := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, expected, domain.StartOfDay(time.Date(2025, time.March, 15, 22, 30, 0, 0, saoPaulo)))
+	assert.Equal(t, expected, domain.StartOfDay(time.Date(2025, time.March, 15, 0, 0, 0, 0, saoPaulo)))
+	assert.Equal(t, expected, domain.StartOfDay(expected))
+}
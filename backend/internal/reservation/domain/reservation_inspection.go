@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type CleanlinessStatus string
+
+const (
+	CleanlinessOK            CleanlinessStatus = "LIMPO"
+	CleanlinessNeedsCleaning CleanlinessStatus = "PRECISA_LIMPEZA"
+	CleanlinessDirty         CleanlinessStatus = "SUJO"
+)
+
+// ReservationInspection represents the checklist filled by the porter when the keys are returned
+// swagger:model
+type ReservationInspection struct {
+	gorm.Model       `swaggerignore:"true"`
+	ReservationID    *uint             `json:"reservation_id" gorm:"not null;uniqueIndex"`
+	InspectedByID    *uint             `json:"inspected_by_id"`
+	Cleanliness      CleanlinessStatus `json:"cleanliness" gorm:"type:varchar(20);not null;default:'LIMPO'"`
+	BrokenItems      string            `json:"broken_items" gorm:"type:text"`
+	MissingEquipment string            `json:"missing_equipment" gorm:"type:text"`
+	Notes            string            `json:"notes" gorm:"type:text"`
+	DamageAmount     float64           `json:"damage_amount" gorm:"type:decimal(10,2);default:0"`
+	InspectedAt      time.Time         `json:"inspected_at"`
+	Photos           []InspectionPhoto `json:"photos" gorm:"foreignKey:InspectionID"`
+}
+
+// InspectionPhoto represents a photo attached to a reservation inspection. The file lives in the
+// configured storage under StorageKey.
+// swagger:model
+type InspectionPhoto struct {
+	gorm.Model   `swaggerignore:"true"`
+	InspectionID uint   `json:"inspection_id" gorm:"not null;index"`
+	FileName     string `json:"file_name" gorm:"not null"`
+	ContentType  string `json:"content_type"`
+	StorageKey   string `json:"-" gorm:"not null"`
+}
+
+// NewInspectionPhoto builds the photo of an uploaded file, with the key it is stored under
+func NewInspectionPhoto(inspectionID uint, fileName, contentType, extension string, now time.Time) *InspectionPhoto {
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if fileName == "." || fileName == "/" {
+		fileName = "foto" + extension
+	}
+
+	return &InspectionPhoto{
+		InspectionID: inspectionID,
+		FileName:     fileName,
+		ContentType:  contentType,
+		StorageKey:   fmt.Sprintf("inspections/%d/%d%s", inspectionID, now.UnixNano(), extension),
+	}
+}
+
+func (i *ReservationInspection) HasFindings() bool {
+	return i.Cleanliness != CleanlinessOK || i.BrokenItems != "" || i.MissingEquipment != "" || i.DamageAmount > 0
+}
+
+func (i *ReservationInspection) Summary() string {
+	if !i.HasFindings() {
+		return "Nenhuma ocorrência"
+	}
+
+	findings := []string{}
+	switch i.Cleanliness {
+	case CleanlinessNeedsCleaning:
+		findings = append(findings, "salão precisa de limpeza")
+	case CleanlinessDirty:
+		findings = append(findings, "salão entregue sujo")
+	}
+	if i.BrokenItems != "" {
+		findings = append(findings, "itens quebrados: "+i.BrokenItems)
+	}
+	if i.MissingEquipment != "" {
+		findings = append(findings, "equipamentos faltando: "+i.MissingEquipment)
+	}
+	if len(findings) == 0 {
+		findings = append(findings, "taxa de danos aplicada")
+	}
+
+	return strings.Join(findings, "; ")
+}
@@ -0,0 +1,8 @@
+package domain
+
+type IReservationInspectionRepository interface {
+	Create(inspection *ReservationInspection) error
+	GetByReservation(reservationID uint) (*ReservationInspection, error)
+	AddPhoto(photo *InspectionPhoto) error
+	GetPhoto(id uint) (*InspectionPhoto, error)
+}
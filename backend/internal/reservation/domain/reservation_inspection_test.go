@@ -0,0 +1,22 @@
+package domain_test
+
+import (
+	"portarius/internal/reservation/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewInspectionPhoto(t *testing.T) {
+	now := time.Date(2025, time.March, 16, 9, 0, 0, 0, time.UTC)
+
+	photo := domain.NewInspectionPhoto(7, `C:\fotos\salao.jpg`, "image/jpeg", ".jpg", now)
+	assert.Equal(t, uint(7), photo.InspectionID)
+	assert.Equal(t, "salao.jpg", photo.FileName)
+	assert.Equal(t, "image/jpeg", photo.ContentType)
+	assert.Equal(t, "inspections/7/1742115600000000000.jpg", photo.StorageKey)
+
+	photo = domain.NewInspectionPhoto(7, "", "image/png", ".png", now)
+	assert.Equal(t, "foto.png", photo.FileName)
+}
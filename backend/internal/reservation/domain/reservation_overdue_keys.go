@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultKeysReturnGraceHours = 2
+	MaxOverdueKeysAlertLevel    = 3
+	OverdueKeysEscalationPeriod = 24 * time.Hour
+)
+
+func KeysReturnGracePeriod() time.Duration {
+	hours, err := strconv.Atoi(os.Getenv("KEYS_RETURN_GRACE_HOURS"))
+	if err != nil || hours < 0 {
+		hours = DefaultKeysReturnGraceHours
+	}
+	return time.Duration(hours) * time.Hour
+}
+
+// OverdueKeysAlertLevel returns how many alerts should have been sent for the keys of a reservation
+// by now: the first once the grace period after EndTime is over, then one more every escalation period.
+func (r *Reservation) OverdueKeysAlertLevel(now time.Time, grace time.Duration) int {
+	if r.Status != StatusKeysTaken || r.EndTime.IsZero() {
+		return 0
+	}
+
+	overdue := now.Sub(r.EndTime.Add(grace))
+	if overdue < 0 {
+		return 0
+	}
+
+	level := 1 + int(overdue/OverdueKeysEscalationPeriod)
+	if level > MaxOverdueKeysAlertLevel {
+		level = MaxOverdueKeysAlertLevel
+	}
+	return level
+}
+
+func (r *Reservation) HoursOverdue(now time.Time) int {
+	if r.EndTime.IsZero() || now.Before(r.EndTime) {
+		return 0
+	}
+	return int(now.Sub(r.EndTime).Hours())
+}
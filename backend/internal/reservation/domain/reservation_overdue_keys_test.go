@@ -0,0 +1,59 @@
+package domain_test
+
+import (
+	"portarius/internal/reservation/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReservation_OverdueKeysAlertLevel(t *testing.T) {
+	end := time.Date(2025, time.March, 15, 22, 0, 0, 0, time.UTC)
+	grace := 2 * time.Hour
+	escalation := domain.OverdueKeysEscalationPeriod
+
+	tests := []struct {
+		name     string
+		status   domain.ReservationStatus
+		now      time.Time
+		expected int
+	}{
+		{"before the end", domain.StatusKeysTaken, end.Add(-time.Hour), 0},
+		{"inside the grace period", domain.StatusKeysTaken, end.Add(grace - time.Second), 0},
+		{"grace period just over", domain.StatusKeysTaken, end.Add(grace), 1},
+		{"just before the first escalation", domain.StatusKeysTaken, end.Add(grace + escalation - time.Second), 1},
+		{"first escalation", domain.StatusKeysTaken, end.Add(grace + escalation), 2},
+		{"second escalation", domain.StatusKeysTaken, end.Add(grace + 2*escalation), 3},
+		{"capped at the last level", domain.StatusKeysTaken, end.Add(grace + 10*escalation), domain.MaxOverdueKeysAlertLevel},
+		{"keys returned", domain.StatusKeysReturned, end.Add(grace + escalation), 0},
+		{"keys not taken", domain.StatusConfirmed, end.Add(grace + escalation), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reservation := domain.Reservation{Status: tt.status, EndTime: end}
+			assert.Equal(t, tt.expected, reservation.OverdueKeysAlertLevel(tt.now, grace))
+		})
+	}
+
+	reservation := domain.Reservation{Status: domain.StatusKeysTaken}
+	assert.Equal(t, 0, reservation.OverdueKeysAlertLevel(end, grace), "no end time")
+
+	reservation = domain.Reservation{Status: domain.StatusKeysTaken, EndTime: end}
+	assert.Equal(t, 1, reservation.OverdueKeysAlertLevel(end, 0), "no grace period")
+}
+
+func TestKeysReturnGracePeriod(t *testing.T) {
+	t.Setenv("KEYS_RETURN_GRACE_HOURS", "")
+	assert.Equal(t, domain.DefaultKeysReturnGraceHours*time.Hour, domain.KeysReturnGracePeriod())
+
+	t.Setenv("KEYS_RETURN_GRACE_HOURS", "5")
+	assert.Equal(t, 5*time.Hour, domain.KeysReturnGracePeriod())
+
+	t.Setenv("KEYS_RETURN_GRACE_HOURS", "-1")
+	assert.Equal(t, domain.DefaultKeysReturnGraceHours*time.Hour, domain.KeysReturnGracePeriod())
+
+	t.Setenv("KEYS_RETURN_GRACE_HOURS", "abc")
+	assert.Equal(t, domain.DefaultKeysReturnGraceHours*time.Hour, domain.KeysReturnGracePeriod())
+}
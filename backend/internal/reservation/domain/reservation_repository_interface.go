@@ -12,7 +12,11 @@ type IReservationRepository interface {
 	GetBySpace(space string) ([]Reservation, error)
 	GetByStatus(status string) ([]Reservation, error)
 	FindByDateRange(startDate, endDate time.Time) ([]Reservation, error)
+	FindBySpaceAndDateRange(space string, startDate, endDate time.Time) ([]Reservation, error)
 	FindUpcomingReservations() ([]Reservation, error)
+	FindOverdueKeys(cutoff time.Time) ([]Reservation, error)
+	FindPendingPayments() ([]Reservation, error)
+	FindByResidentAndPeriod(residentID uint, startTime, endTime time.Time) (*Reservation, error)
 	UpdateStatus(id uint, status string) error
 	ImportSalonReservations(reservations []Reservation) error
 	CheckReservationConflict(space string, startTime, endTime time.Time, excludeID uint) error
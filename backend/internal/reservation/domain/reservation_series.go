@@ -0,0 +1,448 @@
+package domain
+
+import (
+	"fmt"
+	residentDomain "portarius/internal/resident/domain"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type SeriesKind string
+
+const (
+	SeriesKindReservation SeriesKind = "RESERVA"
+	SeriesKindMaintenance SeriesKind = "MANUTENCAO"
+)
+
+// MaxOccurrencesPeriod is the longest period the occurrences of the series can be listed for
+const MaxOccurrencesPeriod = 366 * 24 * time.Hour
+
+// conflictCycleSlackDays covers the first week or month of a rule, which may hold fewer occurrences than
+// the following ones, and occurrences running past midnight
+const conflictCycleSlackDays = 32
+
+// EditScope selects which occurrences of a recurring series are affected by an edit or cancellation
+type EditScope string
+
+const (
+	ScopeThisOccurrence   EditScope = "ESTA"
+	ScopeThisAndFollowing EditScope = "ESTA_E_FUTURAS"
+	ScopeAllOccurrences   EditScope = "TODAS"
+)
+
+// ReservationSeries represents a recurring reservation or maintenance block of a space.
+// StartTime and EndTime describe the first occurrence and RRule how it repeats.
+// swagger:model
+type ReservationSeries struct {
+	gorm.Model  `swaggerignore:"true"`
+	Space       SpaceType                    `json:"space" gorm:"not null;type:varchar(10);index"`
+	Kind        SeriesKind                   `json:"kind" gorm:"type:varchar(20);not null;default:'RESERVA'"`
+	Title       string                       `json:"title" gorm:"not null"`
+	ResidentID  *uint                        `json:"resident_id"`
+	Resident    *residentDomain.Resident     `json:"resident" gorm:"foreignKey:ResidentID" swaggerignore:"true"`
+	RRule       string                       `json:"rrule" gorm:"not null"`
+	StartTime   time.Time                    `json:"start_time" gorm:"not null"`
+	EndTime     time.Time                    `json:"end_time" gorm:"not null"`
+	Description string                       `json:"description" gorm:"type:text"`
+	CreatedByID *uint                        `json:"created_by_id"`
+	Exceptions  []ReservationSeriesException `json:"exceptions" gorm:"foreignKey:SeriesID"`
+}
+
+// ReservationSeriesException represents a single occurrence of a series that was cancelled or moved.
+// OccurrenceStart is the start the occurrence would have according to the rule.
+// swagger:model
+type ReservationSeriesException struct {
+	gorm.Model      `swaggerignore:"true"`
+	SeriesID        uint       `json:"series_id" gorm:"not null;uniqueIndex:idx_series_occurrence"`
+	OccurrenceStart time.Time  `json:"occurrence_start" gorm:"not null;uniqueIndex:idx_series_occurrence"`
+	Cancelled       bool       `json:"cancelled" gorm:"default:false"`
+	StartTime       *time.Time `json:"start_time" gorm:"type:timestamp"`
+	EndTime         *time.Time `json:"end_time" gorm:"type:timestamp"`
+}
+
+// SeriesOccurrence represents one expanded occurrence of a series
+// swagger:model
+type SeriesOccurrence struct {
+	SeriesID        uint       `json:"series_id"`
+	Space           SpaceType  `json:"space"`
+	Kind            SeriesKind `json:"kind"`
+	Title           string     `json:"title"`
+	ResidentID      *uint      `json:"resident_id"`
+	OccurrenceStart time.Time  `json:"occurrence_start"`
+	StartTime       time.Time  `json:"start_time"`
+	EndTime         time.Time  `json:"end_time"`
+	Modified        bool       `json:"modified"`
+}
+
+// SeriesChange represents the fields that can be changed on a series occurrence.
+// Empty fields keep the current value.
+type SeriesChange struct {
+	StartTime   *time.Time
+	EndTime     *time.Time
+	Title       string
+	Description string
+	RRule       string
+}
+
+func ParseSeriesKind(value string) (SeriesKind, error) {
+	switch SeriesKind(value) {
+	case SeriesKindReservation, SeriesKindMaintenance:
+		return SeriesKind(value), nil
+	case "":
+		return SeriesKindReservation, nil
+	default:
+		return "", fmt.Errorf("tipo de recorrência inválido: %s", value)
+	}
+}
+
+func ParseEditScope(value string) (EditScope, error) {
+	switch EditScope(value) {
+	case ScopeThisOccurrence, ScopeThisAndFollowing, ScopeAllOccurrences:
+		return EditScope(value), nil
+	default:
+		return "", fmt.Errorf("escopo inválido: %s", value)
+	}
+}
+
+func (s *ReservationSeries) Validate() (*RecurrenceRule, error) {
+	if s.Title == "" {
+		return nil, fmt.Errorf("o título é obrigatório")
+	}
+	if !IsValidSpaceType(string(s.Space)) {
+		return nil, fmt.Errorf("espaço inválido: %s", s.Space)
+	}
+	if !s.EndTime.After(s.StartTime) {
+		return nil, fmt.Errorf("o horário de término deve ser posterior ao horário de início")
+	}
+	if s.EndTime.Sub(s.StartTime) > 24*time.Hour {
+		return nil, fmt.Errorf("cada ocorrência deve durar no máximo 24 horas")
+	}
+
+	rule, err := ParseRecurrenceRule(s.RRule)
+	if err != nil {
+		return nil, err
+	}
+	s.RRule = rule.String()
+	return rule, nil
+}
+
+// End returns when the last occurrence of a series limited by COUNT or UNTIL ends.
+// Open-ended series return false.
+func (s *ReservationSeries) End() (time.Time, bool) {
+	rule, err := ParseRecurrenceRule(s.RRule)
+	if err != nil || !rule.IsFinite() {
+		return time.Time{}, false
+	}
+
+	until := time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+	if rule.Until != nil {
+		until = *rule.Until
+	}
+
+	end := s.EndTime
+	if starts := rule.Occurrences(s.StartTime, s.StartTime, until); len(starts) > 0 {
+		end = starts[len(starts)-1].Add(s.Duration())
+	}
+	for _, exception := range s.Exceptions {
+		if exception.EndTime != nil && exception.EndTime.After(end) {
+			end = *exception.EndTime
+		}
+	}
+	return end, true
+}
+
+// ConflictWith returns the first occurrence of the series, from the given time on, that overlaps an occurrence
+// of other. Once both series are running their occurrences repeat every joint cycle of the two rules, so they
+// are compared over one cycle after the later of the two starts and the last exception, or until the first of
+// them ends by COUNT or UNTIL.
+func (s *ReservationSeries) ConflictWith(other *ReservationSeries, from time.Time) *SeriesOccurrence {
+	rule, err := ParseRecurrenceRule(s.RRule)
+	if err != nil {
+		return nil
+	}
+	otherRule, err := ParseRecurrenceRule(other.RRule)
+	if err != nil {
+		return nil
+	}
+
+	if s.StartTime.After(from) {
+		from = s.StartTime
+	}
+
+	steady := from
+	if other.StartTime.After(steady) {
+		steady = other.StartTime
+	}
+	for _, exceptions := range [][]ReservationSeriesException{s.Exceptions, other.Exceptions} {
+		for _, exception := range exceptions {
+			if exception.OccurrenceStart.After(steady) {
+				steady = exception.OccurrenceStart
+			}
+		}
+	}
+
+	cycle := lcm(rule.CycleDays(s.StartTime), otherRule.CycleDays(other.StartTime))
+	to := steady.AddDate(0, 0, cycle+conflictCycleSlackDays)
+	for _, series := range []*ReservationSeries{s, other} {
+		if end, ok := series.End(); ok && end.Before(to) {
+			to = end
+		}
+	}
+	if !to.After(from) {
+		return nil
+	}
+
+	occurrences := s.Occurrences(from, to)
+	otherOccurrences := other.Occurrences(from, to)
+
+	for i, j := 0, 0; i < len(occurrences) && j < len(otherOccurrences); {
+		occurrence, otherOccurrence := occurrences[i], otherOccurrences[j]
+		if occurrence.StartTime.Before(otherOccurrence.EndTime) && occurrence.EndTime.After(otherOccurrence.StartTime) {
+			return &occurrence
+		}
+		if occurrence.EndTime.After(otherOccurrence.EndTime) {
+			j++
+		} else {
+			i++
+		}
+	}
+	return nil
+}
+
+// HasOccurrenceAt reports whether the given time identifies an occurrence of the series, either one produced
+// by the rule or one that was already moved or cancelled.
+func (s *ReservationSeries) HasOccurrenceAt(occurrenceStart time.Time) bool {
+	for _, exception := range s.Exceptions {
+		if exception.OccurrenceStart.Equal(occurrenceStart) {
+			return true
+		}
+	}
+	return s.HasOccurrence(occurrenceStart)
+}
+
+func (s *ReservationSeries) Duration() time.Duration {
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// Occurrences expands the series between from and to, applying cancelled and moved occurrences.
+// An occurrence is returned when any part of it overlaps the interval.
+func (s *ReservationSeries) Occurrences(from, to time.Time) []SeriesOccurrence {
+	rule, err := ParseRecurrenceRule(s.RRule)
+	if err != nil {
+		return nil
+	}
+
+	exceptions := make(map[int64]ReservationSeriesException, len(s.Exceptions))
+	for _, exception := range s.Exceptions {
+		exceptions[exception.OccurrenceStart.Unix()] = exception
+	}
+
+	duration := s.Duration()
+	occurrences := []SeriesOccurrence{}
+
+	// moved occurrences may land anywhere, so they are checked independently from the rule window
+	for _, exception := range s.Exceptions {
+		if exception.Cancelled || exception.StartTime == nil || exception.EndTime == nil {
+			continue
+		}
+		if exception.StartTime.Before(to) && exception.EndTime.After(from) {
+			occurrences = append(occurrences, s.occurrence(exception.OccurrenceStart, *exception.StartTime, *exception.EndTime, true))
+		}
+	}
+
+	for _, start := range rule.Occurrences(s.StartTime, from.Add(-duration), to) {
+		end := start.Add(duration)
+		if !start.Before(to) || !end.After(from) {
+			continue
+		}
+		if _, ok := exceptions[start.Unix()]; ok {
+			continue
+		}
+		occurrences = append(occurrences, s.occurrence(start, start, end, false))
+	}
+
+	sort.Slice(occurrences, func(i, j int) bool {
+		return occurrences[i].StartTime.Before(occurrences[j].StartTime)
+	})
+	return occurrences
+}
+
+// HasOccurrence reports whether the rule produces an occurrence starting at the given time
+func (s *ReservationSeries) HasOccurrence(occurrenceStart time.Time) bool {
+	rule, err := ParseRecurrenceRule(s.RRule)
+	if err != nil {
+		return false
+	}
+	for _, start := range rule.Occurrences(s.StartTime, occurrenceStart, occurrenceStart) {
+		if start.Equal(occurrenceStart) {
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps returns the first occurrence of the series overlapping the interval
+func (s *ReservationSeries) Overlaps(startTime, endTime time.Time) *SeriesOccurrence {
+	for _, occurrence := range s.Occurrences(startTime, endTime) {
+		return &occurrence
+	}
+	return nil
+}
+
+// EndBefore limits the rule so the series stops right before the given occurrence.
+// It returns false when no occurrence would remain.
+func (s *ReservationSeries) EndBefore(occurrenceStart time.Time) (bool, error) {
+	rule, err := ParseRecurrenceRule(s.RRule)
+	if err != nil {
+		return false, err
+	}
+
+	remaining := rule.CountBefore(s.StartTime, occurrenceStart)
+	if remaining == 0 {
+		return false, nil
+	}
+
+	if rule.Count > 0 {
+		rule.Count = remaining
+	} else {
+		until := occurrenceStart.Add(-time.Second)
+		rule.Until = &until
+	}
+	s.RRule = rule.String()
+
+	exceptions := s.Exceptions[:0]
+	for _, exception := range s.Exceptions {
+		if exception.OccurrenceStart.Before(occurrenceStart) {
+			exceptions = append(exceptions, exception)
+		}
+	}
+	s.Exceptions = exceptions
+	return true, nil
+}
+
+// SplitAt returns a new series starting at the given occurrence with the change applied,
+// inheriting what is left of the rule. The receiver must be ended with EndBefore afterwards.
+func (s *ReservationSeries) SplitAt(occurrenceStart time.Time, change SeriesChange) (*ReservationSeries, error) {
+	rule, err := ParseRecurrenceRule(s.RRule)
+	if err != nil {
+		return nil, err
+	}
+	if rule.Count > 0 {
+		rule.Count -= rule.CountBefore(s.StartTime, occurrenceStart)
+	}
+
+	following := &ReservationSeries{
+		Space:       s.Space,
+		Kind:        s.Kind,
+		Title:       s.Title,
+		ResidentID:  s.ResidentID,
+		RRule:       rule.String(),
+		StartTime:   occurrenceStart,
+		EndTime:     occurrenceStart.Add(s.Duration()),
+		Description: s.Description,
+		CreatedByID: s.CreatedByID,
+	}
+	if err := following.Apply(change); err != nil {
+		return nil, err
+	}
+	return following, nil
+}
+
+// Apply changes the series as a whole. Moving the series or changing its rule drops the exceptions,
+// since they no longer line up with the new occurrences.
+func (s *ReservationSeries) Apply(change SeriesChange) error {
+	if change.Title != "" {
+		s.Title = change.Title
+	}
+	if change.Description != "" {
+		s.Description = change.Description
+	}
+
+	reschedule := false
+	if change.StartTime != nil && !change.StartTime.Equal(s.StartTime) {
+		duration := s.Duration()
+		s.StartTime = *change.StartTime
+		s.EndTime = s.StartTime.Add(duration)
+		reschedule = true
+	}
+	if change.EndTime != nil && !change.EndTime.Equal(s.EndTime) {
+		s.EndTime = *change.EndTime
+		reschedule = true
+	}
+	if change.RRule != "" && change.RRule != s.RRule {
+		s.RRule = change.RRule
+		reschedule = true
+	}
+
+	if reschedule {
+		s.Exceptions = nil
+	}
+
+	_, err := s.Validate()
+	return err
+}
+
+// Reschedule returns the exception that moves a single occurrence
+func (s *ReservationSeries) Reschedule(occurrenceStart time.Time, change SeriesChange) (*ReservationSeriesException, error) {
+	exception := s.exception(occurrenceStart)
+
+	start := occurrenceStart
+	if exception.StartTime != nil {
+		start = *exception.StartTime
+	}
+	end := start.Add(s.Duration())
+	if exception.EndTime != nil {
+		end = *exception.EndTime
+	}
+
+	if change.StartTime != nil {
+		duration := end.Sub(start)
+		start = *change.StartTime
+		end = start.Add(duration)
+	}
+	if change.EndTime != nil {
+		end = *change.EndTime
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("o horário de término deve ser posterior ao horário de início")
+	}
+
+	exception.Cancelled = false
+	exception.StartTime = &start
+	exception.EndTime = &end
+	return exception, nil
+}
+
+// CancelOccurrence returns the exception that cancels a single occurrence
+func (s *ReservationSeries) CancelOccurrence(occurrenceStart time.Time) *ReservationSeriesException {
+	exception := s.exception(occurrenceStart)
+	exception.Cancelled = true
+	exception.StartTime = nil
+	exception.EndTime = nil
+	return exception
+}
+
+func (s *ReservationSeries) exception(occurrenceStart time.Time) *ReservationSeriesException {
+	for i := range s.Exceptions {
+		if s.Exceptions[i].OccurrenceStart.Equal(occurrenceStart) {
+			return &s.Exceptions[i]
+		}
+	}
+	return &ReservationSeriesException{SeriesID: s.ID, OccurrenceStart: occurrenceStart}
+}
+
+func (s *ReservationSeries) occurrence(occurrenceStart, start, end time.Time, modified bool) SeriesOccurrence {
+	return SeriesOccurrence{
+		SeriesID:        s.ID,
+		Space:           s.Space,
+		Kind:            s.Kind,
+		Title:           s.Title,
+		ResidentID:      s.ResidentID,
+		OccurrenceStart: occurrenceStart,
+		StartTime:       start,
+		EndTime:         end,
+		Modified:        modified,
+	}
+}
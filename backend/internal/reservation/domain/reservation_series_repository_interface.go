@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+type IReservationSeriesRepository interface {
+	GetAll() ([]ReservationSeries, error)
+	GetByID(id uint) (*ReservationSeries, error)
+	FindBySpace(space string, from, to time.Time) ([]ReservationSeries, error)
+	Create(series *ReservationSeries) error
+	Update(series *ReservationSeries) error
+	Delete(id uint) error
+	Split(series, following *ReservationSeries) error
+	SaveException(exception *ReservationSeriesException) error
+	CheckSeriesConflict(series *ReservationSeries, from time.Time) error
+}
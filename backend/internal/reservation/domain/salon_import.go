@@ -0,0 +1,203 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+)
+
+type SalonImportStatus string
+
+const (
+	SalonImportCreated SalonImportStatus = "CRIADA"
+	SalonImportUpdated SalonImportStatus = "ATUALIZADA"
+	SalonImportSkipped SalonImportStatus = "IGNORADA"
+	SalonImportError   SalonImportStatus = "ERRO"
+)
+
+// ErrInvalidSalonImportFile wraps the errors of a file that cannot be read as a salon reservations CSV
+var ErrInvalidSalonImportFile = errors.New("arquivo de reservas inválido")
+
+// Salon reservations imported from spreadsheets cover the whole day the salon is open
+const (
+	SalonImportStartHour = 8
+	SalonImportEndHour   = 20
+)
+
+// SalonImportRow represents a valid line of a salon reservations CSV
+type SalonImportRow struct {
+	Line          int
+	Block         string
+	Apartment     string
+	Space         SpaceType
+	Date          time.Time
+	PaymentMethod PaymentMethod
+}
+
+// SalonImportResult represents the outcome of a single line of the import
+// swagger:model
+type SalonImportResult struct {
+	Line          int               `json:"line"`
+	Unit          string            `json:"unit"`
+	Date          string            `json:"date"`
+	Space         SpaceType         `json:"space,omitempty"`
+	ResidentID    *uint             `json:"resident_id,omitempty"`
+	ReservationID *uint             `json:"reservation_id,omitempty"`
+	Status        SalonImportStatus `json:"status"`
+	Reason        string            `json:"reason,omitempty"`
+}
+
+// SalonImportReport represents the line by line report of a salon reservations import.
+// In a dry run nothing is written and the statuses describe what would happen.
+// swagger:model
+type SalonImportReport struct {
+	DryRun  bool                `json:"dry_run"`
+	Total   int                 `json:"total"`
+	Created int                 `json:"created"`
+	Updated int                 `json:"updated"`
+	Skipped int                 `json:"skipped"`
+	Errors  int                 `json:"errors"`
+	Rows    []SalonImportResult `json:"rows"`
+}
+
+func NewSalonImportReport(dryRun bool) *SalonImportReport {
+	return &SalonImportReport{DryRun: dryRun, Rows: []SalonImportResult{}}
+}
+
+func (r *SalonImportReport) Add(result SalonImportResult) {
+	r.Total++
+	switch result.Status {
+	case SalonImportCreated:
+		r.Created++
+	case SalonImportUpdated:
+		r.Updated++
+	case SalonImportSkipped:
+		r.Skipped++
+	case SalonImportError:
+		r.Errors++
+	}
+	r.Rows = append(r.Rows, result)
+}
+
+// NewSalonImportRow validates the values of a line. The salon is the second one when its name has a 2
+// and the payment is by PIX when its receipt mentions it, by boleto otherwise.
+func NewSalonImportRow(line int, unit, date, space, payment string) (SalonImportRow, error) {
+	block, apartment, err := ParseUnit(unit)
+	if err != nil {
+		return SalonImportRow{}, err
+	}
+
+	day, err := parseImportDate(date)
+	if err != nil {
+		return SalonImportRow{}, err
+	}
+
+	row := SalonImportRow{
+		Line:          line,
+		Block:         block,
+		Apartment:     apartment,
+		Space:         Salon1,
+		Date:          day,
+		PaymentMethod: PaymentMethodBoleto,
+	}
+
+	if strings.Contains(strings.ToUpper(space), "2") {
+		row.Space = Salon2
+	}
+
+	if strings.Contains(strings.ToUpper(payment), "PIX") {
+		row.PaymentMethod = PaymentMethodPix
+	}
+
+	return row, nil
+}
+
+// ParseUnit splits a unit code such as "A30" or "b 07" into block and apartment,
+// with the apartment number stripped of leading zeros
+func ParseUnit(unit string) (string, string, error) {
+	unit = strings.ToUpper(strings.Join(strings.Fields(unit), ""))
+	unit = strings.NewReplacer("-", "", "/", "").Replace(unit)
+
+	if len(unit) < 2 || !unicode.IsLetter(rune(unit[0])) {
+		return "", "", fmt.Errorf("unidade inválida: %s", unit)
+	}
+
+	apartment := NormalizeApartment(unit[1:])
+	for _, r := range apartment {
+		if !unicode.IsDigit(r) {
+			return "", "", fmt.Errorf("unidade inválida: %s", unit)
+		}
+	}
+
+	return unit[:1], apartment, nil
+}
+
+func NormalizeApartment(apartment string) string {
+	apartment = strings.TrimLeft(strings.TrimSpace(apartment), "0")
+	if apartment == "" {
+		return "0"
+	}
+	return apartment
+}
+
+// Period returns the start and end of the reservation of the row
+func (r SalonImportRow) Period() (time.Time, time.Time) {
+	day := r.Date
+	return time.Date(day.Year(), day.Month(), day.Day(), SalonImportStartHour, 0, 0, 0, time.UTC),
+		time.Date(day.Year(), day.Month(), day.Day(), SalonImportEndHour, 0, 0, 0, time.UTC)
+}
+
+// Reservation builds the reservation of the row. Imported reservations are historical records,
+// already confirmed and paid.
+func (r SalonImportRow) Reservation(residentID uint) *Reservation {
+	start, end := r.Period()
+	paymentDate := time.Date(start.Year(), start.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return &Reservation{
+		ResidentID:    &residentID,
+		Space:         r.Space,
+		StartTime:     start,
+		EndTime:       end,
+		Status:        StatusConfirmed,
+		PaymentStatus: PaymentPaid,
+		PaymentMethod: r.PaymentMethod,
+		PaymentAmount: GetPaymentAmountForDate(start),
+		PaymentDate:   &paymentDate,
+	}
+}
+
+// ApplyTo copies the imported fields to an existing reservation, returning false when nothing changed
+func (r SalonImportRow) ApplyTo(reservation *Reservation) bool {
+	imported := r.Reservation(0)
+	if reservation.Space == imported.Space &&
+		reservation.PaymentMethod == imported.PaymentMethod &&
+		reservation.Status == imported.Status &&
+		reservation.PaymentStatus == imported.PaymentStatus {
+		return false
+	}
+
+	reservation.Space = imported.Space
+	reservation.PaymentMethod = imported.PaymentMethod
+	reservation.Status = imported.Status
+	reservation.PaymentStatus = imported.PaymentStatus
+	if reservation.PaymentDate == nil {
+		reservation.PaymentDate = imported.PaymentDate
+	}
+	return true
+}
+
+func parseImportDate(value string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02", "02/01/2006"} {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	if len(value) >= 10 {
+		if date, err := time.Parse("2006-01-02", value[:10]); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("data inválida: %s", value)
+}
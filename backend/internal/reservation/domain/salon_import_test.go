@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"portarius/internal/reservation/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUnit(t *testing.T) {
+	tests := []struct {
+		unit      string
+		block     string
+		apartment string
+		wantErr   bool
+	}{
+		{unit: "A30", block: "A", apartment: "30"},
+		{unit: "b07", block: "B", apartment: "7"},
+		{unit: "C-1", block: "C", apartment: "1"},
+		{unit: " D 12 ", block: "D", apartment: "12"},
+		{unit: "A", wantErr: true},
+		{unit: "130", wantErr: true},
+		{unit: "AB1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			block, apartment, err := domain.ParseUnit(tt.unit)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.block, block)
+			assert.Equal(t, tt.apartment, apartment)
+		})
+	}
+}
+
+func TestSalonImportRow_ApplyTo(t *testing.T) {
+	row := domain.SalonImportRow{Space: domain.Salon2, Date: time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC), PaymentMethod: domain.PaymentMethodPix}
+
+	reservation := row.Reservation(3)
+	assert.False(t, row.ApplyTo(reservation))
+
+	reservation.Space = domain.Salon1
+	assert.True(t, row.ApplyTo(reservation))
+	assert.Equal(t, domain.Salon2, reservation.Space)
+}
@@ -1,24 +1,63 @@
 package reservation
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
 	"net/http"
 	"portarius/internal/eventbus"
+	financeDomain "portarius/internal/finance/domain"
+	middleware "portarius/internal/middleware/auth"
+	pkgDomain "portarius/internal/package/domain"
+	pixDomain "portarius/internal/pix/domain"
 	reminderDomain "portarius/internal/reminder/domain"
 	"portarius/internal/reservation/domain"
 	"portarius/internal/reservation/interfaces"
+	spaceDomain "portarius/internal/space/domain"
+	"portarius/internal/storage"
 	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 )
 
 type ReservationHandler struct {
-	repo          domain.IReservationRepository
-	importService interfaces.ICSVReservationImporter
+	repo           domain.IReservationRepository
+	importService  interfaces.ICSVReservationImporter
+	policyRepo     spaceDomain.ICancellationPolicyRepository
+	financeRepo    financeDomain.IFinancialEntryRepository
+	inspectionRepo domain.IReservationInspectionRepository
+	store          storage.Storage
 }
 
-func NewReservationHandler(repo domain.IReservationRepository) *ReservationHandler {
-	return &ReservationHandler{repo: repo}
+const maxImportSize = 5 << 20
+
+type DamageChargeRequest struct {
+	Description string  `json:"description" binding:"required"`
+	Amount      float64 `json:"amount" binding:"required,gt=0"`
+}
+
+type InspectionRequest struct {
+	Cleanliness      domain.CleanlinessStatus `json:"cleanliness"`
+	BrokenItems      string                   `json:"broken_items"`
+	MissingEquipment string                   `json:"missing_equipment"`
+	Notes            string                   `json:"notes"`
+	DamageCharges    []DamageChargeRequest    `json:"damage_charges" binding:"dive"`
+}
+
+func NewReservationHandler(repo domain.IReservationRepository, importer interfaces.ICSVReservationImporter, policyRepo spaceDomain.ICancellationPolicyRepository, financeRepo financeDomain.IFinancialEntryRepository, inspectionRepo domain.IReservationInspectionRepository, store storage.Storage) *ReservationHandler {
+	return &ReservationHandler{
+		repo:           repo,
+		importService:  importer,
+		policyRepo:     policyRepo,
+		financeRepo:    financeRepo,
+		inspectionRepo: inspectionRepo,
+		store:          store,
+	}
 }
 
 // GetAll godoc
@@ -210,12 +249,19 @@ func (c *ReservationHandler) Delete(ctx *gin.Context) {
 // @Failure 500
 // @Router /reservations/{id}/confirm [put]
 func (c *ReservationHandler) Confirm(ctx *gin.Context) {
-	c.UpdateStatus(ctx, domain.StatusConfirmed)
+	reservation := c.UpdateStatus(ctx, domain.StatusConfirmed)
+
+	if reservation != nil {
+		eventbus.Publish("ReservationConfirmed", &eventbus.ReservationConfirmedEvent{
+			ReservationID: &reservation.ID,
+			Channel:       string(reminderDomain.ReminderChannelWhatsApp),
+		})
+	}
 }
 
 // Cancel godoc
 // @Summary Cancel a reservation
-// @Description Cancel a reservation by setting its status to cancelled and adding a cancellation reason
+// @Description Cancel a reservation by setting its status to cancelled and adding a cancellation reason. If the reservation was paid, the refund is computed from the cancellation policy of the space and recorded as a financial entry.
 // @Tags Reservations
 // @Security BearerAuth
 // @Param id path int true "Reservation ID"
@@ -247,10 +293,49 @@ func (c *ReservationHandler) Cancel(ctx *gin.Context) {
 		return
 	}
 
+	if reservation.Status == domain.StatusCancelled {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A reserva já foi cancelada"})
+		return
+	}
+
+	now := time.Now()
 	reservation.Status = domain.StatusCancelled
 	reservation.CancellationReason = input.CancellationReason
+	reservation.CancelledAt = &now
 
-	if err := c.repo.Update(reservation); err != nil {
+	var refund spaceDomain.RefundResult
+
+	if reservation.PaymentStatus == domain.PaymentPaid {
+		policy, err := c.policyRepo.GetBySpace(string(reservation.Space))
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		refund = policy.ComputeRefund(reservation.PaymentAmount, reservation.StartTime, now)
+
+		if refund.Amount > 0 {
+			reservation.RefundAmount = refund.Amount
+			reservation.RefundedAt = &now
+			reservation.PaymentStatus = domain.PaymentPartiallyRefunded
+			if refund.Amount >= reservation.PaymentAmount {
+				reservation.PaymentStatus = domain.PaymentRefunded
+			}
+		}
+	}
+
+	var entries []financeDomain.FinancialEntry
+	if refund.Amount > 0 {
+		entries = append(entries, financeDomain.FinancialEntry{
+			Type:          financeDomain.EntryRefund,
+			Amount:        refund.Amount,
+			PaymentMethod: reservation.PaymentMethod,
+			Description:   fmt.Sprintf("Reembolso de %.0f%% por cancelamento com %d dia(s) de antecedência", refund.Percentage, refund.DaysBefore),
+			OccurredAt:    now,
+		})
+	}
+
+	if err := c.financeRepo.SaveWithReservation(reservation, nil, entries); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -302,14 +387,17 @@ func (c *ReservationHandler) TakeKeys(ctx *gin.Context) {
 
 // ReturnKeys godoc
 // @Summary Mark reservation keys as returned
-// @Description Marks the keys as returned for a reservation and updates its status
+// @Description Marks the keys as returned for a reservation and updates its status. When an inspection checklist is sent, it is recorded, damage charges are linked to the reservation and the resident is notified of the findings.
 // @Tags Reservations
+// @Accept json
 // @Security BearerAuth
 // @Param id path int true "Reservation ID"
+// @Param inspection body InspectionRequest false "Inspection checklist"
 // @Success 200 {object} domain.Reservation
 // @Failure 400
 // @Failure 401
 // @Failure 404
+// @Failure 409
 // @Failure 500
 // @Router /reservations/{id}/return-keys [put]
 func (c *ReservationHandler) ReturnKeys(ctx *gin.Context) {
@@ -330,18 +418,282 @@ func (c *ReservationHandler) ReturnKeys(ctx *gin.Context) {
 		return
 	}
 
+	// an empty body, whatever its declared length, returns the keys without an inspection
+	input := &InspectionRequest{}
+	if err := ctx.ShouldBindJSON(input); errors.Is(err, io.EOF) {
+		input = nil
+	} else if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input != nil {
+		switch input.Cleanliness {
+		case "", domain.CleanlinessOK, domain.CleanlinessNeedsCleaning, domain.CleanlinessDirty:
+		default:
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Estado de limpeza inválido"})
+			return
+		}
+	}
+
+	if input != nil {
+		_, err := c.inspectionRepo.GetByReservation(reservation.ID)
+		if err == nil {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "A vistoria desta reserva já foi registrada"})
+			return
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	now := time.Now()
 	reservation.KeysReturnedAt = &now
 	reservation.Status = domain.StatusKeysReturned
+	reservation.KeysOverdueAlertLevel = 0
 
-	if err := c.repo.Update(reservation); err != nil {
+	var inspection *domain.ReservationInspection
+	var entries []financeDomain.FinancialEntry
+	if input != nil {
+		inspection, entries = newInspection(ctx, reservation, input, now)
+	}
+
+	if err := c.financeRepo.SaveWithReservation(reservation, inspection, entries); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	if inspection != nil && inspection.HasFindings() {
+		eventbus.Publish("ReservationInspected", &eventbus.ReservationInspectedEvent{
+			ReservationID: &reservation.ID,
+			Channel:       string(reminderDomain.ReminderChannelWhatsApp),
+			Findings:      inspection.Summary(),
+			DamageAmount:  inspection.DamageAmount,
+		})
+	}
+
 	ctx.JSON(http.StatusOK, reservation)
 }
 
+// newInspection builds the checklist filled when the keys are returned and one damage charge entry
+// for each damage found
+func newInspection(ctx *gin.Context, reservation *domain.Reservation, input *InspectionRequest, now time.Time) (*domain.ReservationInspection, []financeDomain.FinancialEntry) {
+	inspection := &domain.ReservationInspection{
+		InspectedByID:    middleware.GetUserID(ctx),
+		Cleanliness:      input.Cleanliness,
+		BrokenItems:      input.BrokenItems,
+		MissingEquipment: input.MissingEquipment,
+		Notes:            input.Notes,
+		InspectedAt:      now,
+	}
+
+	if inspection.Cleanliness == "" {
+		inspection.Cleanliness = domain.CleanlinessOK
+	}
+
+	entries := make([]financeDomain.FinancialEntry, 0, len(input.DamageCharges))
+	for _, charge := range input.DamageCharges {
+		inspection.DamageAmount += charge.Amount
+		entries = append(entries, financeDomain.FinancialEntry{
+			Type:          financeDomain.EntryDamageCharge,
+			Amount:        charge.Amount,
+			PaymentMethod: reservation.PaymentMethod,
+			Description:   charge.Description,
+			OccurredAt:    now,
+		})
+	}
+
+	return inspection, entries
+}
+
+// GetInspection godoc
+// @Summary Get the inspection of a reservation
+// @Description Retrieves the checklist filled when the keys were returned, with its photos
+// @Tags Reservations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Success 200 {object} domain.ReservationInspection
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /reservations/{id}/inspection [get]
+func (c *ReservationHandler) GetInspection(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	inspection, err := c.inspectionRepo.GetByReservation(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Vistoria não encontrada"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, inspection)
+}
+
+// UploadInspectionPhotos godoc
+// @Summary Upload inspection photos
+// @Description Attaches one or more photos to the inspection of a reservation
+// @Tags Reservations
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Param photos formData file true "Photos"
+// @Success 201 {array} domain.InspectionPhoto
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 413
+// @Failure 500
+// @Router /reservations/{id}/inspection/photos [post]
+func (c *ReservationHandler) UploadInspectionPhotos(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	inspection, err := c.inspectionRepo.GetByReservation(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Vistoria não encontrada"})
+		return
+	}
+
+	form, err := ctx.MultipartForm()
+	if err != nil || len(form.File["photos"]) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Nenhuma foto enviada"})
+		return
+	}
+
+	photos := []domain.InspectionPhoto{}
+	for _, file := range form.File["photos"] {
+		if file.Size > pkgDomain.MaxAttachmentSize {
+			ctx.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": pkgDomain.ErrAttachmentTooLarge.Error()})
+			return
+		}
+
+		content, err := readUploadedFile(file)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		// the type sent by the client is not trusted, the content is sniffed like in package attachments
+		contentType, err := pkgDomain.DetectAttachmentType(content)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, pkgDomain.ErrAttachmentTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			ctx.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
+
+		photo := domain.NewInspectionPhoto(inspection.ID, file.Filename, contentType, pkgDomain.AttachmentExtension(contentType), time.Now())
+		if err := c.store.Put(ctx.Request.Context(), photo.StorageKey, bytes.NewReader(content), int64(len(content)), contentType); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("erro ao salvar a foto: %v", err)})
+			return
+		}
+
+		if err := c.inspectionRepo.AddPhoto(photo); err != nil {
+			if deleteErr := c.store.Delete(ctx.Request.Context(), photo.StorageKey); deleteErr != nil {
+				log.Printf("Erro ao remover o arquivo %s do armazenamento: %v", photo.StorageKey, deleteErr)
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		photos = append(photos, *photo)
+	}
+
+	ctx.JSON(http.StatusCreated, photos)
+}
+
+// GetInspectionPhoto godoc
+// @Summary Download an inspection photo
+// @Description Returns the file of a photo attached to the inspection of a reservation
+// @Tags Reservations
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Param photoId path int true "Photo ID"
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /reservations/{id}/inspection/photos/{photoId} [get]
+func (c *ReservationHandler) GetInspectionPhoto(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	photoID, err := strconv.ParseUint(ctx.Param("photoId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID da foto inválido"})
+		return
+	}
+
+	inspection, err := c.inspectionRepo.GetByReservation(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Vistoria não encontrada"})
+		return
+	}
+
+	photo, err := c.inspectionRepo.GetPhoto(uint(photoID))
+	if err != nil || photo.InspectionID != inspection.ID {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Foto não encontrada"})
+		return
+	}
+
+	content, err := c.store.Get(ctx.Request.Context(), photo.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Foto não encontrada"})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, -1, photo.ContentType, content, map[string]string{
+		"Content-Disposition": "attachment; filename=" + strconv.Quote(photo.FileName),
+	})
+}
+
+// ListCleanlinessStatus godoc
+// @Summary List all cleanliness statuses
+// @Description Returns the list of possible cleanliness statuses of an inspection
+// @Tags Reservations
+// @Produce json
+// @Success 200 {array} domain.CleanlinessStatus "List of cleanliness statuses"
+// @Router /reservations/cleanlinessStatus [get]
+func (c *ReservationHandler) ListCleanlinessStatus(ctx *gin.Context) {
+	statuses := []domain.CleanlinessStatus{
+		domain.CleanlinessOK,
+		domain.CleanlinessNeedsCleaning,
+		domain.CleanlinessDirty,
+	}
+
+	ctx.JSON(http.StatusOK, statuses)
+}
+
+func readUploadedFile(file *multipart.FileHeader) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return io.ReadAll(io.LimitReader(reader, pkgDomain.MaxAttachmentSize+1))
+}
+
 // Complete godoc
 // @Summary Mark reservation as complete
 // @Description Updates the reservation status to "keys returned" (complete)
@@ -391,11 +743,13 @@ func (c *ReservationHandler) ConfirmPayment(ctx *gin.Context) {
 		return
 	}
 
-	if reservation.PaymentStatus == domain.PaymentPaid {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Pagamento já foi confirmado anteriormente"})
+	if err := reservation.CanConfirmPayment(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
+	wasPending := reservation.Status == domain.StatusPending
+
 	now := time.Now()
 	reservation.PaymentStatus = domain.PaymentPaid
 	reservation.PaymentAmount = input.PaymentAmount
@@ -407,6 +761,18 @@ func (c *ReservationHandler) ConfirmPayment(ctx *gin.Context) {
 		return
 	}
 
+	eventbus.Publish("ReservationPaid", &eventbus.ReservationPaidEvent{
+		ReservationID: &reservation.ID,
+		Channel:       string(reminderDomain.ReminderChannelWhatsApp),
+	})
+
+	if wasPending {
+		eventbus.Publish("ReservationConfirmed", &eventbus.ReservationConfirmedEvent{
+			ReservationID: &reservation.ID,
+			Channel:       string(reminderDomain.ReminderChannelWhatsApp),
+		})
+	}
+
 	ctx.JSON(http.StatusOK, reservation)
 }
 
@@ -542,49 +908,187 @@ func (c *ReservationHandler) GetUpcomingReservations(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, reservations)
 }
 
-func (c *ReservationHandler) UpdateStatus(ctx *gin.Context, status domain.ReservationStatus) {
+// GetOverdueKeys godoc
+// @Summary Get reservations with overdue keys
+// @Description Retrieve reservations whose keys were taken and not returned after the end of the event plus the grace period
+// @Tags Reservations
+// @Security BearerAuth
+// @Success 200 {array} domain.Reservation
+// @Failure 401
+// @Failure 500
+// @Router /reservations/overdue-keys [get]
+func (c *ReservationHandler) GetOverdueKeys(ctx *gin.Context) {
+	reservations, err := c.repo.FindOverdueKeys(time.Now().Add(-domain.KeysReturnGracePeriod()))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, reservations)
+}
+
+func (c *ReservationHandler) UpdateStatus(ctx *gin.Context, status domain.ReservationStatus) *domain.Reservation {
 	idParam := ctx.Param("id")
 	id, err := strconv.ParseUint(idParam, 10, 32)
 	if err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
-		return
+		return nil
 	}
 
 	reservation, err := c.repo.GetByID(uint(id))
 	if err != nil {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "Reserva não encontrada"})
-		return
+		return nil
 	}
 
 	reservation.Status = status
 
 	if err := c.repo.Update(reservation); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
+		return nil
 	}
 
 	ctx.JSON(http.StatusOK, reservation)
+	return reservation
+}
+
+// GetPixPayment godoc
+// @Summary Get the PIX payment code of a reservation
+// @Description Returns the BR Code "copia e cola" payload with the condominium PIX key, the reservation amount and a txid tied to the reservation
+// @Tags Reservations
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /reservations/{id}/pix [get]
+func (c *ReservationHandler) GetPixPayment(ctx *gin.Context) {
+	reservation, payload, ok := c.getPixPayload(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"txid":    reservation.GetPixTxID(),
+		"amount":  reservation.PaymentAmount,
+		"payload": payload,
+	})
+}
+
+// GetPixQRCode godoc
+// @Summary Get the PIX QR code of a reservation
+// @Description Returns the BR Code of the reservation payment as a PNG QR code
+// @Tags Reservations
+// @Produce png
+// @Security BearerAuth
+// @Param id path int true "Reservation ID"
+// @Success 200 {file} file
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /reservations/{id}/pix/qrcode [get]
+func (c *ReservationHandler) GetPixQRCode(ctx *gin.Context) {
+	_, payload, ok := c.getPixPayload(ctx)
+	if !ok {
+		return
+	}
+
+	image, err := pixDomain.QRCodePNG(payload, pixDomain.QRCodeSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Data(http.StatusOK, "image/png", image)
+}
+
+func (c *ReservationHandler) getPixPayload(ctx *gin.Context) (*domain.Reservation, string, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return nil, "", false
+	}
+
+	reservation, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Reserva não encontrada"})
+		return nil, "", false
+	}
+
+	if reservation.PaymentMethod != domain.PaymentMethodPix {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A forma de pagamento da reserva não é PIX"})
+		return nil, "", false
+	}
+
+	if reservation.PaymentStatus != domain.PaymentPending {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Pagamento já foi confirmado anteriormente"})
+		return nil, "", false
+	}
+
+	code, err := pixDomain.NewBRCodeFromEnv(reservation.PaymentAmount, reservation.GetPixTxID())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, "", false
+	}
+
+	payload, err := code.Payload()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, "", false
+	}
+
+	return reservation, payload, true
 }
 
 // ImportSalonReservations godoc
 // @Summary Import salon reservations from CSV
-// @Description Imports reservations data from a CSV file for salon spaces
+// @Description Imports salon reservations from an uploaded CSV with the columns Data, Salao, Unidade (or Bloco and Apto) and Comprovante. Residents are resolved by block and apartment. With dryRun=true nothing is written and the report shows what would be created, updated, skipped or rejected. The reservations are written together: if the database fails on any line, none of them is kept.
 // @Tags Reservations
+// @Accept multipart/form-data
+// @Produce json
 // @Security BearerAuth
-// @Success 200
+// @Param file formData file true "CSV file"
+// @Param dryRun query bool false "Only validate the file, without writing"
+// @Success 200 {object} domain.SalonImportReport
+// @Failure 400
+// @Failure 401
 // @Failure 500
 // @Router /reservations/import-salon [post]
 func (c *ReservationHandler) ImportSalonReservations(ctx *gin.Context) {
-	if err := c.importService.ImportReservationsFromCSV(); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{
-			"error": err.Error(),
-		})
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Nenhum arquivo enviado"})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Reservas de salão importadas com sucesso",
-	})
+	if fileHeader.Size > maxImportSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Arquivo muito grande"})
+		return
+	}
+
+	dryRun, _ := strconv.ParseBool(ctx.DefaultQuery("dryRun", "false"))
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	report, err := c.importService.ImportReservationsFromCSV(file, dryRun)
+	if errors.Is(err, domain.ErrInvalidSalonImportFile) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
 }
 
 // ListReservationStatus godoc
@@ -650,6 +1154,7 @@ func (c *ReservationHandler) ListPaymentStatuses(ctx *gin.Context) {
 		domain.PaymentPending,
 		domain.PaymentPaid,
 		domain.PaymentRefunded,
+		domain.PaymentPartiallyRefunded,
 	}
 
 	ctx.JSON(http.StatusOK, paymentStatuses)
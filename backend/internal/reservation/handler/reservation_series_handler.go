@@ -0,0 +1,514 @@
+package reservation
+
+import (
+	"net/http"
+	middleware "portarius/internal/middleware/auth"
+	"portarius/internal/reservation/domain"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReservationSeriesHandler struct {
+	repo domain.IReservationSeriesRepository
+}
+
+type ReservationSeriesRequest struct {
+	Space       domain.SpaceType  `json:"space" binding:"required"`
+	Kind        domain.SeriesKind `json:"kind"`
+	Title       string            `json:"title" binding:"required"`
+	ResidentID  *uint             `json:"resident_id"`
+	RRule       string            `json:"rrule" binding:"required"`
+	StartTime   time.Time         `json:"start_time" binding:"required"`
+	EndTime     time.Time         `json:"end_time" binding:"required"`
+	Description string            `json:"description"`
+}
+
+type OccurrenceChangeRequest struct {
+	OccurrenceStart time.Time        `json:"occurrence_start" binding:"required"`
+	Scope           domain.EditScope `json:"scope" binding:"required"`
+	StartTime       *time.Time       `json:"start_time"`
+	EndTime         *time.Time       `json:"end_time"`
+	Title           string           `json:"title"`
+	Description     string           `json:"description"`
+	RRule           string           `json:"rrule"`
+}
+
+type OccurrenceCancelRequest struct {
+	OccurrenceStart time.Time        `json:"occurrence_start" binding:"required"`
+	Scope           domain.EditScope `json:"scope" binding:"required"`
+}
+
+func NewReservationSeriesHandler(repo domain.IReservationSeriesRepository) *ReservationSeriesHandler {
+	return &ReservationSeriesHandler{repo: repo}
+}
+
+// GetAll godoc
+// @Summary List recurring reservations
+// @Description Returns every recurring reservation and maintenance block with its exceptions
+// @Tags ReservationSeries
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.ReservationSeries
+// @Failure 401
+// @Failure 500
+// @Router /reservation-series [get]
+func (c *ReservationSeriesHandler) GetAll(ctx *gin.Context) {
+	series, err := c.repo.GetAll()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, series)
+}
+
+// GetByID godoc
+// @Summary Get a recurring reservation
+// @Description Returns a recurring reservation or maintenance block with its exceptions
+// @Tags ReservationSeries
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Success 200 {object} domain.ReservationSeries
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /reservation-series/{id} [get]
+func (c *ReservationSeriesHandler) GetByID(ctx *gin.Context) {
+	series, ok := c.findSeries(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, series)
+}
+
+// Create godoc
+// @Summary Create a recurring reservation
+// @Description Creates a recurring reservation or maintenance block. The rule follows the iCalendar RRULE syntax (FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY, BYMONTHDAY, COUNT, UNTIL) and start_time/end_time describe the first occurrence. Every coming occurrence is checked against the existing reservations and the other series of the space.
+// @Tags ReservationSeries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param series body ReservationSeriesRequest true "Series data"
+// @Success 201 {object} domain.ReservationSeries
+// @Failure 400
+// @Failure 401
+// @Failure 409
+// @Failure 500
+// @Router /reservation-series [post]
+func (c *ReservationSeriesHandler) Create(ctx *gin.Context) {
+	var input ReservationSeriesRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	kind, err := domain.ParseSeriesKind(string(input.Kind))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series := &domain.ReservationSeries{
+		Space:       input.Space,
+		Kind:        kind,
+		Title:       input.Title,
+		ResidentID:  input.ResidentID,
+		RRule:       input.RRule,
+		StartTime:   input.StartTime,
+		EndTime:     input.EndTime,
+		Description: input.Description,
+		CreatedByID: middleware.GetUserID(ctx),
+	}
+
+	if _, err := series.Validate(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.repo.CheckSeriesConflict(series, time.Now()); err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.repo.Create(series); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, series)
+}
+
+// GetOccurrences godoc
+// @Summary List the occurrences of a recurring reservation
+// @Description Expands the series between from and to (format: yyyy-MM-dd), applying cancelled and moved occurrences
+// @Tags ReservationSeries
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param from query string true "Start date in format yyyy-MM-dd"
+// @Param to query string true "End date in format yyyy-MM-dd"
+// @Success 200 {array} domain.SeriesOccurrence
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /reservation-series/{id}/occurrences [get]
+func (c *ReservationSeriesHandler) GetOccurrences(ctx *gin.Context) {
+	from, to, ok := parseOccurrenceRange(ctx)
+	if !ok {
+		return
+	}
+
+	series, ok := c.findSeries(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, series.Occurrences(from, to))
+}
+
+// GetSpaceOccurrences godoc
+// @Summary List the recurring occurrences of a space
+// @Description Expands every series of the space between from and to (format: yyyy-MM-dd)
+// @Tags ReservationSeries
+// @Produce json
+// @Security BearerAuth
+// @Param space path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Param from query string true "Start date in format yyyy-MM-dd"
+// @Param to query string true "End date in format yyyy-MM-dd"
+// @Success 200 {array} domain.SeriesOccurrence
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /reservation-series/space/{space}/occurrences [get]
+func (c *ReservationSeriesHandler) GetSpaceOccurrences(ctx *gin.Context) {
+	space := ctx.Param("space")
+	if !domain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	from, to, ok := parseOccurrenceRange(ctx)
+	if !ok {
+		return
+	}
+
+	series, err := c.repo.FindBySpace(space, from, to)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	occurrences := []domain.SeriesOccurrence{}
+	for _, s := range series {
+		occurrences = append(occurrences, s.Occurrences(from, to)...)
+	}
+
+	ctx.JSON(http.StatusOK, occurrences)
+}
+
+// UpdateOccurrence godoc
+// @Summary Edit occurrences of a recurring reservation
+// @Description Changes the occurrence identified by occurrence_start. Scope ESTA moves only that occurrence, ESTA_E_FUTURAS splits the series and changes the occurrence and the following ones, TODAS changes the whole series.
+// @Tags ReservationSeries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param change body OccurrenceChangeRequest true "Change data"
+// @Success 200 {object} domain.ReservationSeries
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /reservation-series/{id}/occurrences [put]
+func (c *ReservationSeriesHandler) UpdateOccurrence(ctx *gin.Context) {
+	var input OccurrenceChangeRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scope, err := domain.ParseEditScope(string(input.Scope))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, ok := c.findSeries(ctx)
+	if !ok {
+		return
+	}
+
+	if !series.HasOccurrenceAt(input.OccurrenceStart) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Ocorrência não encontrada na recorrência"})
+		return
+	}
+
+	change := domain.SeriesChange{
+		StartTime:   input.StartTime,
+		EndTime:     input.EndTime,
+		Title:       input.Title,
+		Description: input.Description,
+		RRule:       input.RRule,
+	}
+
+	if scope == domain.ScopeThisOccurrence {
+		exception, err := series.Reschedule(input.OccurrenceStart, change)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// the moved occurrence is checked on its own, as a single occurrence of the same series
+		moved := &domain.ReservationSeries{
+			Space:     series.Space,
+			RRule:     "FREQ=DAILY;COUNT=1",
+			StartTime: *exception.StartTime,
+			EndTime:   *exception.EndTime,
+		}
+		moved.ID = series.ID
+		if err := c.repo.CheckSeriesConflict(moved, moved.StartTime); err != nil {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := c.repo.SaveException(exception); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.respondWithSeries(ctx, series.ID)
+		return
+	}
+
+	if scope == domain.ScopeThisAndFollowing && !input.OccurrenceStart.Equal(series.StartTime) {
+		following, err := series.SplitAt(input.OccurrenceStart, change)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		// the original series still holds the following occurrences until the split is saved
+		following.ID = series.ID
+		if err := c.repo.CheckSeriesConflict(following, time.Now()); err != nil {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+		following.ID = 0
+
+		if _, err := series.EndBefore(input.OccurrenceStart); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		if err := c.repo.Split(series, following); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.respondWithSeries(ctx, following.ID)
+		return
+	}
+
+	if err := series.Apply(change); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.repo.CheckSeriesConflict(series, time.Now()); err != nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.repo.Update(series); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.respondWithSeries(ctx, series.ID)
+}
+
+// CancelOccurrence godoc
+// @Summary Cancel occurrences of a recurring reservation
+// @Description Cancels the occurrence identified by occurrence_start. Scope ESTA cancels only that occurrence, ESTA_E_FUTURAS ends the series before it and TODAS removes the whole series.
+// @Tags ReservationSeries
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Param cancel body OccurrenceCancelRequest true "Cancellation data"
+// @Success 200 {object} domain.ReservationSeries
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /reservation-series/{id}/occurrences/cancel [post]
+func (c *ReservationSeriesHandler) CancelOccurrence(ctx *gin.Context) {
+	var input OccurrenceCancelRequest
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scope, err := domain.ParseEditScope(string(input.Scope))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, ok := c.findSeries(ctx)
+	if !ok {
+		return
+	}
+
+	if !series.HasOccurrenceAt(input.OccurrenceStart) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Ocorrência não encontrada na recorrência"})
+		return
+	}
+
+	switch scope {
+	case domain.ScopeThisOccurrence:
+		if err := c.repo.SaveException(series.CancelOccurrence(input.OccurrenceStart)); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.respondWithSeries(ctx, series.ID)
+		return
+
+	case domain.ScopeThisAndFollowing:
+		remaining, err := series.EndBefore(input.OccurrenceStart)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if remaining {
+			if err := c.repo.Update(series); err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+				return
+			}
+			c.respondWithSeries(ctx, series.ID)
+			return
+		}
+	}
+
+	if err := c.repo.Delete(series.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// Delete godoc
+// @Summary Delete a recurring reservation
+// @Description Removes the series and all its occurrences
+// @Tags ReservationSeries
+// @Security BearerAuth
+// @Param id path int true "Series ID"
+// @Success 204
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /reservation-series/{id} [delete]
+func (c *ReservationSeriesHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := c.repo.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListSeriesKinds godoc
+// @Summary List all recurring series kinds
+// @Description Returns the list of possible kinds of recurring series
+// @Tags ReservationSeries
+// @Produce json
+// @Success 200 {array} domain.SeriesKind "List of series kinds"
+// @Router /reservation-series/seriesKinds [get]
+func (c *ReservationSeriesHandler) ListSeriesKinds(ctx *gin.Context) {
+	kinds := []domain.SeriesKind{
+		domain.SeriesKindReservation,
+		domain.SeriesKindMaintenance,
+	}
+
+	ctx.JSON(http.StatusOK, kinds)
+}
+
+// ListEditScopes godoc
+// @Summary List all edit scopes
+// @Description Returns the list of possible scopes when editing or cancelling occurrences of a series
+// @Tags ReservationSeries
+// @Produce json
+// @Success 200 {array} domain.EditScope "List of edit scopes"
+// @Router /reservation-series/editScopes [get]
+func (c *ReservationSeriesHandler) ListEditScopes(ctx *gin.Context) {
+	scopes := []domain.EditScope{
+		domain.ScopeThisOccurrence,
+		domain.ScopeThisAndFollowing,
+		domain.ScopeAllOccurrences,
+	}
+
+	ctx.JSON(http.StatusOK, scopes)
+}
+
+func (c *ReservationSeriesHandler) findSeries(ctx *gin.Context) (*domain.ReservationSeries, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return nil, false
+	}
+
+	series, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Recorrência não encontrada"})
+		return nil, false
+	}
+
+	return series, true
+}
+
+func (c *ReservationSeriesHandler) respondWithSeries(ctx *gin.Context, id uint) {
+	series, err := c.repo.GetByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, series)
+}
+
+func parseOccurrenceRange(ctx *gin.Context) (time.Time, time.Time, bool) {
+	from, err := time.Parse("2006-01-02", ctx.Query("from"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data inicial inválida"})
+		return time.Time{}, time.Time{}, false
+	}
+
+	to, err := time.Parse("2006-01-02", ctx.Query("to"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data final inválida"})
+		return time.Time{}, time.Time{}, false
+	}
+
+	if to.Before(from) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A data final deve ser posterior à data inicial"})
+		return time.Time{}, time.Time{}, false
+	}
+
+	if to.Sub(from) > domain.MaxOccurrencesPeriod {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "O período consultado não pode ultrapassar um ano"})
+		return time.Time{}, time.Time{}, false
+	}
+
+	return from, to.AddDate(0, 0, 1), true
+}
@@ -1,5 +1,10 @@
 package interfaces
 
+import (
+	"io"
+	"portarius/internal/reservation/domain"
+)
+
 type ICSVReservationImporter interface {
-	ImportReservationsFromCSV() error
+	ImportReservationsFromCSV(reader io.Reader, dryRun bool) (*domain.SalonImportReport, error)
 }
@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"portarius/internal/reservation/domain"
+
+	"gorm.io/gorm"
+)
+
+type reservationInspectionRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationInspectionRepository(db *gorm.DB) domain.IReservationInspectionRepository {
+	return &reservationInspectionRepository{db: db}
+}
+
+func (r *reservationInspectionRepository) Create(inspection *domain.ReservationInspection) error {
+	return r.db.Create(inspection).Error
+}
+
+func (r *reservationInspectionRepository) GetByReservation(reservationID uint) (*domain.ReservationInspection, error) {
+	var inspection domain.ReservationInspection
+	err := r.db.Preload("Photos").Where("reservation_id = ?", reservationID).First(&inspection).Error
+	return &inspection, err
+}
+
+func (r *reservationInspectionRepository) AddPhoto(photo *domain.InspectionPhoto) error {
+	return r.db.Create(photo).Error
+}
+
+func (r *reservationInspectionRepository) GetPhoto(id uint) (*domain.InspectionPhoto, error) {
+	var photo domain.InspectionPhoto
+	err := r.db.First(&photo, id).Error
+	return &photo, err
+}
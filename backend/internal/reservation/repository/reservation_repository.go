@@ -49,7 +49,7 @@ func (r *reservationRepository) GetByResident(residentID uint) ([]domain.Reserva
 
 func (r *reservationRepository) GetBySpace(space string) ([]domain.Reservation, error) {
 	var reservations []domain.Reservation
-	err := r.db.Where("space = ?", space).Find(&reservations).Error
+	err := r.db.Preload("Resident").Where("space = ?", space).Find(&reservations).Error
 	return reservations, err
 }
 
@@ -65,6 +65,15 @@ func (r *reservationRepository) FindByDateRange(startDate, endDate time.Time) ([
 	return reservations, err
 }
 
+func (r *reservationRepository) FindBySpaceAndDateRange(space string, startDate, endDate time.Time) ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	err := r.db.
+		Where("space = ? AND status != ? AND start_time <= ? AND end_time >= ?", space, domain.StatusCancelled, endDate, startDate).
+		Order("start_time ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
 func (r *reservationRepository) FindUpcomingReservations() ([]domain.Reservation, error) {
 	var reservations []domain.Reservation
 	err := r.db.
@@ -74,6 +83,30 @@ func (r *reservationRepository) FindUpcomingReservations() ([]domain.Reservation
 	return reservations, err
 }
 
+func (r *reservationRepository) FindOverdueKeys(cutoff time.Time) ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	err := r.db.Preload("Resident").
+		Where("status = ? AND end_time < ?", domain.StatusKeysTaken, cutoff).
+		Order("end_time ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *reservationRepository) FindPendingPayments() ([]domain.Reservation, error) {
+	var reservations []domain.Reservation
+	err := r.db.Preload("Resident").
+		Where("payment_status = ? AND status != ?", domain.PaymentPending, domain.StatusCancelled).
+		Order("start_time ASC").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *reservationRepository) FindByResidentAndPeriod(residentID uint, startTime, endTime time.Time) (*domain.Reservation, error) {
+	var reservation domain.Reservation
+	err := r.db.Where("resident_id = ? AND start_time = ? AND end_time = ?", residentID, startTime, endTime).First(&reservation).Error
+	return &reservation, err
+}
+
 func (r *reservationRepository) UpdateStatus(id uint, status string) error {
 	var reservation domain.Reservation
 	if err := r.db.First(&reservation, id).Error; err != nil {
@@ -113,5 +146,44 @@ func (r *reservationRepository) CheckReservationConflict(space string, startTime
 		return fmt.Errorf("já existe uma reserva para este salão no horário selecionado")
 	}
 
-	return nil
+	if err := findBlackoutConflict(r.db, space, startTime, endTime); err != nil {
+		return err
+	}
+
+	return findSeriesConflict(r.db, space, startTime, endTime, 0)
+}
+
+// findBlackoutConflict checks the days the space cannot be booked. Blackouts are whole days, so any
+// day touched by the reservation counts, using the same day boundaries as the availability calendar.
+// The table is queried directly because the space domain depends on the reservation domain.
+func findBlackoutConflict(db *gorm.DB, space string, startTime, endTime time.Time) error {
+	firstDay := domain.StartOfDay(startTime)
+	dayAfter := domain.StartOfDay(endTime.Add(-time.Nanosecond)).AddDate(0, 0, 1)
+
+	var blackout struct {
+		StartDate time.Time
+		EndDate   time.Time
+		Reason    string
+	}
+	err := db.Table("space_blackouts").
+		Select("start_date, end_date, reason").
+		Where("deleted_at IS NULL AND space = ? AND start_date < ? AND end_date >= ?", space, dayAfter, firstDay).
+		Order("start_date ASC").
+		Limit(1).
+		Scan(&blackout).Error
+	if err != nil {
+		return err
+	}
+	if blackout.StartDate.IsZero() {
+		return nil
+	}
+
+	period := blackout.StartDate.Format("02/01/2006")
+	if !blackout.EndDate.Equal(blackout.StartDate) {
+		period += " a " + blackout.EndDate.Format("02/01/2006")
+	}
+	if blackout.Reason == "" {
+		return fmt.Errorf("o salão está bloqueado para reservas de %s", period)
+	}
+	return fmt.Errorf("o salão está bloqueado para reservas de %s: %s", period, blackout.Reason)
 }
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"fmt"
+	"portarius/internal/reservation/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type reservationSeriesRepository struct {
+	db *gorm.DB
+}
+
+func NewReservationSeriesRepository(db *gorm.DB) domain.IReservationSeriesRepository {
+	return &reservationSeriesRepository{db: db}
+}
+
+func (r *reservationSeriesRepository) GetAll() ([]domain.ReservationSeries, error) {
+	var series []domain.ReservationSeries
+	err := r.db.Preload("Resident").Preload("Exceptions").Order("start_time").Find(&series).Error
+	return series, err
+}
+
+func (r *reservationSeriesRepository) GetByID(id uint) (*domain.ReservationSeries, error) {
+	var series domain.ReservationSeries
+	err := r.db.Preload("Resident").Preload("Exceptions").First(&series, id).Error
+	return &series, err
+}
+
+func (r *reservationSeriesRepository) FindBySpace(space string, from, to time.Time) ([]domain.ReservationSeries, error) {
+	series, err := findSeriesBySpace(r.db, space, to, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	active := []domain.ReservationSeries{}
+	for _, s := range series {
+		if len(s.Occurrences(from, to)) > 0 {
+			active = append(active, s)
+		}
+	}
+	return active, nil
+}
+
+func (r *reservationSeriesRepository) Create(series *domain.ReservationSeries) error {
+	return r.db.Omit("Resident").Create(series).Error
+}
+
+// Update saves the series and replaces its exceptions with the ones currently on it
+func (r *reservationSeriesRepository) Update(series *domain.ReservationSeries) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Resident", "Exceptions").Save(series).Error; err != nil {
+			return err
+		}
+
+		keep := []uint{0}
+		for i := range series.Exceptions {
+			series.Exceptions[i].SeriesID = series.ID
+			if err := tx.Save(&series.Exceptions[i]).Error; err != nil {
+				return err
+			}
+			keep = append(keep, series.Exceptions[i].ID)
+		}
+
+		return tx.Unscoped().
+			Where("series_id = ? AND id NOT IN ?", series.ID, keep).
+			Delete(&domain.ReservationSeriesException{}).Error
+	})
+}
+
+func (r *reservationSeriesRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.ReservationSeries{}, id).Error
+}
+
+// Split ends the series before the occurrence where following starts and creates following in the same transaction
+func (r *reservationSeriesRepository) Split(series, following *domain.ReservationSeries) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		repo := &reservationSeriesRepository{db: tx}
+		if err := repo.Update(series); err != nil {
+			return err
+		}
+		return repo.Create(following)
+	})
+}
+
+func (r *reservationSeriesRepository) SaveException(exception *domain.ReservationSeriesException) error {
+	return r.db.Save(exception).Error
+}
+
+// CheckSeriesConflict checks the occurrences of the series from the given time on against the reservations
+// and the other series of the same space, up to the end of the series when it is limited by COUNT or UNTIL.
+// Two series are compared as described in ReservationSeries.ConflictWith.
+func (r *reservationSeriesRepository) CheckSeriesConflict(series *domain.ReservationSeries, from time.Time) error {
+	reservationQuery := r.db.Where("space = ? AND status NOT IN ? AND end_time > ?",
+		series.Space,
+		[]domain.ReservationStatus{domain.StatusCancelled, domain.StatusKeysReturned},
+		from)
+	seriesQuery := r.db.Preload("Exceptions").Where("space = ? AND id != ?", series.Space, series.ID)
+	if end, ok := series.End(); ok {
+		reservationQuery = reservationQuery.Where("start_time < ?", end)
+		seriesQuery = seriesQuery.Where("start_time < ?", end)
+	}
+
+	var reservations []domain.Reservation
+	if err := reservationQuery.Order("start_time").Find(&reservations).Error; err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		start := reservation.StartTime
+		if start.Before(from) {
+			start = from
+		}
+		if occurrence := series.Overlaps(start, reservation.EndTime); occurrence != nil {
+			return fmt.Errorf("a ocorrência de %s conflita com a reserva %d", occurrence.StartTime.Format("02/01/2006 15:04"), reservation.ID)
+		}
+	}
+
+	var others []domain.ReservationSeries
+	if err := seriesQuery.Find(&others).Error; err != nil {
+		return err
+	}
+
+	for i := range others {
+		if occurrence := series.ConflictWith(&others[i], from); occurrence != nil {
+			return fmt.Errorf("a ocorrência de %s conflita com a recorrência \"%s\"", occurrence.StartTime.Format("02/01/2006 15:04"), others[i].Title)
+		}
+	}
+
+	return nil
+}
+
+// findSeriesBySpace loads the series of a space that start before the given time; whether they still
+// have occurrences in a window is decided by expanding the rule.
+func findSeriesBySpace(db *gorm.DB, space string, before time.Time, excludeID uint) ([]domain.ReservationSeries, error) {
+	var series []domain.ReservationSeries
+	query := db.Preload("Exceptions").Where("space = ? AND start_time < ?", space, before)
+	if excludeID > 0 {
+		query = query.Where("id != ?", excludeID)
+	}
+	err := query.Find(&series).Error
+	return series, err
+}
+
+func findSeriesConflict(db *gorm.DB, space string, startTime, endTime time.Time, excludeID uint) error {
+	series, err := findSeriesBySpace(db, space, endTime, excludeID)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range series {
+		if occurrence := s.Overlaps(startTime, endTime); occurrence != nil {
+			if occurrence.Kind == domain.SeriesKindMaintenance {
+				return fmt.Errorf("o salão está bloqueado para \"%s\" no horário selecionado", occurrence.Title)
+			}
+			return fmt.Errorf("o horário selecionado conflita com a reserva recorrente \"%s\"", occurrence.Title)
+		}
+	}
+
+	return nil
+}
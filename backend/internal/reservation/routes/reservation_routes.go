@@ -1,20 +1,35 @@
 package reservation
 
 import (
+	financeDomain "portarius/internal/finance/domain"
+	financeRepository "portarius/internal/finance/repository"
 	"portarius/internal/reservation/domain"
 	reservationHandler "portarius/internal/reservation/handler"
 	"portarius/internal/reservation/repository"
+	reservationService "portarius/internal/reservation/service"
+	residentDomain "portarius/internal/resident/domain"
+	residentRepository "portarius/internal/resident/repository"
+	spaceDomain "portarius/internal/space/domain"
+	spaceRepository "portarius/internal/space/repository"
+	"portarius/internal/storage"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-func RegisterReservationRoutes(router *gin.RouterGroup, db *gorm.DB) {
+func RegisterReservationRoutes(router *gin.RouterGroup, db *gorm.DB, store storage.Storage) {
 	var (
-		repo domain.IReservationRepository = repository.NewReservationRepository(db)
+		repo           domain.IReservationRepository             = repository.NewReservationRepository(db)
+		policyRepo     spaceDomain.ICancellationPolicyRepository = spaceRepository.NewCancellationPolicyRepository(db)
+		financeRepo    financeDomain.IFinancialEntryRepository   = financeRepository.NewFinancialEntryRepository(db)
+		inspectionRepo domain.IReservationInspectionRepository   = repository.NewReservationInspectionRepository(db)
+		seriesRepo     domain.IReservationSeriesRepository       = repository.NewReservationSeriesRepository(db)
+		residentRepo   residentDomain.IResidentRepository        = residentRepository.NewResidentRepository(db)
 	)
 
-	handler := reservationHandler.NewReservationHandler(repo)
+	importer := reservationService.NewReservationImportService(db, repo, residentRepo)
+	handler := reservationHandler.NewReservationHandler(repo, importer, policyRepo, financeRepo, inspectionRepo, store)
+	seriesHandler := reservationHandler.NewReservationSeriesHandler(seriesRepo)
 
 	reservations := router.Group("/reservations")
 	{
@@ -30,17 +45,39 @@ func RegisterReservationRoutes(router *gin.RouterGroup, db *gorm.DB) {
 		reservations.PUT("/:id/return-keys", handler.ReturnKeys)
 		reservations.PUT("/:id/complete", handler.Complete)
 		reservations.PUT("/:id/confirm-payment", handler.ConfirmPayment)
+		reservations.GET("/:id/pix", handler.GetPixPayment)
+		reservations.GET("/:id/pix/qrcode", handler.GetPixQRCode)
+		reservations.GET("/:id/inspection", handler.GetInspection)
+		reservations.POST("/:id/inspection/photos", handler.UploadInspectionPhotos)
+		reservations.GET("/:id/inspection/photos/:photoId", handler.GetInspectionPhoto)
 
 		reservations.GET("/resident/:residentId", handler.GetByResident)
 		reservations.GET("/space/:space", handler.GetBySpace)
 		reservations.GET("/status/:status", handler.GetByStatus)
 		reservations.GET("/date-range", handler.GetByDateRange)
 		reservations.GET("/upcoming", handler.GetUpcomingReservations)
+		reservations.GET("/overdue-keys", handler.GetOverdueKeys)
 
 		reservations.POST("/import-salon", handler.ImportSalonReservations)
 		reservations.GET("/reservationStatus", handler.ListReservationStatus)
 		reservations.GET("/spaceTypes", handler.ListSpaceTypes)
 		reservations.GET("/paymentMethods", handler.ListPaymentMethods)
 		reservations.GET("/paymentStatuses", handler.ListPaymentStatuses)
+		reservations.GET("/cleanlinessStatus", handler.ListCleanlinessStatus)
+	}
+
+	series := router.Group("/reservation-series")
+	{
+		series.GET("/", seriesHandler.GetAll)
+		series.POST("/", seriesHandler.Create)
+		series.GET("/:id", seriesHandler.GetByID)
+		series.DELETE("/:id", seriesHandler.Delete)
+		series.GET("/:id/occurrences", seriesHandler.GetOccurrences)
+		series.PUT("/:id/occurrences", seriesHandler.UpdateOccurrence)
+		series.POST("/:id/occurrences/cancel", seriesHandler.CancelOccurrence)
+
+		series.GET("/space/:space/occurrences", seriesHandler.GetSpaceOccurrences)
+		series.GET("/seriesKinds", seriesHandler.ListSeriesKinds)
+		series.GET("/editScopes", seriesHandler.ListEditScopes)
 	}
 }
@@ -0,0 +1,68 @@
+package scheduler
+
+import (
+	"log"
+	"portarius/internal/eventbus"
+	reminderDomain "portarius/internal/reminder/domain"
+	"portarius/internal/reservation/domain"
+	"time"
+
+	cron "github.com/robfig/cron/v3"
+)
+
+type OverdueKeysScheduler struct {
+	repo domain.IReservationRepository
+}
+
+func NewOverdueKeysScheduler(repo domain.IReservationRepository) *OverdueKeysScheduler {
+	return &OverdueKeysScheduler{repo: repo}
+}
+
+func (s *OverdueKeysScheduler) Run() {
+
+	c := cron.New()
+
+	c.AddFunc("0 * * * *", func() {
+		s.CheckOverdueKeys()
+	})
+
+	c.Start()
+}
+
+func (s *OverdueKeysScheduler) CheckOverdueKeys() {
+
+	now := time.Now()
+	grace := domain.KeysReturnGracePeriod()
+
+	reservations, err := s.repo.FindOverdueKeys(now.Add(-grace))
+
+	if err != nil {
+		log.Printf("[OverdueKeysScheduler] Failed to get reservations: %v", err)
+		return
+	}
+
+	for i := range reservations {
+		reservation := &reservations[i]
+
+		level := reservation.OverdueKeysAlertLevel(now, grace)
+		if level <= reservation.KeysOverdueAlertLevel {
+			continue
+		}
+
+		reservation.KeysOverdueAlertLevel = level
+		reservation.KeysOverdueLastAlertAt = &now
+
+		if err := s.repo.Update(reservation); err != nil {
+			log.Printf("[OverdueKeysScheduler] Failed to update reservation %d: %v", reservation.ID, err)
+			continue
+		}
+
+		eventbus.Publish("ReservationKeysOverdue", &eventbus.ReservationKeysOverdueEvent{
+			ReservationID: &reservation.ID,
+			Channel:       string(reminderDomain.ReminderChannelWhatsApp),
+			AlertLevel:    level,
+			HoursOverdue:  reservation.HoursOverdue(now),
+		})
+	}
+
+}
@@ -0,0 +1,110 @@
+package reservation
+
+import (
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+
+	exportDomain "portarius/internal/export/domain"
+	domainReservation "portarius/internal/reservation/domain"
+)
+
+// ReservationExporter exports reservations filtered by resident, space, status and the
+// start_date/end_date range, read like in the date-range endpoint
+type ReservationExporter struct{}
+
+func NewReservationExporter() *ReservationExporter {
+	return &ReservationExporter{}
+}
+
+func (e *ReservationExporter) Entity() exportDomain.ExportEntity {
+	return exportDomain.ExportEntityReservations
+}
+
+func (e *ReservationExporter) Columns() []exportDomain.Column {
+	return []exportDomain.Column{
+		{Key: "id", Header: "ID"},
+		{Key: "resident_id", Header: "ID do morador"},
+		{Key: "resident_name", Header: "Morador"},
+		{Key: "unit", Header: "Unidade"},
+		{Key: "space", Header: "Espaço"},
+		{Key: "start_time", Header: "Início"},
+		{Key: "end_time", Header: "Fim"},
+		{Key: "status", Header: "Status"},
+		{Key: "payment_status", Header: "Status do pagamento"},
+		{Key: "payment_method", Header: "Forma de pagamento"},
+		{Key: "payment_amount", Header: "Valor pago"},
+		{Key: "payment_date", Header: "Data do pagamento"},
+		{Key: "description", Header: "Descrição"},
+	}
+}
+
+func (e *ReservationExporter) Query(db *gorm.DB, filters url.Values) (*gorm.DB, error) {
+	query := db.Model(&domainReservation.Reservation{}).Preload("Resident")
+
+	residentID, err := exportDomain.FilterID(filters, "residentId")
+	if err != nil {
+		return nil, err
+	}
+	if residentID != nil {
+		query = query.Where("resident_id = ?", *residentID)
+	}
+
+	if space := filters.Get("space"); space != "" {
+		query = query.Where("space = ?", strings.ToUpper(space))
+	}
+	if status := filters.Get("status"); status != "" {
+		query = query.Where("status = ?", strings.ToUpper(status))
+	}
+
+	startDate, err := exportDomain.FilterDate(filters, "start_date")
+	if err != nil {
+		return nil, err
+	}
+	if startDate != nil {
+		query = query.Where("start_time >= ?", *startDate)
+	}
+
+	endDate, err := exportDomain.FilterDate(filters, "end_date")
+	if err != nil {
+		return nil, err
+	}
+	if endDate != nil {
+		query = query.Where("start_time <= ?", *endDate)
+	}
+
+	return query, nil
+}
+
+func (e *ReservationExporter) Export(query *gorm.DB, write func(values []any) error) error {
+	var reservations []domainReservation.Reservation
+	return query.FindInBatches(&reservations, exportDomain.ExportBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, reservation := range reservations {
+			var residentName, unit string
+			if reservation.Resident != nil {
+				residentName = reservation.Resident.Name
+				unit = reservation.Resident.Block + reservation.Resident.Apartment
+			}
+
+			if err := write([]any{
+				reservation.ID,
+				reservation.ResidentID,
+				residentName,
+				unit,
+				reservation.Space,
+				reservation.StartTime,
+				reservation.EndTime,
+				reservation.Status,
+				reservation.PaymentStatus,
+				reservation.PaymentMethod,
+				reservation.PaymentAmount,
+				reservation.PaymentDate,
+				reservation.Description,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
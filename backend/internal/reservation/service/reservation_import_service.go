@@ -1,280 +1,276 @@
 package reservation
 
 import (
-	"encoding/csv"
+	"errors"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
-
-	"strings"
-	"time"
+	"io"
+	"sort"
 
 	"gorm.io/gorm"
 
+	importerDomain "portarius/internal/importer/domain"
 	domainReservation "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+	domainResident "portarius/internal/resident/domain"
+	residentRepository "portarius/internal/resident/repository"
 )
 
-var monthMap = map[string]int{
-	"jan": 1,
-	"fev": 2,
-	"mar": 3,
-	"abr": 4,
-	"mai": 5,
-	"jun": 6,
-	"jul": 7,
-	"ago": 8,
-	"set": 9,
-	"out": 10,
-	"nov": 11,
-	"dez": 12,
-}
-
-var residentMap = map[string]uint{
-	"A01": 1,
-	"A02": 3,
-	"A03": 5,
-	"A04": 7,
-	"A05": 9,
-	"A06": 10,
-	"A07": 12,
-	"A08": 15,
-	"A09": 16,
-	"A10": 17,
-	"A11": 18,
-	"A13": 20,
-	"A14": 22,
-	"A15": 23,
-	"A16": 26,
-	"A17": 28,
-	"A18": 30,
-	"A20": 31,
-	"B01": 32,
-	"B03": 33,
-	"B05": 34,
-	"B08": 36,
-	"B09": 38,
-	"B10": 39,
-	"B11": 40,
-	"B12": 42,
-	"B13": 43,
-	"B14": 45,
-	"B15": 47,
-	"B16": 48,
-	"B17": 49,
-	"B18": 50,
-	"B19": 51,
-	"B20": 53,
-	"C01": 54,
-	"C02": 55,
-	"C04": 56,
-	"C07": 58,
-	"C08": 60,
-	"C10": 62,
-	"C11": 63,
-	"C12": 64,
-	"C13": 66,
-	"C15": 68,
-	"C16": 71,
-	"C17": 72,
-	"C19": 74,
-	"C20": 76,
-	"D01": 78,
-	"D02": 79,
-	"D03": 80,
-	"D04": 81,
-	"D05": 82,
-	"D06": 84,
-	"D08": 85,
-	"D09": 87,
-	"D10": 88,
-	"D11": 90,
-	"D13": 92,
-	"D15": 93,
-	"D16": 94,
-	"D17": 95,
-	"D18": 96,
-	"D20": 98,
-	"E02": 99,
-	"E04": 101,
-	"E05": 102,
-	"E06": 6,
-	"E07": 103,
-	"E08": 105,
-	"E09": 106,
-	"E10": 108,
-	"E11": 110,
-	"E12": 111,
-	"E13": 112,
-	"E15": 113,
-	"E16": 114,
-	"E17": 115,
-	"E18": 116,
-	"E19": 117,
-	"E20": 118,
-}
-
 type ReservationImportService struct {
-	db *gorm.DB
+	db              *gorm.DB
+	reservationRepo domainReservation.IReservationRepository
+	residentRepo    domainResident.IResidentRepository
 }
 
-func NewReservationImportService(db *gorm.DB) *ReservationImportService {
-	return &ReservationImportService{db: db}
+func NewReservationImportService(db *gorm.DB, reservationRepo domainReservation.IReservationRepository, residentRepo domainResident.IResidentRepository) *ReservationImportService {
+	return &ReservationImportService{
+		db:              db,
+		reservationRepo: reservationRepo,
+		residentRepo:    residentRepo,
+	}
 }
 
-func normalizeUnit(unit string) string {
-	unit = strings.TrimSpace(strings.ToUpper(unit))
-	if len(unit) < 2 {
-		return ""
+// ImportReservationsFromCSV imports salon reservations, resolving the resident of each line by block and apartment.
+// Lines that already match a reservation of the same resident and day update it. In a dry run the report
+// tells what would happen without writing anything. Otherwise the lines are written in a single transaction,
+// so a database failure halfway leaves none of them behind.
+func (s *ReservationImportService) ImportReservationsFromCSV(reader io.Reader, dryRun bool) (*domainReservation.SalonImportReport, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
 	}
 
-	block := unit[0:1]
-	number := strings.TrimLeft(unit[1:], "0")
-	if number == "" {
-		number = "0"
+	rows, failures, err := ReadSalonRows(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domainReservation.ErrInvalidSalonImportFile, err)
 	}
-	return fmt.Sprintf("%s%02s", block, number)
-}
-
-func (s *ReservationImportService) ImportSalonReservationsFromCSV() error {
 
-	etlPath := filepath.Join("..", "resources", "ETL", "salon")
-	absPath, err := filepath.Abs(etlPath)
-	if err != nil {
-		return fmt.Errorf("erro ao obter caminho absoluto: %v", err)
+	report := domainReservation.NewSalonImportReport(dryRun)
+	for _, failure := range failures {
+		report.Add(failure)
 	}
 
-	files, err := filepath.Glob(filepath.Join(absPath, "*.csv"))
+	if dryRun {
+		err = s.importRows(rows, report, true)
+	} else {
+		err = s.db.Transaction(func(tx *gorm.DB) error {
+			service := NewReservationImportService(tx, reservationRepository.NewReservationRepository(tx), residentRepository.NewResidentRepository(tx))
+			return service.importRows(rows, report, false)
+		})
+	}
 	if err != nil {
-		return fmt.Errorf("erro ao buscar arquivos CSV: %v", err)
+		return nil, err
 	}
 
-	if len(files) == 0 {
-		return fmt.Errorf("nenhum arquivo CSV encontrado em: %s", absPath)
-	}
+	sort.SliceStable(report.Rows, func(i, j int) bool {
+		return report.Rows[i].Line < report.Rows[j].Line
+	})
+
+	return report, nil
+}
 
-	for _, file := range files {
-		if err := s.processCSVFile(file); err != nil {
-			return fmt.Errorf("erro ao processar arquivo %s: %v", file, err)
+// importRows adds the result of each row to the report, skipping the rows that book a salon already booked
+// by an earlier row of the file
+func (s *ReservationImportService) importRows(rows []domainReservation.SalonImportRow, report *domainReservation.SalonImportReport, dryRun bool) error {
+	seen := map[string]int{}
+
+	for _, row := range rows {
+		key := fmt.Sprintf("%s|%s", row.Space, row.Date.Format("2006-01-02"))
+		if line, ok := seen[key]; ok {
+			result := newSalonImportResult(row)
+			result.Status = domainReservation.SalonImportSkipped
+			result.Reason = fmt.Sprintf("salão já reservado na linha %d do arquivo", line)
+			report.Add(result)
+			continue
+		}
+
+		result, err := s.importRow(row, dryRun)
+		if err != nil {
+			return err
 		}
+		if result.Status != domainReservation.SalonImportError {
+			seen[key] = row.Line
+		}
+		report.Add(result)
 	}
 
 	return nil
 }
 
-func (s *ReservationImportService) processCSVFile(filePath string) error {
-	file, err := os.Open(filePath)
+// importRow creates or updates the reservation of a line. Problems with the line are reported in the result;
+// the returned error is reserved for failures reading or writing the database.
+func (s *ReservationImportService) importRow(row domainReservation.SalonImportRow, dryRun bool) (domainReservation.SalonImportResult, error) {
+	result := newSalonImportResult(row)
+	start, end := row.Period()
+
+	residents, err := s.residentRepo.FindByUnit(row.Block, row.Apartment)
 	if err != nil {
-		return fmt.Errorf("erro ao abrir arquivo: %v", err)
+		return result, fmt.Errorf("erro ao buscar moradores da unidade %s: %v", result.Unit, err)
 	}
-	defer file.Close()
-
-	reader := csv.NewReader(file)
-	reader.Comma = ','
 
-	if _, err := reader.Read(); err != nil {
-		return fmt.Errorf("erro ao ler cabeçalho: %v", err)
+	if len(residents) == 0 {
+		result.Status = domainReservation.SalonImportSkipped
+		result.Reason = fmt.Sprintf("nenhum morador cadastrado no bloco %s, apartamento %s", row.Block, row.Apartment)
+		return result, nil
 	}
+	residentID := residents[0].ID
+	result.ResidentID = &residentID
 
-	var processados, ignorados int
-	for {
-		record, err := reader.Read()
-		if err != nil {
-			if err.Error() == "EOF" {
-				break
-			}
-			return fmt.Errorf("erro ao ler registro: %v", err)
-		}
+	existing, err := s.reservationRepo.FindByResidentAndPeriod(residentID, start, end)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return result, fmt.Errorf("erro ao verificar reserva existente: %v", err)
+	}
 
-		if len(record) < 4 {
-			ignorados++
-			continue
-		}
+	var excludeID uint
+	if err == nil {
+		excludeID = existing.ID
+		result.ReservationID = &existing.ID
+	}
 
-		data := strings.TrimSpace(record[0])
-		salao := strings.TrimSpace(record[1])
-		unidade := normalizeUnit(record[2])
-		formaPagamento := strings.TrimSpace(record[3])
+	if err := s.reservationRepo.CheckReservationConflict(string(row.Space), start, end, excludeID); err != nil {
+		result.Status = domainReservation.SalonImportError
+		result.Reason = err.Error()
+		return result, nil
+	}
 
-		if unidade == "" {
-			log.Printf("Unidade inválida: %s", record[2])
-			ignorados++
-			continue
+	if excludeID > 0 {
+		if !row.ApplyTo(existing) {
+			result.Status = domainReservation.SalonImportSkipped
+			result.Reason = "reserva já importada"
+			return result, nil
 		}
 
-		if strings.Contains("FGH", unidade[0:1]) {
-			log.Printf("Bloco não cadastrado: %s", unidade)
-			ignorados++
-			continue
+		if !dryRun {
+			if err := s.reservationRepo.Update(existing); err != nil {
+				return result, fmt.Errorf("erro ao atualizar reserva da linha %d: %v", row.Line, err)
+			}
 		}
 
-		residentID, ok := residentMap[unidade]
-		if !ok {
-			log.Printf("Residente não encontrado para unidade: %s", unidade)
-			ignorados++
-			continue
+		result.Status = domainReservation.SalonImportUpdated
+		return result, nil
+	}
+
+	reservation := row.Reservation(residentID)
+	if !dryRun {
+		if err := s.reservationRepo.Create(reservation); err != nil {
+			return result, fmt.Errorf("erro ao criar reserva da linha %d: %v", row.Line, err)
 		}
+		result.ReservationID = &reservation.ID
+	}
 
-		var date time.Time
+	result.Status = domainReservation.SalonImportCreated
+	return result, nil
+}
 
-		date, err = time.Parse(time.RFC3339, data)
-		if err != nil {
+// ReadSalonRows reads a salon reservations CSV with the columns of SalonRowImporter, the same the import
+// jobs use. Lines that cannot be parsed are returned as errored results so the import can go on with the
+// valid ones. Units may be given in a single column ("A30") or as separate block and apartment columns.
+func ReadSalonRows(data []byte) ([]domainReservation.SalonImportRow, []domainReservation.SalonImportResult, error) {
+	table, err := importerDomain.ReadTable(importerDomain.ImportFormatCSV, data)
+	if err != nil {
+		return nil, nil, err
+	}
 
-			date, err = time.Parse("2006-01-02", data)
-			if err != nil {
-				log.Printf("Erro ao converter data %s: %v", data, err)
-				ignorados++
-				continue
-			}
-		}
+	columns, err := importerDomain.MapColumns(table.Header, NewSalonRowImporter().Columns())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	_, hasUnit := columns["unit"]
+	_, hasBlock := columns["block"]
+	_, hasApartment := columns["apartment"]
+	if !hasUnit && !(hasBlock && hasApartment) {
+		return nil, nil, fmt.Errorf("o cabeçalho deve ter a coluna Unidade ou as colunas Bloco e Apto")
+	}
 
-		date, err = time.Parse("2006-01-02", data[:10])
+	rows := []domainReservation.SalonImportRow{}
+	failures := []domainReservation.SalonImportResult{}
 
-		fmt.Println(data[:10])
+	for i, values := range table.Rows {
+		// the header is line 1 of the file
+		row := importerDomain.NewRow(i+2, values, columns)
 
-		reserva := &domainReservation.Reservation{
-			ResidentID:    &residentID,
-			Space:         domainReservation.Salon1,
-			StartTime:     time.Date(date.Year(), date.Month(), date.Day(), 8, 0, 0, 0, time.UTC),
-			EndTime:       time.Date(date.Year(), date.Month(), date.Day(), 20, 0, 0, 0, time.UTC),
-			Status:        domainReservation.StatusConfirmed,
-			PaymentStatus: domainReservation.PaymentPaid,
-			PaymentMethod: domainReservation.PaymentMethodBoleto,
+		salonRow, err := newSalonRow(row)
+		if err != nil {
+			failures = append(failures, domainReservation.SalonImportResult{
+				Line:   row.Line,
+				Unit:   salonUnit(row),
+				Date:   row.Get("date"),
+				Status: domainReservation.SalonImportError,
+				Reason: err.Error(),
+			})
+			continue
 		}
+		rows = append(rows, salonRow)
+	}
 
-		if strings.Contains(strings.ToUpper(salao), "2") {
-			reserva.Space = domainReservation.Salon2
-		}
+	return rows, failures, nil
+}
 
-		if strings.Contains(strings.ToUpper(formaPagamento), "PIX") {
-			reserva.PaymentMethod = domainReservation.PaymentMethodPix
-		}
+func salonUnit(row importerDomain.Row) string {
+	if unit := row.Get("unit"); unit != "" {
+		return unit
+	}
+	return row.Get("block") + row.Get("apartment")
+}
 
-		paymentDate := time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
-		reserva.PaymentDate = &paymentDate
+func newSalonRow(row importerDomain.Row) (domainReservation.SalonImportRow, error) {
+	unit := salonUnit(row)
+	if unit == "" {
+		return domainReservation.SalonImportRow{}, fmt.Errorf("a unidade é obrigatória")
+	}
+	return domainReservation.NewSalonImportRow(row.Line, unit, row.Get("date"), row.Get("space"), row.Get("payment"))
+}
 
-		var existingReservation domainReservation.Reservation
-		result := s.db.Where("resident_id = ? AND start_time = ? AND end_time = ?",
-			reserva.ResidentID, reserva.StartTime, reserva.EndTime).First(&existingReservation)
+func newSalonImportResult(row domainReservation.SalonImportRow) domainReservation.SalonImportResult {
+	return domainReservation.SalonImportResult{
+		Line:  row.Line,
+		Unit:  row.Block + row.Apartment,
+		Date:  row.Date.Format("2006-01-02"),
+		Space: row.Space,
+	}
+}
 
-		if result.Error == nil {
+// SalonRowImporter imports salon reservations through the import jobs, with the same columns and rules as
+// the CSV import of the reservations
+type SalonRowImporter struct{}
 
-			if err := s.db.Model(&existingReservation).Updates(reserva).Error; err != nil {
-				return fmt.Errorf("erro ao atualizar reserva: %v", err)
-			}
-		} else if result.Error == gorm.ErrRecordNotFound {
+func NewSalonRowImporter() *SalonRowImporter {
+	return &SalonRowImporter{}
+}
 
-			if err := s.db.Create(reserva).Error; err != nil {
-				return fmt.Errorf("erro ao criar reserva: %v", err)
-			}
-		} else {
-			return fmt.Errorf("erro ao verificar reserva existente: %v", result.Error)
-		}
+func (i *SalonRowImporter) Kind() importerDomain.ImportKind {
+	return importerDomain.ImportKindSalonReservations
+}
 
-		processados++
+func (i *SalonRowImporter) Columns() []importerDomain.Column {
+	return []importerDomain.Column{
+		{Field: "date", Aliases: []string{"Data", "Data da reserva"}, Required: true},
+		{Field: "space", Aliases: []string{"Salão", "Espaço"}},
+		{Field: "unit", Aliases: []string{"Unidade"}},
+		{Field: "block", Aliases: []string{"Bloco"}},
+		{Field: "apartment", Aliases: []string{"Apto", "Apartamento"}},
+		{Field: "payment", Aliases: []string{"Comprovante", "Pagamento", "Forma de pagamento"}},
 	}
+}
 
-	log.Printf("Arquivo %s processado. Registros processados: %d, ignorados: %d",
-		filepath.Base(filePath), processados, ignorados)
-	return nil
+func (i *SalonRowImporter) ImportRow(tx *gorm.DB, row importerDomain.Row) error {
+	salonRow, err := newSalonRow(row)
+	if err != nil {
+		return err
+	}
+
+	service := NewReservationImportService(tx, reservationRepository.NewReservationRepository(tx), residentRepository.NewResidentRepository(tx))
+	result, err := service.importRow(salonRow, false)
+	if err != nil {
+		return err
+	}
+
+	switch result.Status {
+	case domainReservation.SalonImportSkipped:
+		return fmt.Errorf("%w: %s", importerDomain.ErrRowSkipped, result.Reason)
+	case domainReservation.SalonImportError:
+		return errors.New(result.Reason)
+	default:
+		return nil
+	}
 }
@@ -0,0 +1,63 @@
+package reservation_test
+
+import (
+	"portarius/internal/reservation/domain"
+	reservationService "portarius/internal/reservation/service"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadSalonRows(t *testing.T) {
+	content := strings.Join([]string{
+		"Data,Salão,Unidade,Comprovante",
+		"2025-01-01T00:00:00Z,SALAO_1,A30,PIX",
+		"2025-01-23,SALAO_2,B040,BOLETO",
+		"23/02/2025,Salão 1,XX,PIX",
+		"ontem,SALAO_1,C01,PIX",
+		"",
+	}, "\n")
+
+	rows, failures, err := reservationService.ReadSalonRows([]byte(content))
+	require.NoError(t, err)
+
+	require.Len(t, rows, 2)
+	assert.Equal(t, 2, rows[0].Line)
+	assert.Equal(t, "A", rows[0].Block)
+	assert.Equal(t, "30", rows[0].Apartment)
+	assert.Equal(t, domain.Salon1, rows[0].Space)
+	assert.Equal(t, domain.PaymentMethodPix, rows[0].PaymentMethod)
+
+	assert.Equal(t, "40", rows[1].Apartment)
+	assert.Equal(t, domain.Salon2, rows[1].Space)
+	assert.Equal(t, domain.PaymentMethodBoleto, rows[1].PaymentMethod)
+
+	start, end := rows[1].Period()
+	assert.Equal(t, time.Date(2025, time.January, 23, 8, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2025, time.January, 23, 20, 0, 0, 0, time.UTC), end)
+
+	require.Len(t, failures, 2)
+	assert.Equal(t, 4, failures[0].Line)
+	assert.Equal(t, domain.SalonImportError, failures[0].Status)
+	assert.Contains(t, failures[0].Reason, "unidade inválida")
+	assert.Equal(t, 5, failures[1].Line)
+	assert.Contains(t, failures[1].Reason, "data inválida")
+}
+
+func TestReadSalonRows_BlockAndApartmentColumns(t *testing.T) {
+	rows, failures, err := reservationService.ReadSalonRows([]byte("Bloco;Apto;Data\nA;01;2025-03-10\n;;\n"))
+	require.NoError(t, err)
+	assert.Empty(t, failures)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "A", rows[0].Block)
+	assert.Equal(t, "1", rows[0].Apartment)
+
+	_, _, err = reservationService.ReadSalonRows([]byte("Data,Salao\n2025-03-10,SALAO_1\n"))
+	assert.Error(t, err)
+
+	_, _, err = reservationService.ReadSalonRows([]byte("Unidade,Salao\nA30,SALAO_1\n"))
+	assert.Error(t, err)
+}
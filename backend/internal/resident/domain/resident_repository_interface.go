@@ -6,6 +6,7 @@ type IResidentRepository interface {
 	Create(resident *Resident) error
 	Update(resident *Resident) error
 	Delete(id uint) error
+	FindByUnit(block, apartment string) ([]Resident, error)
 	GetPhoneByReservationID(reservationID uint) (string, error)
 	GetPhoneByPackageID(packageID uint) (string, error)
 }
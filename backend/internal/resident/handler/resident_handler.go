@@ -3,7 +3,6 @@ package handler
 import (
 	"net/http"
 	"portarius/internal/resident/domain"
-	"portarius/internal/resident/interfaces"
 
 	"strconv"
 
@@ -11,14 +10,12 @@ import (
 )
 
 type ResidentHandler struct {
-	repo          domain.IResidentRepository
-	importService interfaces.ICSVResidentImporter
+	repo domain.IResidentRepository
 }
 
-func NewResidentHandler(repo domain.IResidentRepository, importer interfaces.ICSVResidentImporter) *ResidentHandler {
+func NewResidentHandler(repo domain.IResidentRepository) *ResidentHandler {
 	return &ResidentHandler{
-		repo:          repo,
-		importService: importer,
+		repo: repo,
 	}
 }
 
@@ -159,25 +156,6 @@ func (c *ResidentHandler) Delete(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Morador excluído com sucesso"})
 }
 
-// ImportResidents godoc
-// @Summary Import residents from CSV
-// @Description Imports residents data from a CSV file (implementation-specific).
-// @Tags Residents
-// @Produce json
-// @Success 200
-// @Failure 400
-// @Failure 401
-// @Failure 404
-// @Failure 500
-// @Router /residents/import [post]
-func (c *ResidentHandler) ImportResidents(ctx *gin.Context) {
-	if err := c.importService.ImportResidentsFromCSV(); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "Moradores importados com sucesso"})
-}
-
 // ListResidentType godoc
 // @Summary List all resident types
 // @Description Returns the list of possible resident types.
@@ -1,5 +0,0 @@
-package interfaces
-
-type ICSVResidentImporter interface {
-	ImportResidentsFromCSV() error
-}
@@ -116,4 +116,16 @@ func (m *MockIResidentRepository) GetPhoneByReservationID(arg0 uint) (string, er
 func (mr *MockIResidentRepositoryMockRecorder) GetPhoneByReservationID(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPhoneByReservationID", reflect.TypeOf((*MockIResidentRepository)(nil).GetPhoneByReservationID), arg0)
-}
\ No newline at end of file
+}
+func (m *MockIResidentRepository) FindByUnit(arg0, arg1 string) ([]residentDomain.Resident, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUnit", arg0, arg1)
+	ret0, _ := ret[0].([]residentDomain.Resident)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockIResidentRepositoryMockRecorder) FindByUnit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUnit", reflect.TypeOf((*MockIResidentRepository)(nil).FindByUnit), arg0, arg1)
+}
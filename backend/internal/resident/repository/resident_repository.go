@@ -41,6 +41,16 @@ func (r *residentRepository) Delete(id uint) error {
 	return r.db.Delete(&domain.Resident{}, id).Error
 }
 
+// FindByUnit returns the residents of a unit, ignoring the case of the block and leading zeros in the apartment
+func (r *residentRepository) FindByUnit(block, apartment string) ([]domain.Resident, error) {
+	var residents []domain.Resident
+	err := r.db.
+		Where("UPPER(block) = UPPER(?) AND COALESCE(NULLIF(LTRIM(apartment, '0'), ''), '0') = ?", block, apartment).
+		Order("id").
+		Find(&residents).Error
+	return residents, err
+}
+
 func (r *residentRepository) GetPhoneByReservationID(reservationID uint) (string, error) {
 	var phone string
 	err := r.db.Model(&reservationDomain.Reservation{}).
@@ -3,9 +3,7 @@ package handler
 import (
 	"portarius/internal/resident/domain"
 	residentHandler "portarius/internal/resident/handler"
-	"portarius/internal/resident/interfaces"
 	"portarius/internal/resident/repository"
-	residentService "portarius/internal/resident/service"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -13,11 +11,10 @@ import (
 
 func ResidentRegisterRoutes(router *gin.RouterGroup, db *gorm.DB) {
 	var (
-		repo     domain.IResidentRepository      = repository.NewResidentRepository(db)
-		importer interfaces.ICSVResidentImporter = residentService.NewResidentImportService(db)
+		repo domain.IResidentRepository = repository.NewResidentRepository(db)
 	)
 
-	handler := residentHandler.NewResidentHandler(repo, importer)
+	handler := residentHandler.NewResidentHandler(repo)
 
 	residents := router.Group("/residents")
 	{
@@ -26,7 +23,6 @@ func ResidentRegisterRoutes(router *gin.RouterGroup, db *gorm.DB) {
 		residents.POST("/", handler.Create)
 		residents.PUT("/:id", handler.Update)
 		residents.DELETE("/:id", handler.Delete)
-		residents.POST("/import", handler.ImportResidents)
 		residents.GET("/residentType", handler.ListResidentType)
 	}
 }
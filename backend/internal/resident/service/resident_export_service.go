@@ -0,0 +1,71 @@
+package resident
+
+import (
+	"net/url"
+	exportDomain "portarius/internal/export/domain"
+	"portarius/internal/resident/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// ResidentExporter exports residents, optionally filtered by block and type
+type ResidentExporter struct{}
+
+func NewResidentExporter() *ResidentExporter {
+	return &ResidentExporter{}
+}
+
+func (e *ResidentExporter) Entity() exportDomain.ExportEntity {
+	return exportDomain.ExportEntityResidents
+}
+
+func (e *ResidentExporter) Columns() []exportDomain.Column {
+	return []exportDomain.Column{
+		{Key: "id", Header: "ID"},
+		{Key: "block", Header: "Bloco"},
+		{Key: "apartment", Header: "Apto"},
+		{Key: "name", Header: "Nome"},
+		{Key: "document", Header: "Documento"},
+		{Key: "email", Header: "E-mail"},
+		{Key: "phone", Header: "Telefone"},
+		{Key: "resident_type", Header: "Tipo"},
+	}
+}
+
+func (e *ResidentExporter) Query(db *gorm.DB, filters url.Values) (*gorm.DB, error) {
+	query := db.Model(&domain.Resident{})
+
+	if block := filters.Get("block"); block != "" {
+		query = query.Where("block = ?", strings.ToUpper(block))
+	}
+	if apartment := filters.Get("apartment"); apartment != "" {
+		query = query.Where("apartment = ?", apartment)
+	}
+	if residentType := filters.Get("residentType"); residentType != "" {
+		query = query.Where("resident_type = ?", strings.ToUpper(residentType))
+	}
+
+	return query, nil
+}
+
+func (e *ResidentExporter) Export(query *gorm.DB, write func(values []any) error) error {
+	var residents []domain.Resident
+	return query.FindInBatches(&residents, exportDomain.ExportBatchSize, func(tx *gorm.DB, batch int) error {
+		for _, resident := range residents {
+			if err := write([]any{
+				resident.ID,
+				resident.Block,
+				resident.Apartment,
+				resident.Name,
+				resident.Document,
+				resident.Email,
+				resident.Phone,
+				resident.ResidentType,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}).Error
+}
@@ -1,140 +1,103 @@
 package resident
 
 import (
-	"encoding/csv"
+	"errors"
 	"fmt"
-	"log"
-	"os"
-	"path/filepath"
+	importerDomain "portarius/internal/importer/domain"
 	"portarius/internal/resident/domain"
+	"portarius/internal/utils"
 	"strings"
 
 	"gorm.io/gorm"
 )
 
-type ResidentImportService struct {
-	db *gorm.DB
+// ResidentRowImporter imports residents, creating or updating them by document
+type ResidentRowImporter struct{}
+
+func NewResidentRowImporter() *ResidentRowImporter {
+	return &ResidentRowImporter{}
 }
 
-func NewResidentImportService(db *gorm.DB) *ResidentImportService {
-	return &ResidentImportService{db: db}
+func (i *ResidentRowImporter) Kind() importerDomain.ImportKind {
+	return importerDomain.ImportKindResidents
 }
 
-func (s *ResidentImportService) ImportResidentsFromCSV() error {
-	etlPath := filepath.Join("..", "resources", "ETL", "resident_inventory")
-	absPath, err := filepath.Abs(etlPath)
-	if err != nil {
-		return fmt.Errorf("error getting absolute path: %v", err)
+func (i *ResidentRowImporter) Columns() []importerDomain.Column {
+	return []importerDomain.Column{
+		{Field: "block", Aliases: []string{"Bloco"}, Required: true},
+		{Field: "apartment", Aliases: []string{"Apto", "Apartamento"}, Required: true},
+		{Field: "type", Aliases: []string{"Tipo"}},
+		{Field: "name", Aliases: []string{"Morador", "Nome"}, Required: true},
+		{Field: "document", Aliases: []string{"CPF", "Documento"}, Required: true},
+		{Field: "phone", Aliases: []string{"Telefone", "Celular"}},
+		{Field: "email", Aliases: []string{"Email", "E-mail"}},
 	}
+}
 
-	log.Printf("Looking for CSV files in directory: %s", absPath)
-
-	files, err := filepath.Glob(filepath.Join(absPath, "*.csv"))
+func (i *ResidentRowImporter) ImportRow(tx *gorm.DB, row importerDomain.Row) error {
+	resident, err := parseResident(row)
 	if err != nil {
-		return fmt.Errorf("error finding CSV files: %v", err)
+		return err
 	}
 
-	if len(files) == 0 {
-		return fmt.Errorf("no CSV files found in directory: %s", absPath)
-	}
-
-	log.Printf("Found %d CSV files to process", len(files))
-	for _, file := range files {
-		log.Printf("Processing file: %s", file)
-		if err := s.processCSVFile(file); err != nil {
-			log.Printf("Error processing file %s: %v", file, err)
+	var existing domain.Resident
+	err = tx.Where("document = ?", resident.Document).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		if err := tx.Create(&resident).Error; err != nil {
+			return fmt.Errorf("erro ao criar morador: %v", err)
 		}
+		return nil
+	}
+	if err != nil {
+		return err
 	}
 
+	if err := tx.Model(&existing).Updates(resident).Error; err != nil {
+		return fmt.Errorf("erro ao atualizar morador: %v", err)
+	}
 	return nil
 }
 
-func (s *ResidentImportService) processCSVFile(filePath string) error {
-	file, err := os.Open(filePath)
+func parseResident(row importerDomain.Row) (domain.Resident, error) {
+	name, err := row.Require("name", "o nome do morador")
 	if err != nil {
-		return fmt.Errorf("error opening file: %v", err)
+		return domain.Resident{}, err
 	}
-	defer file.Close()
 
-	reader := csv.NewReader(file)
-	reader.Comma = ','
-	reader.LazyQuotes = true
-
-	if _, err := reader.Read(); err != nil {
-		return fmt.Errorf("error reading header: %v", err)
+	document := utils.KeepOnlyNumbers(row.Get("document"))
+	if len(document) != 11 && len(document) != 14 {
+		return domain.Resident{}, fmt.Errorf("documento inválido: %s", row.Get("document"))
 	}
 
-	recordsProcessed := 0
-	for {
-		record, err := reader.Read()
-		if err != nil {
-			if err.Error() == "EOF" {
-				break
-			}
-			log.Printf("Error reading record: %v", err)
-			continue
-		}
-
-		resident, err := parseResident(record)
-		if err != nil {
-			log.Printf("Skipping invalid record: %v", err)
-			continue
-		}
-
-		if err := s.upsertResident(resident); err != nil {
-			log.Printf("Error upserting resident: %v", err)
-			continue
-		}
-
-		recordsProcessed++
+	block := strings.ToUpper(row.Get("block"))
+	if len(block) != 1 || block[0] < 'A' || block[0] > 'Z' {
+		return domain.Resident{}, fmt.Errorf("bloco inválido: %s", row.Get("block"))
 	}
 
-	log.Printf("Successfully processed %d records from file: %s", recordsProcessed, filePath)
-	return nil
-}
-
-func parseResident(record []string) (domain.Resident, error) {
-	if len(record) < 7 || strings.TrimSpace(record[3]) == "" {
-		return domain.Resident{}, fmt.Errorf("invalid record or missing name")
+	apartment := utils.KeepOnlyNumbers(row.Get("apartment"))
+	if apartment == "" || len(apartment) > 2 {
+		return domain.Resident{}, fmt.Errorf("apartamento inválido: %s", row.Get("apartment"))
 	}
 
 	residentType := domain.Tenant
-	if strings.TrimSpace(strings.ToUpper(record[2])) == "PROPRIETARIO" {
+	switch strings.ToUpper(row.Get("type")) {
+	case "", "INQUILINO":
+	case "PROPRIETARIO", "PROPRIETÁRIO":
 		residentType = domain.Owner
+	default:
+		return domain.Resident{}, fmt.Errorf("tipo de morador inválido: %s", row.Get("type"))
 	}
 
 	resident := domain.Resident{
-		Name:         strings.TrimSpace(record[3]),
-		Document:     strings.TrimSpace(record[4]),
-		Email:        strings.TrimSpace(record[6]),
-		Phone:        strings.TrimSpace(record[5]),
-		Apartment:    strings.TrimSpace(record[1]),
-		Block:        strings.TrimSpace(record[0]),
+		Name:         name,
+		Document:     document,
+		Email:        row.Get("email"),
+		Phone:        row.Get("phone"),
+		Apartment:    apartment,
+		Block:        block,
 		ResidentType: residentType,
 	}
 
 	resident.Normalise()
 	return resident, nil
 }
-
-func (s *ResidentImportService) upsertResident(resident domain.Resident) error {
-	var existing domain.Resident
-	result := s.db.Where("document = ?", resident.Document).First(&existing)
-	if result.Error != nil && result.Error != gorm.ErrRecordNotFound {
-		return result.Error
-	}
-
-	if result.Error == gorm.ErrRecordNotFound {
-		if err := s.db.Create(&resident).Error; err != nil {
-			return fmt.Errorf("error creating resident: %v", err)
-		}
-		log.Printf("Created new resident: %s", resident.Document)
-	} else {
-		if err := s.db.Model(&existing).Updates(resident).Error; err != nil {
-			return fmt.Errorf("error updating resident: %v", err)
-		}
-		log.Printf("Updated resident: %s", resident.Document)
-	}
-
-	return nil
-}
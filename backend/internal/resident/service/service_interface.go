@@ -1,5 +0,0 @@
-package resident
-
-type IResidentImporter interface {
-	ImportResidentsFromCSV() error
-}
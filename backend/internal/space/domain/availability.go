@@ -0,0 +1,117 @@
+package domain
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	"time"
+)
+
+type SlotStatus string
+
+const (
+	SlotFree    SlotStatus = "LIVRE"
+	SlotBusy    SlotStatus = "OCUPADO"
+	SlotBlocked SlotStatus = "BLOQUEADO"
+)
+
+const MaxAvailabilityDays = 366
+
+// AvailabilitySlot represents the availability of a space on a single day
+// swagger:model
+type AvailabilitySlot struct {
+	Date          string     `json:"date"`
+	Status        SlotStatus `json:"status"`
+	Price         float64    `json:"price"`
+	Holiday       string     `json:"holiday,omitempty"`
+	ReservationID *uint      `json:"reservation_id,omitempty"`
+	SeriesID      *uint      `json:"series_id,omitempty"`
+	Reason        string     `json:"reason,omitempty"`
+}
+
+// Availability represents the day by day availability of a space
+// swagger:model
+type Availability struct {
+	Space reservationDomain.SpaceType `json:"space"`
+	From  string                      `json:"from"`
+	To    string                      `json:"to"`
+	Slots []AvailabilitySlot          `json:"slots"`
+}
+
+// BuildAvailability lays out the days from "from" to "to". findHolidayFunc returns the name of the
+// holiday on a day, if there is one.
+func BuildAvailability(space reservationDomain.SpaceType, from, to time.Time, reservations []reservationDomain.Reservation, blackouts []SpaceBlackout, occurrences []reservationDomain.SeriesOccurrence, findHolidayFunc func(time.Time) (string, bool)) Availability {
+	from = reservationDomain.StartOfDay(from)
+	to = reservationDomain.StartOfDay(to)
+
+	availability := Availability{
+		Space: space,
+		From:  from.Format("2006-01-02"),
+		To:    to.Format("2006-01-02"),
+		Slots: []AvailabilitySlot{},
+	}
+
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		slot := AvailabilitySlot{
+			Date:   day.Format("2006-01-02"),
+			Status: SlotFree,
+			Price:  reservationDomain.GetPaymentAmountForDate(day),
+		}
+
+		if holiday, ok := findHolidayFunc(day); ok {
+			slot.Holiday = holiday
+		}
+
+		for _, blackout := range blackouts {
+			if blackout.Covers(day) {
+				slot.Status = SlotBlocked
+				slot.Reason = blackout.Reason
+				break
+			}
+		}
+
+		if slot.Status == SlotFree {
+			for i := range occurrences {
+				if !occurrenceCoversDay(&occurrences[i], day) {
+					continue
+				}
+				slot.SeriesID = &occurrences[i].SeriesID
+				slot.Reason = occurrences[i].Title
+				slot.Status = SlotBusy
+				if occurrences[i].Kind == reservationDomain.SeriesKindMaintenance {
+					slot.Status = SlotBlocked
+				}
+				break
+			}
+		}
+
+		if slot.Status == SlotFree {
+			for i := range reservations {
+				if reservationCoversDay(&reservations[i], day) {
+					slot.Status = SlotBusy
+					slot.ReservationID = &reservations[i].ID
+					break
+				}
+			}
+		}
+
+		availability.Slots = append(availability.Slots, slot)
+	}
+
+	return availability
+}
+
+func reservationCoversDay(reservation *reservationDomain.Reservation, day time.Time) bool {
+	if reservation.Status == reservationDomain.StatusCancelled {
+		return false
+	}
+
+	end := reservation.EndTime
+	if end.IsZero() {
+		end = reservation.StartTime
+	}
+
+	return !day.Before(reservationDomain.StartOfDay(reservation.StartTime)) && !day.After(reservationDomain.StartOfDay(end))
+}
+
+func occurrenceCoversDay(occurrence *reservationDomain.SeriesOccurrence, day time.Time) bool {
+	return !day.Before(reservationDomain.StartOfDay(occurrence.StartTime)) && !day.After(reservationDomain.StartOfDay(occurrence.EndTime))
+}
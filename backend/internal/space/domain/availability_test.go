@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	spaceDomain "portarius/internal/space/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func findHoliday(day time.Time) (string, bool) {
+	if day.Month() == time.November && day.Day() == 14 {
+		return "Aniversário de Cascavel", true
+	}
+	return "", false
+}
+
+func TestBuildAvailability(t *testing.T) {
+	from := time.Date(2025, time.November, 12, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC)
+
+	reservation := reservationDomain.Reservation{
+		Space:     reservationDomain.Salon1,
+		StartTime: time.Date(2025, time.November, 13, 8, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, time.November, 13, 20, 0, 0, 0, time.UTC),
+		Status:    reservationDomain.StatusConfirmed,
+	}
+	reservation.ID = 7
+
+	cancelled := reservationDomain.Reservation{
+		Space:     reservationDomain.Salon1,
+		StartTime: time.Date(2025, time.November, 12, 8, 0, 0, 0, time.UTC),
+		EndTime:   time.Date(2025, time.November, 12, 20, 0, 0, 0, time.UTC),
+		Status:    reservationDomain.StatusCancelled,
+	}
+
+	blackouts := []spaceDomain.SpaceBlackout{
+		{
+			Space:     reservationDomain.Salon1,
+			StartDate: time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC),
+			EndDate:   time.Date(2025, time.November, 15, 0, 0, 0, 0, time.UTC),
+			Reason:    "Manutenção",
+		},
+	}
+
+	availability := spaceDomain.BuildAvailability(reservationDomain.Salon1, from, to, []reservationDomain.Reservation{reservation, cancelled}, blackouts, nil, findHoliday)
+
+	assert.Equal(t, "2025-11-12", availability.From)
+	assert.Equal(t, "2025-11-15", availability.To)
+	assert.Len(t, availability.Slots, 4)
+
+	assert.Equal(t, spaceDomain.SlotFree, availability.Slots[0].Status)
+	assert.Equal(t, reservationDomain.CommonPaymentAmount, availability.Slots[0].Price)
+
+	assert.Equal(t, spaceDomain.SlotBusy, availability.Slots[1].Status)
+	assert.Equal(t, uint(7), *availability.Slots[1].ReservationID)
+
+	assert.Equal(t, spaceDomain.SlotFree, availability.Slots[2].Status)
+	assert.Equal(t, "Aniversário de Cascavel", availability.Slots[2].Holiday)
+	assert.Equal(t, reservationDomain.HolydayPaymentAmount, availability.Slots[2].Price)
+
+	assert.Equal(t, spaceDomain.SlotBlocked, availability.Slots[3].Status)
+	assert.Equal(t, "Manutenção", availability.Slots[3].Reason)
+}
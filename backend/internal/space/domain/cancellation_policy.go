@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"math"
+	reservationDomain "portarius/internal/reservation/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultFullRefundDaysBefore    = 7
+	DefaultPartialRefundPercentage = 50.0
+)
+
+// CancellationPolicy represents the refund rules applied when a reservation of a space is cancelled.
+// Cancelling at least FullRefundDaysBefore days ahead refunds everything, cancelling later refunds
+// PartialRefundPercentage and cancelling on the day of the event refunds nothing.
+// swagger:model
+type CancellationPolicy struct {
+	gorm.Model              `swaggerignore:"true"`
+	Space                   reservationDomain.SpaceType `json:"space" gorm:"type:varchar(10);not null;uniqueIndex"`
+	FullRefundDaysBefore    int                         `json:"full_refund_days_before" gorm:"not null;default:7"`
+	PartialRefundPercentage float64                     `json:"partial_refund_percentage" gorm:"type:decimal(5,2);not null;default:50"`
+}
+
+// RefundResult represents the outcome of applying a cancellation policy
+// swagger:model
+type RefundResult struct {
+	DaysBefore int     `json:"days_before"`
+	Percentage float64 `json:"percentage"`
+	Amount     float64 `json:"amount"`
+	Penalty    float64 `json:"penalty"`
+}
+
+func DefaultCancellationPolicy(space reservationDomain.SpaceType) *CancellationPolicy {
+	return &CancellationPolicy{
+		Space:                   space,
+		FullRefundDaysBefore:    DefaultFullRefundDaysBefore,
+		PartialRefundPercentage: DefaultPartialRefundPercentage,
+	}
+}
+
+func (p *CancellationPolicy) ComputeRefund(paidAmount float64, startTime, cancelledAt time.Time) RefundResult {
+	daysBefore := int(reservationDomain.StartOfDay(startTime).Sub(reservationDomain.StartOfDay(cancelledAt)).Hours() / 24)
+
+	var percentage float64
+	switch {
+	case daysBefore >= p.FullRefundDaysBefore:
+		percentage = 100
+	case daysBefore >= 1:
+		percentage = p.PartialRefundPercentage
+	default:
+		percentage = 0
+	}
+
+	amount := math.Round(paidAmount*percentage) / 100
+
+	return RefundResult{
+		DaysBefore: daysBefore,
+		Percentage: percentage,
+		Amount:     amount,
+		Penalty:    math.Round((paidAmount-amount)*100) / 100,
+	}
+}
@@ -0,0 +1,6 @@
+package domain
+
+type ICancellationPolicyRepository interface {
+	GetBySpace(space string) (*CancellationPolicy, error)
+	Save(policy *CancellationPolicy) error
+}
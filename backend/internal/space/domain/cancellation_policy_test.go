@@ -0,0 +1,49 @@
+package domain_test
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	spaceDomain "portarius/internal/space/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancellationPolicy_ComputeRefund(t *testing.T) {
+	policy := spaceDomain.DefaultCancellationPolicy(reservationDomain.Salon1)
+	start := time.Date(2025, time.June, 20, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		cancelledAt time.Time
+		amount      float64
+		penalty     float64
+	}{
+		{
+			name:        "should refund everything when cancelled ahead of the deadline",
+			cancelledAt: time.Date(2025, time.June, 13, 18, 0, 0, 0, time.UTC),
+			amount:      70,
+			penalty:     0,
+		},
+		{
+			name:        "should refund partially after the deadline",
+			cancelledAt: time.Date(2025, time.June, 18, 10, 0, 0, 0, time.UTC),
+			amount:      35,
+			penalty:     35,
+		},
+		{
+			name:        "should refund nothing on the day of the event",
+			cancelledAt: time.Date(2025, time.June, 20, 7, 0, 0, 0, time.UTC),
+			amount:      0,
+			penalty:     70,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := policy.ComputeRefund(reservationDomain.HolydayPaymentAmount, start, tt.cancelledAt)
+			assert.Equal(t, tt.amount, result.Amount)
+			assert.Equal(t, tt.penalty, result.Penalty)
+		})
+	}
+}
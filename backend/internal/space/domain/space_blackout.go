@@ -0,0 +1,23 @@
+package domain
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SpaceBlackout represents a period in which a space cannot be booked
+// swagger:model
+type SpaceBlackout struct {
+	gorm.Model `swaggerignore:"true"`
+	Space      reservationDomain.SpaceType `json:"space" gorm:"not null;type:varchar(10)"`
+	StartDate  time.Time                   `json:"start_date" gorm:"not null"`
+	EndDate    time.Time                   `json:"end_date" gorm:"not null"`
+	Reason     string                      `json:"reason" gorm:"type:text"`
+}
+
+func (b *SpaceBlackout) Covers(date time.Time) bool {
+	day := reservationDomain.StartOfDay(date)
+	return !day.Before(reservationDomain.StartOfDay(b.StartDate)) && !day.After(reservationDomain.StartOfDay(b.EndDate))
+}
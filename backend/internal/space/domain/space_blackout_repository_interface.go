@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+type ISpaceBlackoutRepository interface {
+	GetByID(id uint) (*SpaceBlackout, error)
+	GetBySpace(space string) ([]SpaceBlackout, error)
+	FindBySpaceAndDateRange(space string, startDate, endDate time.Time) ([]SpaceBlackout, error)
+	Create(blackout *SpaceBlackout) error
+	Delete(id uint) error
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+
+	"gorm.io/gorm"
+)
+
+// DefaultSpaceCapacity is the maximum number of guests of spaces that have no capacity configured yet.
+const DefaultSpaceCapacity = 50
+
+// DefaultSpaceRules is printed on rental terms of spaces that have no rules configured yet.
+const DefaultSpaceRules = `1. O morador é responsável pelo espaço e por seus convidados durante todo o período da reserva.
+2. O som deve respeitar o horário de silêncio do condomínio, das 22h às 8h.
+3. O espaço deve ser devolvido limpo e organizado, com o lixo recolhido.
+4. Danos ao espaço, aos móveis ou aos equipamentos serão cobrados do morador responsável.
+5. As chaves devem ser retiradas e devolvidas na portaria nos horários combinados.`
+
+// SpaceRules represents the usage rules of a space printed on the rental term signed by residents,
+// including the maximum number of guests allowed on the guest list of a reservation
+// swagger:model
+type SpaceRules struct {
+	gorm.Model `swaggerignore:"true"`
+	Space      reservationDomain.SpaceType `json:"space" gorm:"type:varchar(10);not null;uniqueIndex"`
+	Text       string                      `json:"text" gorm:"type:text;not null"`
+	Capacity   int                         `json:"capacity" gorm:"not null;default:50"`
+}
+
+func DefaultRules(space reservationDomain.SpaceType) *SpaceRules {
+	return &SpaceRules{
+		Space:    space,
+		Text:     DefaultSpaceRules,
+		Capacity: DefaultSpaceCapacity,
+	}
+}
@@ -0,0 +1,6 @@
+package domain
+
+type ISpaceRulesRepository interface {
+	GetBySpace(space string) (*SpaceRules, error)
+	Save(rules *SpaceRules) error
+}
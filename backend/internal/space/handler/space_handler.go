@@ -0,0 +1,383 @@
+package handler
+
+import (
+	"net/http"
+	holydayHandler "portarius/internal/holyday/handler"
+	reservationDomain "portarius/internal/reservation/domain"
+	"portarius/internal/space/domain"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SpaceHandler struct {
+	reservationRepo reservationDomain.IReservationRepository
+	blackoutRepo    domain.ISpaceBlackoutRepository
+	policyRepo      domain.ICancellationPolicyRepository
+	rulesRepo       domain.ISpaceRulesRepository
+	seriesRepo      reservationDomain.IReservationSeriesRepository
+}
+
+func NewSpaceHandler(reservationRepo reservationDomain.IReservationRepository, blackoutRepo domain.ISpaceBlackoutRepository, policyRepo domain.ICancellationPolicyRepository, rulesRepo domain.ISpaceRulesRepository, seriesRepo reservationDomain.IReservationSeriesRepository) *SpaceHandler {
+	return &SpaceHandler{
+		reservationRepo: reservationRepo,
+		blackoutRepo:    blackoutRepo,
+		policyRepo:      policyRepo,
+		rulesRepo:       rulesRepo,
+		seriesRepo:      seriesRepo,
+	}
+}
+
+// GetAvailability godoc
+// @Summary Get the availability calendar of a space
+// @Description Returns one slot per day between from and to (format: yyyy-MM-dd) with its status, price and holiday, taking reservations, recurring series and blackout dates into account
+// @Tags Spaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Param from query string true "Start date in format yyyy-MM-dd"
+// @Param to query string true "End date in format yyyy-MM-dd"
+// @Success 200 {object} domain.Availability
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /spaces/{id}/availability [get]
+func (c *SpaceHandler) GetAvailability(ctx *gin.Context) {
+	space := ctx.Param("id")
+	if !reservationDomain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	fromDate := ctx.Query("from")
+	toDate := ctx.Query("to")
+
+	if fromDate == "" || toDate == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data inicial e final são obrigatórias"})
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", fromDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data inicial inválida"})
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", toDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Data final inválida"})
+		return
+	}
+
+	if to.Before(from) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A data final deve ser posterior à data inicial"})
+		return
+	}
+
+	if to.Sub(from) > domain.MaxAvailabilityDays*24*time.Hour {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "O período consultado não pode ultrapassar um ano"})
+		return
+	}
+
+	endOfRange := to.AddDate(0, 0, 1)
+
+	reservations, err := c.reservationRepo.FindBySpaceAndDateRange(space, from, endOfRange)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	blackouts, err := c.blackoutRepo.FindBySpaceAndDateRange(space, from, endOfRange)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	series, err := c.seriesRepo.FindBySpace(space, from, endOfRange)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	occurrences := []reservationDomain.SeriesOccurrence{}
+	for _, s := range series {
+		occurrences = append(occurrences, s.Occurrences(from, endOfRange)...)
+	}
+
+	findHoliday := func(day time.Time) (string, bool) {
+		holiday, ok := holydayHandler.FindHolyday(day)
+		return holiday.Name, ok
+	}
+
+	ctx.JSON(http.StatusOK, domain.BuildAvailability(reservationDomain.SpaceType(space), from, to, reservations, blackouts, occurrences, findHoliday))
+}
+
+// GetBlackouts godoc
+// @Summary List blackout dates of a space
+// @Description Returns all periods in which the space cannot be booked
+// @Tags Spaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Success 200 {array} domain.SpaceBlackout
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /spaces/{id}/blackouts [get]
+func (c *SpaceHandler) GetBlackouts(ctx *gin.Context) {
+	space := ctx.Param("id")
+	if !reservationDomain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	blackouts, err := c.blackoutRepo.GetBySpace(space)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, blackouts)
+}
+
+// CreateBlackout godoc
+// @Summary Create a blackout period for a space
+// @Description Blocks the space between start_date and end_date so it shows as unavailable
+// @Tags Spaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Param blackout body domain.SpaceBlackout true "Blackout data"
+// @Success 201 {object} domain.SpaceBlackout
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /spaces/{id}/blackouts [post]
+func (c *SpaceHandler) CreateBlackout(ctx *gin.Context) {
+	space := ctx.Param("id")
+	if !reservationDomain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	var blackout domain.SpaceBlackout
+	if err := ctx.ShouldBindJSON(&blackout); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if blackout.StartDate.IsZero() || blackout.EndDate.IsZero() || blackout.EndDate.Before(blackout.StartDate) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Período de bloqueio inválido"})
+		return
+	}
+
+	blackout.Space = reservationDomain.SpaceType(space)
+
+	if err := c.blackoutRepo.Create(&blackout); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, blackout)
+}
+
+// DeleteBlackout godoc
+// @Summary Delete a blackout period
+// @Description Removes a blackout period from a space
+// @Tags Spaces
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Param blackoutId path int true "Blackout ID"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 500
+// @Router /spaces/{id}/blackouts/{blackoutId} [delete]
+func (c *SpaceHandler) DeleteBlackout(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("blackoutId"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	blackout, err := c.blackoutRepo.GetByID(uint(id))
+	if err != nil || string(blackout.Space) != ctx.Param("id") {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Bloqueio não encontrado"})
+		return
+	}
+
+	if err := c.blackoutRepo.Delete(blackout.ID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Bloqueio excluído com sucesso"})
+}
+
+// GetCancellationPolicy godoc
+// @Summary Get the cancellation policy of a space
+// @Description Returns the refund rules applied when a reservation of the space is cancelled
+// @Tags Spaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Success 200 {object} domain.CancellationPolicy
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /spaces/{id}/cancellation-policy [get]
+func (c *SpaceHandler) GetCancellationPolicy(ctx *gin.Context) {
+	space := ctx.Param("id")
+	if !reservationDomain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	policy, err := c.policyRepo.GetBySpace(space)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// UpdateCancellationPolicy godoc
+// @Summary Update the cancellation policy of a space
+// @Description Sets how many days before the event a cancellation is fully refunded and the percentage refunded after that
+// @Tags Spaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Param policy body domain.CancellationPolicy true "Cancellation policy"
+// @Success 200 {object} domain.CancellationPolicy
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /spaces/{id}/cancellation-policy [put]
+func (c *SpaceHandler) UpdateCancellationPolicy(ctx *gin.Context) {
+	space := ctx.Param("id")
+	if !reservationDomain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	var input domain.CancellationPolicy
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if input.FullRefundDaysBefore < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "O prazo para reembolso integral deve ser de pelo menos 1 dia"})
+		return
+	}
+
+	if input.PartialRefundPercentage < 0 || input.PartialRefundPercentage > 100 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "O percentual de reembolso parcial deve estar entre 0 e 100"})
+		return
+	}
+
+	policy, err := c.policyRepo.GetBySpace(space)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	policy.FullRefundDaysBefore = input.FullRefundDaysBefore
+	policy.PartialRefundPercentage = input.PartialRefundPercentage
+
+	if err := c.policyRepo.Save(policy); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, policy)
+}
+
+// GetRules godoc
+// @Summary Get the usage rules of a space
+// @Description Returns the rules printed on the rental term of the space and its guest capacity
+// @Tags Spaces
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Success 200 {object} domain.SpaceRules
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /spaces/{id}/rules [get]
+func (c *SpaceHandler) GetRules(ctx *gin.Context) {
+	space := ctx.Param("id")
+	if !reservationDomain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	rules, err := c.rulesRepo.GetBySpace(space)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
+
+// UpdateRules godoc
+// @Summary Update the usage rules of a space
+// @Description Sets the rules printed on the rental term of the space and its guest capacity
+// @Tags Spaces
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Space type" Enums(SALAO_1,SALAO_2)
+// @Param rules body domain.SpaceRules true "Space rules"
+// @Success 200 {object} domain.SpaceRules
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /spaces/{id}/rules [put]
+func (c *SpaceHandler) UpdateRules(ctx *gin.Context) {
+	space := ctx.Param("id")
+	if !reservationDomain.IsValidSpaceType(space) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Espaço inválido"})
+		return
+	}
+
+	var input domain.SpaceRules
+	if err := ctx.ShouldBindJSON(&input); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if strings.TrimSpace(input.Text) == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "As regras do espaço são obrigatórias"})
+		return
+	}
+
+	if input.Capacity < 1 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A capacidade do espaço deve ser de pelo menos 1 convidado"})
+		return
+	}
+
+	rules, err := c.rulesRepo.GetBySpace(space)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	rules.Text = strings.TrimSpace(input.Text)
+	rules.Capacity = input.Capacity
+
+	if err := c.rulesRepo.Save(rules); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, rules)
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"errors"
+	reservationDomain "portarius/internal/reservation/domain"
+	"portarius/internal/space/domain"
+
+	"gorm.io/gorm"
+)
+
+type cancellationPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewCancellationPolicyRepository(db *gorm.DB) domain.ICancellationPolicyRepository {
+	return &cancellationPolicyRepository{db: db}
+}
+
+// GetBySpace returns the policy configured for the space, or the default policy when none was saved yet.
+func (r *cancellationPolicyRepository) GetBySpace(space string) (*domain.CancellationPolicy, error) {
+	var policy domain.CancellationPolicy
+	err := r.db.Where("space = ?", space).First(&policy).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.DefaultCancellationPolicy(reservationDomain.SpaceType(space)), nil
+	}
+	return &policy, err
+}
+
+func (r *cancellationPolicyRepository) Save(policy *domain.CancellationPolicy) error {
+	return r.db.Save(policy).Error
+}
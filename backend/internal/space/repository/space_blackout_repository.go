@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"portarius/internal/space/domain"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type spaceBlackoutRepository struct {
+	db *gorm.DB
+}
+
+func NewSpaceBlackoutRepository(db *gorm.DB) domain.ISpaceBlackoutRepository {
+	return &spaceBlackoutRepository{db: db}
+}
+
+func (r *spaceBlackoutRepository) GetByID(id uint) (*domain.SpaceBlackout, error) {
+	var blackout domain.SpaceBlackout
+	err := r.db.First(&blackout, id).Error
+	return &blackout, err
+}
+
+func (r *spaceBlackoutRepository) GetBySpace(space string) ([]domain.SpaceBlackout, error) {
+	var blackouts []domain.SpaceBlackout
+	err := r.db.Where("space = ?", space).Order("start_date ASC").Find(&blackouts).Error
+	return blackouts, err
+}
+
+func (r *spaceBlackoutRepository) FindBySpaceAndDateRange(space string, startDate, endDate time.Time) ([]domain.SpaceBlackout, error) {
+	var blackouts []domain.SpaceBlackout
+	err := r.db.
+		Where("space = ? AND start_date <= ? AND end_date >= ?", space, endDate, startDate).
+		Order("start_date ASC").
+		Find(&blackouts).Error
+	return blackouts, err
+}
+
+func (r *spaceBlackoutRepository) Create(blackout *domain.SpaceBlackout) error {
+	return r.db.Create(blackout).Error
+}
+
+func (r *spaceBlackoutRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.SpaceBlackout{}, id).Error
+}
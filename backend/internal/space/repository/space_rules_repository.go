@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"errors"
+	reservationDomain "portarius/internal/reservation/domain"
+	"portarius/internal/space/domain"
+
+	"gorm.io/gorm"
+)
+
+type spaceRulesRepository struct {
+	db *gorm.DB
+}
+
+func NewSpaceRulesRepository(db *gorm.DB) domain.ISpaceRulesRepository {
+	return &spaceRulesRepository{db: db}
+}
+
+// GetBySpace returns the rules configured for the space, or the default rules when none were saved yet.
+func (r *spaceRulesRepository) GetBySpace(space string) (*domain.SpaceRules, error) {
+	var rules domain.SpaceRules
+	err := r.db.Where("space = ?", space).First(&rules).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return domain.DefaultRules(reservationDomain.SpaceType(space)), nil
+	}
+	return &rules, err
+}
+
+func (r *spaceRulesRepository) Save(rules *domain.SpaceRules) error {
+	return r.db.Save(rules).Error
+}
@@ -0,0 +1,36 @@
+package routes
+
+import (
+	reservationDomain "portarius/internal/reservation/domain"
+	reservationRepository "portarius/internal/reservation/repository"
+	"portarius/internal/space/domain"
+	spaceHandler "portarius/internal/space/handler"
+	"portarius/internal/space/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterSpaceRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	var (
+		reservationRepo reservationDomain.IReservationRepository       = reservationRepository.NewReservationRepository(db)
+		blackoutRepo    domain.ISpaceBlackoutRepository                = repository.NewSpaceBlackoutRepository(db)
+		policyRepo      domain.ICancellationPolicyRepository           = repository.NewCancellationPolicyRepository(db)
+		rulesRepo       domain.ISpaceRulesRepository                   = repository.NewSpaceRulesRepository(db)
+		seriesRepo      reservationDomain.IReservationSeriesRepository = reservationRepository.NewReservationSeriesRepository(db)
+	)
+
+	handler := spaceHandler.NewSpaceHandler(reservationRepo, blackoutRepo, policyRepo, rulesRepo, seriesRepo)
+
+	spaces := router.Group("/spaces")
+	{
+		spaces.GET("/:id/availability", handler.GetAvailability)
+		spaces.GET("/:id/blackouts", handler.GetBlackouts)
+		spaces.POST("/:id/blackouts", handler.CreateBlackout)
+		spaces.DELETE("/:id/blackouts/:blackoutId", handler.DeleteBlackout)
+		spaces.GET("/:id/cancellation-policy", handler.GetCancellationPolicy)
+		spaces.PUT("/:id/cancellation-policy", handler.UpdateCancellationPolicy)
+		spaces.GET("/:id/rules", handler.GetRules)
+		spaces.PUT("/:id/rules", handler.UpdateRules)
+	}
+}
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// DefaultURLTTL is how long a download link stays valid when STORAGE_URL_TTL_MINUTES is not set
+const DefaultURLTTL = 15 * time.Minute
+
+// URLSigner issues download links that work without the bearer token, such as in <img> tags, but
+// only for the path they were issued for and until they expire
+type URLSigner struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+func NewURLSigner(secret string, ttl time.Duration) *URLSigner {
+	if ttl <= 0 {
+		ttl = DefaultURLTTL
+	}
+	return &URLSigner{secret: []byte(secret), ttl: ttl}
+}
+
+// NewURLSignerFromEnv signs with STORAGE_URL_SECRET, falling back to JWT_SECRET. Without either one
+// anybody could forge download links, so it fails instead of signing with an empty key.
+func NewURLSignerFromEnv() (*URLSigner, error) {
+	secret := os.Getenv("STORAGE_URL_SECRET")
+	if secret == "" {
+		secret = os.Getenv("JWT_SECRET")
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("defina STORAGE_URL_SECRET ou JWT_SECRET para assinar os links de download")
+	}
+
+	ttl := DefaultURLTTL
+	if minutes, err := strconv.Atoi(os.Getenv("STORAGE_URL_TTL_MINUTES")); err == nil && minutes > 0 {
+		ttl = time.Duration(minutes) * time.Minute
+	}
+
+	return NewURLSigner(secret, ttl), nil
+}
+
+// Sign returns the path with the expires and signature query parameters
+func (s *URLSigner) Sign(path string, now time.Time) string {
+	expires := now.Add(s.ttl).Unix()
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", s.signature(path, expires))
+	return path + "?" + query.Encode()
+}
+
+// Verify checks the parameters of a link issued by Sign for the path
+func (s *URLSigner) Verify(path, expires, signature string, now time.Time) error {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || signature == "" {
+		return fmt.Errorf("link de download inválido")
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(path, expiresAt))) {
+		return fmt.Errorf("link de download inválido")
+	}
+
+	if now.Unix() > expiresAt {
+		return fmt.Errorf("link de download expirado")
+	}
+
+	return nil
+}
+
+func (s *URLSigner) signature(path string, expires int64) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "%s\n%d", path, expires)
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("arquivo não encontrado")
+	ErrInvalidKey = errors.New("chave de arquivo inválida")
+)
+
+// Storage keeps uploaded files under keys such as "packages/12/photo.jpg". Backends are chosen with
+// STORAGE_DRIVER: "local" (default) writes below UPLOADS_DIR and "s3" talks to any S3-compatible
+// service, such as AWS S3 or MinIO.
+type Storage interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// NewFromEnv builds the storage configured in the environment
+func NewFromEnv() (Storage, error) {
+	switch strings.ToLower(os.Getenv("STORAGE_DRIVER")) {
+	case "", "local":
+		dir := os.Getenv("UPLOADS_DIR")
+		if dir == "" {
+			dir = "uploads"
+		}
+		return NewLocalStorage(dir), nil
+	case "s3":
+		return NewS3Storage(S3Config{
+			Endpoint:     os.Getenv("S3_ENDPOINT"),
+			Region:       os.Getenv("S3_REGION"),
+			Bucket:       os.Getenv("S3_BUCKET"),
+			AccessKey:    os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:    os.Getenv("S3_SECRET_KEY"),
+			VirtualHosts: os.Getenv("S3_VIRTUAL_HOSTS") == "true",
+		})
+	default:
+		return nil, fmt.Errorf("STORAGE_DRIVER inválido: %s", os.Getenv("STORAGE_DRIVER"))
+	}
+}
+
+// CleanKey rejects keys that are empty, absolute or escape the storage root
+func CleanKey(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, "\\") {
+		return "", ErrInvalidKey
+	}
+
+	cleaned := path.Clean(key)
+	if cleaned != key || cleaned == "." || strings.HasPrefix(cleaned, "../") || cleaned == ".." {
+		return "", ErrInvalidKey
+	}
+
+	return cleaned, nil
+}
@@ -0,0 +1,103 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+// ThumbnailSize is the longest side, in pixels, of generated thumbnails
+const ThumbnailSize = 320
+
+// thumbnailSamples is how many source pixels per axis are averaged into each thumbnail pixel; enough
+// to avoid aliasing without visiting every pixel of a large photo
+const thumbnailSamples = 4
+
+// MaxImagePixels is the largest image, in pixels, that is decoded for a thumbnail; a small file can
+// declare huge dimensions, and decoding it would allocate width*height*4 bytes
+const MaxImagePixels = 40_000_000
+
+var ErrImageTooLarge = errors.New("imagem com resolução muito alta")
+
+// Thumbnail decodes a JPEG, PNG or GIF image and returns a JPEG whose longest side is at most maxSize.
+// Transparent areas, as in signatures drawn on a canvas, are painted white.
+func Thumbnail(data []byte, maxSize int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("imagem inválida: %v", err)
+	}
+	if config.Width*config.Height > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	source, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("imagem inválida: %v", err)
+	}
+
+	bounds := source.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("imagem vazia")
+	}
+
+	targetWidth, targetHeight := width, height
+	if width > maxSize || height > maxSize {
+		if width >= height {
+			targetWidth = maxSize
+			targetHeight = max(1, height*maxSize/width)
+		} else {
+			targetHeight = maxSize
+			targetWidth = max(1, width*maxSize/height)
+		}
+	}
+
+	thumbnail := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	for y := 0; y < targetHeight; y++ {
+		top := bounds.Min.Y + y*height/targetHeight
+		bottom := max(top+1, bounds.Min.Y+(y+1)*height/targetHeight)
+
+		for x := 0; x < targetWidth; x++ {
+			left := bounds.Min.X + x*width/targetWidth
+			right := max(left+1, bounds.Min.X+(x+1)*width/targetWidth)
+
+			thumbnail.Set(x, y, averageOnWhite(source, left, top, right, bottom))
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, thumbnail, &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+func averageOnWhite(source image.Image, left, top, right, bottom int) color.RGBA {
+	stepX := max(1, (right-left)/thumbnailSamples)
+	stepY := max(1, (bottom-top)/thumbnailSamples)
+
+	var red, green, blue, count uint64
+	for y := top; y < bottom; y += stepY {
+		for x := left; x < right; x += stepX {
+			r, g, b, a := source.At(x, y).RGBA()
+			// Colors are premultiplied by alpha, so adding the missing coverage as white blends onto white
+			white := 0xffff - a
+			red += uint64(r + white)
+			green += uint64(g + white)
+			blue += uint64(b + white)
+			count++
+		}
+	}
+
+	return color.RGBA{
+		R: uint8(red / count >> 8),
+		G: uint8(green / count >> 8),
+		B: uint8(blue / count >> 8),
+		A: 0xff,
+	}
+}
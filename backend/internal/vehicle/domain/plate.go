@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+type PlateFormat string
+
+const (
+	PlateFormatOld      PlateFormat = "ANTIGA"
+	PlateFormatMercosul PlateFormat = "MERCOSUL"
+)
+
+var ErrInvalidPlate = errors.New("placa inválida: use o formato ABC1234 ou o Mercosul ABC1D23")
+
+var (
+	oldPlatePattern      = regexp.MustCompile(`^[A-Z]{3}[0-9]{4}$`)
+	mercosulPlatePattern = regexp.MustCompile(`^[A-Z]{3}[0-9][A-Z][0-9]{2}$`)
+)
+
+// NormalizePlate keeps only letters and digits in upper case, so "abc-1234" and "ABC 1234" match
+func NormalizePlate(plate string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToUpper(plate) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+// ParsePlate normalizes a plate and tells its format, rejecting anything that is neither the old
+// Brazilian format nor the Mercosul one
+func ParsePlate(value string) (string, PlateFormat, error) {
+	plate := NormalizePlate(value)
+	switch {
+	case oldPlatePattern.MatchString(plate):
+		return plate, PlateFormatOld, nil
+	case mercosulPlatePattern.MatchString(plate):
+		return plate, PlateFormatMercosul, nil
+	default:
+		return "", "", ErrInvalidPlate
+	}
+}
+
+// PlateVariants returns the plate in both formats. A vehicle registered with its old plate keeps the
+// same letters and digits when converted to Mercosul, except the fifth character, which becomes a
+// letter (0 is A, 1 is B and so on), so ABC1234 and ABC1C34 are the same vehicle.
+func PlateVariants(plate string) []string {
+	plate, format, err := ParsePlate(plate)
+	if err != nil {
+		return nil
+	}
+
+	fifth := plate[4]
+	converted := []byte(plate)
+	if format == PlateFormatOld {
+		converted[4] = 'A' + (fifth - '0')
+		return []string{plate, string(converted)}
+	}
+
+	if fifth > 'J' {
+		return []string{plate}
+	}
+	converted[4] = '0' + (fifth - 'A')
+	return []string{plate, string(converted)}
+}
+
+// FormatPlate writes the plate the way it is shown: ABC-1234 for the old format, ABC1D23 for Mercosul
+func FormatPlate(plate string) string {
+	plate, format, err := ParsePlate(plate)
+	if err != nil {
+		return plate
+	}
+	if format == PlateFormatOld {
+		return plate[:3] + "-" + plate[3:]
+	}
+	return plate
+}
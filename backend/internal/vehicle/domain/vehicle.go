@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"fmt"
+	residentDomain "portarius/internal/resident/domain"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type VehicleType string
+
+const (
+	VehicleTypeCar        VehicleType = "CARRO"
+	VehicleTypeMotorcycle VehicleType = "MOTO"
+	VehicleTypeTruck      VehicleType = "CAMINHONETE"
+	VehicleTypeOther      VehicleType = "OUTRO"
+)
+
+// Vehicle represents a car or motorcycle of a resident, allowed in the garage. The plate is unique
+// among vehicles not deleted, so a plate can be registered again after its vehicle is removed.
+// swagger:model
+type Vehicle struct {
+	gorm.Model  `swaggerignore:"true"`
+	Plate       string                   `json:"plate" gorm:"type:varchar(7);not null;uniqueIndex:idx_vehicles_plate_active,where:deleted_at IS NULL"`
+	PlateFormat PlateFormat              `json:"plate_format" gorm:"type:varchar(10);not null"`
+	Type        VehicleType              `json:"type" gorm:"type:varchar(15);not null;default:'CARRO'"`
+	Make        string                   `json:"make" gorm:"type:varchar(50)"`
+	ModelName   string                   `json:"model" gorm:"type:varchar(50)"`
+	Color       string                   `json:"color" gorm:"type:varchar(30)"`
+	Notes       string                   `json:"notes"`
+	OwnerID     *uint                    `json:"owner_id" gorm:"not null;index" binding:"required"`
+	Owner       *residentDomain.Resident `json:"owner,omitempty" gorm:"foreignKey:OwnerID" swaggerignore:"true"`
+}
+
+// VehicleLookup represents the answer given to the gate for a plate: the vehicle with its unit and
+// resident when registered, or Known false for an unknown vehicle
+// swagger:model
+type VehicleLookup struct {
+	Plate     string                   `json:"plate"`
+	Known     bool                     `json:"known"`
+	Vehicle   *Vehicle                 `json:"vehicle,omitempty"`
+	Unit      string                   `json:"unit,omitempty"`
+	Resident  *residentDomain.Resident `json:"resident,omitempty"`
+	Converted bool                     `json:"converted"`
+}
+
+func ParseVehicleType(value string) (VehicleType, error) {
+	vehicleType := VehicleType(strings.ToUpper(strings.TrimSpace(value)))
+	switch vehicleType {
+	case "":
+		return VehicleTypeCar, nil
+	case VehicleTypeCar, VehicleTypeMotorcycle, VehicleTypeTruck, VehicleTypeOther:
+		return vehicleType, nil
+	default:
+		return "", fmt.Errorf("tipo de veículo inválido: %s", value)
+	}
+}
+
+// Normalize validates the plate and type and tidies the free text fields before the vehicle is saved
+func (v *Vehicle) Normalize() error {
+	plate, format, err := ParsePlate(v.Plate)
+	if err != nil {
+		return err
+	}
+	vehicleType, err := ParseVehicleType(string(v.Type))
+	if err != nil {
+		return err
+	}
+
+	v.Plate = plate
+	v.PlateFormat = format
+	v.Type = vehicleType
+	v.Make = strings.Join(strings.Fields(v.Make), " ")
+	v.ModelName = strings.Join(strings.Fields(v.ModelName), " ")
+	v.Color = strings.ToLower(strings.Join(strings.Fields(v.Color), " "))
+	v.Notes = strings.TrimSpace(v.Notes)
+	return nil
+}
+
+// NewVehicleLookup builds the gate answer for the plate read at the gate; vehicle is nil when no
+// registered vehicle has the plate in either format
+func NewVehicleLookup(plate string, vehicle *Vehicle) VehicleLookup {
+	lookup := VehicleLookup{Plate: plate}
+	if vehicle == nil {
+		return lookup
+	}
+
+	lookup.Known = true
+	lookup.Vehicle = vehicle
+	lookup.Converted = vehicle.Plate != plate
+	if vehicle.Owner != nil {
+		lookup.Resident = vehicle.Owner
+		lookup.Unit = fmt.Sprintf("Bloco %s - Apto %s", vehicle.Owner.Block, vehicle.Owner.Apartment)
+	}
+	return lookup
+}
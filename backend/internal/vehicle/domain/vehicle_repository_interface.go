@@ -0,0 +1,10 @@
+package domain
+
+type IVehicleRepository interface {
+	GetAll(page, pageSize int, ownerID *uint, plate string) ([]Vehicle, error)
+	GetByID(id uint) (*Vehicle, error)
+	FindByPlates(plates []string) (*Vehicle, error)
+	Create(vehicle *Vehicle) error
+	Update(vehicle *Vehicle) error
+	Delete(id uint) error
+}
@@ -0,0 +1,78 @@
+package domain_test
+
+import (
+	residentDomain "portarius/internal/resident/domain"
+	"portarius/internal/vehicle/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestParsePlate(t *testing.T) {
+	tests := []struct {
+		value  string
+		plate  string
+		format domain.PlateFormat
+	}{
+		{"abc-1234", "ABC1234", domain.PlateFormatOld},
+		{" ABC 1234 ", "ABC1234", domain.PlateFormatOld},
+		{"abc1d23", "ABC1D23", domain.PlateFormatMercosul},
+		{"BRA-2E19", "BRA2E19", domain.PlateFormatMercosul},
+	}
+
+	for _, test := range tests {
+		plate, format, err := domain.ParsePlate(test.value)
+		require.NoError(t, err, test.value)
+		assert.Equal(t, test.plate, plate)
+		assert.Equal(t, test.format, format)
+	}
+
+	for _, value := range []string{"", "AB1234", "ABCD123", "1BC1234", "ABC12D3", "ABC-12345", "ÁBC1234"} {
+		_, _, err := domain.ParsePlate(value)
+		assert.ErrorIs(t, err, domain.ErrInvalidPlate, value)
+	}
+}
+
+func TestPlateVariants(t *testing.T) {
+	assert.Equal(t, []string{"ABC1234", "ABC1C34"}, domain.PlateVariants("abc-1234"))
+	assert.Equal(t, []string{"ABC1C34", "ABC1234"}, domain.PlateVariants("ABC1C34"))
+	assert.Equal(t, []string{"ABC1J34", "ABC1934"}, domain.PlateVariants("ABC1J34"))
+	assert.Equal(t, []string{"ABC1K34"}, domain.PlateVariants("ABC1K34"))
+	assert.Nil(t, domain.PlateVariants("invalid"))
+
+	assert.Equal(t, "ABC-1234", domain.FormatPlate("abc1234"))
+	assert.Equal(t, "ABC1D23", domain.FormatPlate("abc-1d23"))
+}
+
+func TestVehicleNormalize(t *testing.T) {
+	vehicle := domain.Vehicle{Plate: "abc-1d23", Make: " Volkswagen ", ModelName: "Gol  1.0", Color: " Prata "}
+	require.NoError(t, vehicle.Normalize())
+	assert.Equal(t, "ABC1D23", vehicle.Plate)
+	assert.Equal(t, domain.PlateFormatMercosul, vehicle.PlateFormat)
+	assert.Equal(t, domain.VehicleTypeCar, vehicle.Type)
+	assert.Equal(t, "Volkswagen", vehicle.Make)
+	assert.Equal(t, "Gol 1.0", vehicle.ModelName)
+	assert.Equal(t, "prata", vehicle.Color)
+
+	assert.ErrorIs(t, (&domain.Vehicle{Plate: "123"}).Normalize(), domain.ErrInvalidPlate)
+	assert.Error(t, (&domain.Vehicle{Plate: "ABC1234", Type: "AVIAO"}).Normalize())
+}
+
+func TestNewVehicleLookup(t *testing.T) {
+	unknown := domain.NewVehicleLookup("XYZ9A99", nil)
+	assert.False(t, unknown.Known)
+	assert.Nil(t, unknown.Vehicle)
+
+	owner := &residentDomain.Resident{Model: gorm.Model{ID: 3}, Name: "Ana", Block: "B", Apartment: "12"}
+	vehicle := &domain.Vehicle{Plate: "ABC1234", Owner: owner}
+
+	lookup := domain.NewVehicleLookup("ABC1C34", vehicle)
+	assert.True(t, lookup.Known)
+	assert.True(t, lookup.Converted)
+	assert.Equal(t, "Bloco B - Apto 12", lookup.Unit)
+	assert.Equal(t, owner, lookup.Resident)
+
+	assert.False(t, domain.NewVehicleLookup("ABC1234", vehicle).Converted)
+}
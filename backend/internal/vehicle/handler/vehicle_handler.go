@@ -0,0 +1,270 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	residentDomain "portarius/internal/resident/domain"
+	"portarius/internal/vehicle/domain"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type VehicleHandler struct {
+	repo         domain.IVehicleRepository
+	residentRepo residentDomain.IResidentRepository
+}
+
+func NewVehicleHandler(repo domain.IVehicleRepository, residentRepo residentDomain.IResidentRepository) *VehicleHandler {
+	return &VehicleHandler{repo: repo, residentRepo: residentRepo}
+}
+
+// GetAll 	godoc
+// @Summary List vehicles
+// @Description Get paginated list of the residents' vehicles ordered by plate, optionally of one resident or with plates starting with the given characters
+// @Tags Vehicles
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param pageSize query int false "Items per page" minimum(1) maximum(100) default(10)
+// @Param ownerId query int false "Resident ID"
+// @Param plate query string false "Start of the plate"
+// @Success 200 {array} domain.Vehicle
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /vehicles [get]
+func (c *VehicleHandler) GetAll(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.Query("page"))
+	pageSize, _ := strconv.Atoi(ctx.Query("pageSize"))
+
+	var ownerID *uint
+	if value := ctx.Query("ownerId"); value != "" {
+		id, err := strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID do morador inválido"})
+			return
+		}
+		owner := uint(id)
+		ownerID = &owner
+	}
+
+	vehicles, err := c.repo.GetAll(page, pageSize, ownerID, domain.NormalizePlate(ctx.Query("plate")))
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, vehicles)
+}
+
+// GetByID 	godoc
+// @Summary Get vehicle by ID
+// @Description Get a vehicle with its owner
+// @Tags Vehicles
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Vehicle ID"
+// @Success 200 {object} domain.Vehicle
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Router /vehicles/{id} [get]
+func (c *VehicleHandler) GetByID(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	vehicle, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Veículo não encontrado"})
+		return
+	}
+	ctx.JSON(http.StatusOK, vehicle)
+}
+
+// Create 	godoc
+// @Summary Register a vehicle
+// @Description Registers a car or motorcycle of a resident. The plate is validated in the old (ABC1234) or Mercosul (ABC1D23) format and stored without dashes or spaces; a plate already registered in either format is rejected.
+// @Tags Vehicles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param vehicle body domain.Vehicle true "Vehicle"
+// @Success 201 {object} domain.Vehicle
+// @Failure 400
+// @Failure 401
+// @Failure 409
+// @Failure 500
+// @Router /vehicles [post]
+func (c *VehicleHandler) Create(ctx *gin.Context) {
+	var vehicle domain.Vehicle
+	if err := ctx.ShouldBindJSON(&vehicle); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !c.validate(ctx, &vehicle) {
+		return
+	}
+
+	if err := c.repo.Create(&vehicle); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, vehicle)
+}
+
+// Update 	godoc
+// @Summary Update a vehicle
+// @Description Updates a vehicle, validating the plate like the registration
+// @Tags Vehicles
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Vehicle ID"
+// @Param vehicle body domain.Vehicle true "Vehicle"
+// @Success 200 {object} domain.Vehicle
+// @Failure 400
+// @Failure 401
+// @Failure 404
+// @Failure 409
+// @Failure 500
+// @Router /vehicles/{id} [put]
+func (c *VehicleHandler) Update(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	existing, err := c.repo.GetByID(uint(id))
+	if err != nil {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Veículo não encontrado"})
+		return
+	}
+
+	var vehicle domain.Vehicle
+	if err := ctx.ShouldBindJSON(&vehicle); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	vehicle.Model = existing.Model
+	if !c.validate(ctx, &vehicle) {
+		return
+	}
+
+	if err := c.repo.Update(&vehicle); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, vehicle)
+}
+
+// Delete 	godoc
+// @Summary Delete a vehicle
+// @Description Removes a vehicle that no longer uses the garage
+// @Tags Vehicles
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Vehicle ID"
+// @Success 200
+// @Failure 400
+// @Failure 401
+// @Failure 500
+// @Router /vehicles/{id} [delete]
+func (c *VehicleHandler) Delete(ctx *gin.Context) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 32)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "ID inválido"})
+		return
+	}
+
+	if err := c.repo.Delete(uint(id)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Veículo excluído com sucesso"})
+}
+
+// Lookup 	godoc
+// @Summary Look up a plate at the gate
+// @Description Finds the vehicle with the plate read at the gate and returns the unit and resident it belongs to. A plate converted from the old format to Mercosul matches the vehicle registered with the other one (converted is true). Unknown vehicles, and plates that are not valid Brazilian plates, are answered with known false.
+// @Tags Vehicles
+// @Produce json
+// @Security BearerAuth
+// @Param plate query string true "Vehicle plate"
+// @Success 200 {object} domain.VehicleLookup
+// @Failure 401
+// @Failure 500
+// @Router /vehicles/lookup [get]
+func (c *VehicleHandler) Lookup(ctx *gin.Context) {
+	// a plate misread at the gate is answered as an unknown vehicle, like any plate not registered
+	plate, _, err := domain.ParsePlate(ctx.Query("plate"))
+	if err != nil {
+		ctx.JSON(http.StatusOK, domain.NewVehicleLookup(domain.NormalizePlate(ctx.Query("plate")), nil))
+		return
+	}
+
+	vehicle, err := c.repo.FindByPlates(domain.PlateVariants(plate))
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		vehicle = nil
+	}
+
+	ctx.JSON(http.StatusOK, domain.NewVehicleLookup(plate, vehicle))
+}
+
+// ListVehicleTypes 	godoc
+// @Summary List vehicle types
+// @Description List available vehicle types
+// @Tags Vehicles
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} domain.VehicleType
+// @Router /vehicles/types [get]
+func (c *VehicleHandler) ListVehicleTypes(ctx *gin.Context) {
+	types := []domain.VehicleType{
+		domain.VehicleTypeCar,
+		domain.VehicleTypeMotorcycle,
+		domain.VehicleTypeTruck,
+		domain.VehicleTypeOther,
+	}
+	ctx.JSON(http.StatusOK, types)
+}
+
+// validate normalizes the vehicle and checks its owner exists and no other vehicle has the plate
+func (c *VehicleHandler) validate(ctx *gin.Context, vehicle *domain.Vehicle) bool {
+	if err := vehicle.Normalize(); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return false
+	}
+
+	if vehicle.OwnerID == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Informe o morador dono do veículo"})
+		return false
+	}
+	owner, err := c.residentRepo.GetByID(*vehicle.OwnerID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Morador não encontrado"})
+		return false
+	}
+	vehicle.Owner = owner
+
+	registered, err := c.repo.FindByPlates(domain.PlateVariants(vehicle.Plate))
+	switch {
+	case err == nil && registered.ID != vehicle.ID:
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Placa já cadastrada para outro veículo: " + domain.FormatPlate(registered.Plate)})
+		return false
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound):
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	infra "portarius/internal/infra"
+	"portarius/internal/vehicle/domain"
+
+	"gorm.io/gorm"
+)
+
+type vehicleRepository struct {
+	db *gorm.DB
+}
+
+func NewVehicleRepository(db *gorm.DB) domain.IVehicleRepository {
+	return &vehicleRepository{db: db}
+}
+
+// GetAll lists the vehicles by plate, optionally of one resident or with plates starting with plate
+func (r *vehicleRepository) GetAll(page, pageSize int, ownerID *uint, plate string) ([]domain.Vehicle, error) {
+	var vehicles []domain.Vehicle
+	query := r.db.Preload("Owner").Scopes(infra.Paginate(page, pageSize)).Order("plate")
+	if ownerID != nil {
+		query = query.Where("owner_id = ?", *ownerID)
+	}
+	if plate != "" {
+		query = query.Where("plate LIKE ?", plate+"%")
+	}
+	err := query.Find(&vehicles).Error
+	return vehicles, err
+}
+
+func (r *vehicleRepository) GetByID(id uint) (*domain.Vehicle, error) {
+	var vehicle domain.Vehicle
+	err := r.db.Preload("Owner").First(&vehicle, id).Error
+	return &vehicle, err
+}
+
+// FindByPlates returns the vehicle registered with any of the plates, such as the old and Mercosul
+// forms of the same plate
+func (r *vehicleRepository) FindByPlates(plates []string) (*domain.Vehicle, error) {
+	var vehicle domain.Vehicle
+	err := r.db.Preload("Owner").Where("plate IN ?", plates).First(&vehicle).Error
+	return &vehicle, err
+}
+
+func (r *vehicleRepository) Create(vehicle *domain.Vehicle) error {
+	return r.db.Omit("Owner").Create(vehicle).Error
+}
+
+func (r *vehicleRepository) Update(vehicle *domain.Vehicle) error {
+	return r.db.Omit("Owner").Save(vehicle).Error
+}
+
+func (r *vehicleRepository) Delete(id uint) error {
+	return r.db.Delete(&domain.Vehicle{}, id).Error
+}
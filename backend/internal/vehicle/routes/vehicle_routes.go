@@ -0,0 +1,32 @@
+package routes
+
+import (
+	residentDomain "portarius/internal/resident/domain"
+	residentRepository "portarius/internal/resident/repository"
+	"portarius/internal/vehicle/domain"
+	vehicleHandler "portarius/internal/vehicle/handler"
+	"portarius/internal/vehicle/repository"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+func RegisterVehicleRoutes(router *gin.RouterGroup, db *gorm.DB) {
+	var (
+		repo         domain.IVehicleRepository          = repository.NewVehicleRepository(db)
+		residentRepo residentDomain.IResidentRepository = residentRepository.NewResidentRepository(db)
+	)
+
+	handler := vehicleHandler.NewVehicleHandler(repo, residentRepo)
+
+	vehicles := router.Group("/vehicles")
+	{
+		vehicles.GET("/", handler.GetAll)
+		vehicles.POST("/", handler.Create)
+		vehicles.GET("/lookup", handler.Lookup)
+		vehicles.GET("/types", handler.ListVehicleTypes)
+		vehicles.GET("/:id", handler.GetByID)
+		vehicles.PUT("/:id", handler.Update)
+		vehicles.DELETE("/:id", handler.Delete)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"os"
 )
 
@@ -31,9 +33,15 @@ type Component struct {
 }
 
 type Param struct {
-	Type  string `json:"type"`
-	Text  string `json:"text,omitempty"`
-	Value string `json:"value,omitempty"`
+	Type     string         `json:"type"`
+	Text     string         `json:"text,omitempty"`
+	Value    string         `json:"value,omitempty"`
+	Document *DocumentMedia `json:"document,omitempty"`
+}
+
+type DocumentMedia struct {
+	ID       string `json:"id"`
+	Filename string `json:"filename"`
 }
 
 type Template struct {
@@ -87,3 +95,55 @@ func (s *WhatsAppService) SendMessage(message WhatsAppMessage) error {
 	message.PublishReminderSentEvent()
 	return nil
 }
+
+// UploadMedia sends a file to the WhatsApp media endpoint and returns the media ID used to attach it to messages.
+func (s *WhatsAppService) UploadMedia(content []byte, fileName, contentType string) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	if err := writer.WriteField("messaging_product", "whatsapp"); err != nil {
+		return "", fmt.Errorf("error writing media form: %v", err)
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, fileName))
+	header.Set("Content-Type", contentType)
+
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return "", fmt.Errorf("error writing media form: %v", err)
+	}
+	if _, err := part.Write(content); err != nil {
+		return "", fmt.Errorf("error writing media form: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("error writing media form: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", s.apiBaseURL+"/media", &body)
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %v", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error uploading media: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error response from WhatsApp media API: %d", resp.StatusCode)
+	}
+
+	var media struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&media); err != nil {
+		return "", fmt.Errorf("error decoding media response: %v", err)
+	}
+	return media.ID, nil
+}
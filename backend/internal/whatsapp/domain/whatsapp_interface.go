@@ -1,6 +1,13 @@
 package domain
 
 type IWhatsAppHandler interface {
-	SendPackageNotification(reminderID uint, phone, name string) error
+	SendPackageNotification(reminderID uint, phone, name, pickupCode string) error
+	SendPackageBatchNotification(reminderID uint, phone, name string, count int, pickupCode string) error
 	SendReservationKeyReminder(reminderID uint, phone, name, hall string) error
+	SendReservationPixPayment(reminderID uint, phone, name, hall, date, amount, payload string) error
+	SendReservationInspectionReport(reminderID uint, phone, name, hall, findings, charges string) error
+	SendReservationKeysOverdue(reminderID uint, phone, name, hall string, hoursOverdue int) error
+	SendReservationDocument(reminderID uint, phone, name, hall, documentLabel, fileName string, content []byte) error
+	SendReservationUnlistedGuest(reminderID uint, phone, name, hall, guest string) error
+	SendStaffKeysOverdueAlert(reminderID uint, phone, name, unit, hall string, hoursOverdue, alertLevel int) error
 }
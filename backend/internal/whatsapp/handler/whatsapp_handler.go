@@ -2,6 +2,7 @@ package handler
 
 import (
 	"portarius/internal/whatsapp/domain"
+	"strconv"
 )
 
 type WhatsAppHandler struct {
@@ -46,14 +47,319 @@ func (h *WhatsAppHandler) SendReservationKeyReminder(reminderId uint, phone, nam
 	return h.WhatsAppService.SendMessage(message)
 }
 
-func (h *WhatsAppHandler) SendPackageNotification(reminderId uint, phone, name string) error {
+// SendPackageNotification tells the resident a package arrived. Packages registered with a pickup code
+// use the template that carries the code the resident must show at the front desk.
+func (h *WhatsAppHandler) SendPackageNotification(reminderId uint, phone, name, pickupCode string) error {
+	templateName := "package_notification"
+	parameters := []domain.Param{
+		{
+			Type: "text",
+			Text: name,
+		},
+	}
+
+	if pickupCode != "" {
+		templateName = "package_notification_pickup_code"
+		parameters = append(parameters, domain.Param{
+			Type: "text",
+			Text: pickupCode,
+		})
+	}
+
+	message := domain.WhatsAppMessage{
+		ReminderID:       reminderId,
+		MessagingProduct: "whatsapp",
+		To:               phone,
+		Type:             "template",
+		Template: domain.Template{
+			Name: templateName,
+			Language: domain.Language{
+				Code: "pt_BR",
+			},
+			Components: []domain.Component{
+				{
+					Type:       "body",
+					Parameters: parameters,
+				},
+			},
+		},
+	}
+
+	return h.WhatsAppService.SendMessage(message)
+}
+
+// SendPackageBatchNotification tells the resident how many packages arrived in a single delivery,
+// with the pickup code shared by all of them
+func (h *WhatsAppHandler) SendPackageBatchNotification(reminderId uint, phone, name string, count int, pickupCode string) error {
+	templateName := "package_batch_notification"
+	parameters := []domain.Param{
+		{
+			Type: "text",
+			Text: name,
+		},
+		{
+			Type: "text",
+			Text: strconv.Itoa(count),
+		},
+	}
+
+	if pickupCode != "" {
+		templateName = "package_batch_notification_pickup_code"
+		parameters = append(parameters, domain.Param{
+			Type: "text",
+			Text: pickupCode,
+		})
+	}
+
+	message := domain.WhatsAppMessage{
+		ReminderID:       reminderId,
+		MessagingProduct: "whatsapp",
+		To:               phone,
+		Type:             "template",
+		Template: domain.Template{
+			Name: templateName,
+			Language: domain.Language{
+				Code: "pt_BR",
+			},
+			Components: []domain.Component{
+				{
+					Type:       "body",
+					Parameters: parameters,
+				},
+			},
+		},
+	}
+
+	return h.WhatsAppService.SendMessage(message)
+}
+
+func (h *WhatsAppHandler) SendReservationInspectionReport(reminderId uint, phone, name, hall, findings, charges string) error {
+	message := domain.WhatsAppMessage{
+		ReminderID:       reminderId,
+		MessagingProduct: "whatsapp",
+		To:               phone,
+		Type:             "template",
+		Template: domain.Template{
+			Name: "reservation_inspection_report",
+			Language: domain.Language{
+				Code: "pt_BR",
+			},
+			Components: []domain.Component{
+				{
+					Type: "body",
+					Parameters: []domain.Param{
+						{
+							Type: "text",
+							Text: name,
+						},
+						{
+							Type: "text",
+							Text: hall,
+						},
+						{
+							Type: "text",
+							Text: findings,
+						},
+						{
+							Type: "text",
+							Text: charges,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return h.WhatsAppService.SendMessage(message)
+}
+
+func (h *WhatsAppHandler) SendReservationKeysOverdue(reminderId uint, phone, name, hall string, hoursOverdue int) error {
+	message := domain.WhatsAppMessage{
+		ReminderID:       reminderId,
+		MessagingProduct: "whatsapp",
+		To:               phone,
+		Type:             "template",
+		Template: domain.Template{
+			Name: "reservation_keys_overdue",
+			Language: domain.Language{
+				Code: "pt_BR",
+			},
+			Components: []domain.Component{
+				{
+					Type: "body",
+					Parameters: []domain.Param{
+						{
+							Type: "text",
+							Text: name,
+						},
+						{
+							Type: "text",
+							Text: hall,
+						},
+						{
+							Type: "text",
+							Text: strconv.Itoa(hoursOverdue),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return h.WhatsAppService.SendMessage(message)
+}
+
+func (h *WhatsAppHandler) SendStaffKeysOverdueAlert(reminderId uint, phone, name, unit, hall string, hoursOverdue, alertLevel int) error {
+	message := domain.WhatsAppMessage{
+		ReminderID:       reminderId,
+		MessagingProduct: "whatsapp",
+		To:               phone,
+		Type:             "template",
+		Template: domain.Template{
+			Name: "staff_keys_overdue_alert",
+			Language: domain.Language{
+				Code: "pt_BR",
+			},
+			Components: []domain.Component{
+				{
+					Type: "body",
+					Parameters: []domain.Param{
+						{
+							Type: "text",
+							Text: name,
+						},
+						{
+							Type: "text",
+							Text: unit,
+						},
+						{
+							Type: "text",
+							Text: hall,
+						},
+						{
+							Type: "text",
+							Text: strconv.Itoa(hoursOverdue),
+						},
+						{
+							Type: "text",
+							Text: strconv.Itoa(alertLevel),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return h.WhatsAppService.SendMessage(message)
+}
+
+func (h *WhatsAppHandler) SendReservationPixPayment(reminderId uint, phone, name, hall, date, amount, payload string) error {
+	message := domain.WhatsAppMessage{
+		ReminderID:       reminderId,
+		MessagingProduct: "whatsapp",
+		To:               phone,
+		Type:             "template",
+		Template: domain.Template{
+			Name: "reservation_confirmation_pix",
+			Language: domain.Language{
+				Code: "pt_BR",
+			},
+			Components: []domain.Component{
+				{
+					Type: "body",
+					Parameters: []domain.Param{
+						{
+							Type: "text",
+							Text: name,
+						},
+						{
+							Type: "text",
+							Text: hall,
+						},
+						{
+							Type: "text",
+							Text: date,
+						},
+						{
+							Type: "text",
+							Text: amount,
+						},
+						{
+							Type: "text",
+							Text: payload,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	return h.WhatsAppService.SendMessage(message)
+}
+
+func (h *WhatsAppHandler) SendReservationDocument(reminderId uint, phone, name, hall, documentLabel, fileName string, content []byte) error {
+	message := domain.WhatsAppMessage{
+		ReminderID:       reminderId,
+		MessagingProduct: "whatsapp",
+		To:               phone,
+		Type:             "template",
+	}
+
+	mediaID, err := h.WhatsAppService.UploadMedia(content, fileName, "application/pdf")
+	if err != nil {
+		message.PublishReminderFailedEvent()
+		return err
+	}
+
+	message.Template = domain.Template{
+		Name: "reservation_document",
+		Language: domain.Language{
+			Code: "pt_BR",
+		},
+		Components: []domain.Component{
+			{
+				Type: "header",
+				Parameters: []domain.Param{
+					{
+						Type: "document",
+						Document: &domain.DocumentMedia{
+							ID:       mediaID,
+							Filename: fileName,
+						},
+					},
+				},
+			},
+			{
+				Type: "body",
+				Parameters: []domain.Param{
+					{
+						Type: "text",
+						Text: name,
+					},
+					{
+						Type: "text",
+						Text: documentLabel,
+					},
+					{
+						Type: "text",
+						Text: hall,
+					},
+				},
+			},
+		},
+	}
+
+	return h.WhatsAppService.SendMessage(message)
+}
+
+func (h *WhatsAppHandler) SendReservationUnlistedGuest(reminderId uint, phone, name, hall, guest string) error {
 	message := domain.WhatsAppMessage{
 		ReminderID:       reminderId,
 		MessagingProduct: "whatsapp",
 		To:               phone,
 		Type:             "template",
 		Template: domain.Template{
-			Name: "package_notification",
+			Name: "reservation_unlisted_guest",
 			Language: domain.Language{
 				Code: "pt_BR",
 			},
@@ -65,6 +371,14 @@ func (h *WhatsAppHandler) SendPackageNotification(reminderId uint, phone, name s
 							Type: "text",
 							Text: name,
 						},
+						{
+							Type: "text",
+							Text: guest,
+						},
+						{
+							Type: "text",
+							Text: hall,
+						},
 					},
 				},
 			},
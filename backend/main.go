@@ -21,6 +21,7 @@ import (
 	middleware "portarius/internal/middleware/auth"
 
 	"portarius/internal/infra"
+	fileStorage "portarius/internal/storage"
 
 	reminderListeners "portarius/internal/reminder/listeners"
 	"portarius/internal/reminder/scheduler"
@@ -32,6 +33,7 @@ import (
 	packageRepository "portarius/internal/package/repository"
 
 	reservationRepository "portarius/internal/reservation/repository"
+	reservationScheduler "portarius/internal/reservation/scheduler"
 
 	inventoryRoutes "portarius/internal/inventory/routes"
 
@@ -45,6 +47,32 @@ import (
 
 	reminderRoutes "portarius/internal/reminder/routes"
 
+	spaceRoutes "portarius/internal/space/routes"
+
+	calendarRoutes "portarius/internal/calendar/routes"
+
+	financeRoutes "portarius/internal/finance/routes"
+
+	reconciliationRoutes "portarius/internal/reconciliation/routes"
+
+	boletoRoutes "portarius/internal/boleto/routes"
+
+	documentListeners "portarius/internal/document/listeners"
+	documentRoutes "portarius/internal/document/routes"
+	documentService "portarius/internal/document/service"
+
+	financeRepository "portarius/internal/finance/repository"
+
+	spaceRepository "portarius/internal/space/repository"
+
+	guestRoutes "portarius/internal/guest/routes"
+
+	importerRoutes "portarius/internal/importer/routes"
+
+	exportRoutes "portarius/internal/export/routes"
+
+	vehicleRoutes "portarius/internal/vehicle/routes"
+
 	whatsappDomain "portarius/internal/whatsapp/domain"
 	"portarius/internal/whatsapp/handler"
 )
@@ -83,6 +111,16 @@ func main() {
 
 	infra.RunMigrations(db)
 
+	fileStore, err := fileStorage.NewFromEnv()
+	if err != nil {
+		log.Fatal("Failed to configure file storage:", err)
+	}
+
+	urlSigner, err := fileStorage.NewURLSignerFromEnv()
+	if err != nil {
+		log.Fatal("Failed to configure download links:", err)
+	}
+
 	reminderRepo := reminderRepository.NewReminderRepository(db)
 	residentRepo := residentRepository.NewResidentRepository(db)
 	packageRepo := packageRepository.NewPackageRepository(db)
@@ -94,10 +132,23 @@ func main() {
 
 	reminderListeners.RegisterReminderListeners(reminderRepo, residentRepo, packageRepo, reservationRepo, whatsappHandler)
 
+	reservationDocumentService := documentService.NewReservationDocumentService(
+		reservationRepo,
+		financeRepository.NewFinancialEntryRepository(db),
+		spaceRepository.NewSpaceRulesRepository(db),
+		spaceRepository.NewCancellationPolicyRepository(db),
+	)
+
+	documentListeners.RegisterDocumentListeners(reservationDocumentService)
+
 	reminderScheduler := scheduler.NewReminderScheduler(reminderRepo)
 
 	reminderScheduler.Run()
 
+	overdueKeysScheduler := reservationScheduler.NewOverdueKeysScheduler(reservationRepo)
+
+	overdueKeysScheduler.Run()
+
 	r := gin.Default()
 
 	r.Use(cors.New(cors.Config{
@@ -114,15 +165,27 @@ func main() {
 	apiPrefixGroup := r.Group("/api")
 
 	userRoutes.RegisterUserRoutes(apiPrefixGroup, db)
+	calendarRoutes.RegisterCalendarRoutes(apiPrefixGroup, db)
+	packageRoutes.RegisterPackagePublicRoutes(apiPrefixGroup, db, fileStore, urlSigner)
 
 	apiPrefixGroup.Use(middleware.AuthMiddleware())
 	{
 		inventoryRoutes.RegisterInventoryRoutes(apiPrefixGroup, db)
 		residentRoutes.ResidentRegisterRoutes(apiPrefixGroup, db)
-		packageRoutes.RegisterPackageRoutes(apiPrefixGroup, db)
-		reservationRoutes.RegisterReservationRoutes(apiPrefixGroup, db)
+		packageRoutes.RegisterPackageRoutes(apiPrefixGroup, db, fileStore, urlSigner)
+		reservationRoutes.RegisterReservationRoutes(apiPrefixGroup, db, fileStore)
 		userRoutes.RegisterUserProtectedRoutes(apiPrefixGroup, db)
 		reminderRoutes.RegisterReminderProtectedRoutes(apiPrefixGroup, db)
+		spaceRoutes.RegisterSpaceRoutes(apiPrefixGroup, db)
+		calendarRoutes.RegisterCalendarProtectedRoutes(apiPrefixGroup, db)
+		financeRoutes.RegisterFinanceRoutes(apiPrefixGroup, db)
+		reconciliationRoutes.RegisterReconciliationRoutes(apiPrefixGroup, db)
+		boletoRoutes.RegisterBoletoRoutes(apiPrefixGroup, db)
+		documentRoutes.RegisterDocumentRoutes(apiPrefixGroup, reservationDocumentService)
+		guestRoutes.RegisterGuestRoutes(apiPrefixGroup, db)
+		importerRoutes.RegisterImportRoutes(apiPrefixGroup, db)
+		exportRoutes.RegisterExportRoutes(apiPrefixGroup, db)
+		vehicleRoutes.RegisterVehicleRoutes(apiPrefixGroup, db)
 	}
 
 	port := os.Getenv("PORT")